@@ -0,0 +1,275 @@
+// Package blockquery reads the blocks and transactions collected in the block database
+// (see interchaintest.InterchainBuildOptions.BlockDatabaseFile), so that a test can assert on them after running.
+package blockquery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// DB reads the blocks of a single test case.
+type DB struct {
+	db         *sql.DB
+	q          *blockdb.Query
+	testCaseID int64
+}
+
+// Open opens the block database at databasePath, reading the most recent test case named testName,
+// i.e. the TestName of the interchaintest.InterchainBuildOptions.
+//
+// Blocks are collected in the background while the chains run, polling every 100ms;
+// wait for a block after the last transaction of interest before querying it.
+func Open(ctx context.Context, databasePath, testName string) (*DB, error) {
+	// blockdb.ConnectDB would create a missing database.
+	if _, err := os.Stat(databasePath); err != nil {
+		return nil, err
+	}
+	db, err := blockdb.ConnectDB(ctx, databasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait on the collectors writing to the database instead of failing.
+	if _, err := db.ExecContext(ctx, `PRAGMA busy_timeout = 4000`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("pragma busy_timeout: %w", err)
+	}
+
+	q := blockdb.NewQuery(db)
+	id, err := q.TestCaseID(ctx, testName)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &DB{db: db, q: q, testCaseID: id}, nil
+}
+
+// Close closes the database.
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// TestCaseID is the test_case_id of the test case in the database views,
+// to scope the queries passed to SQL.
+func (db *DB) TestCaseID() int64 {
+	return db.testCaseID
+}
+
+type Tx struct {
+	Height int64
+	// Data is the tx as JSON for Cosmos and EVM chains.
+	Data []byte
+	// Code is non-zero if the tx failed: the ABCI result code for Cosmos chains, 1 for reverted EVM txs.
+	Code   uint32
+	Events []Event
+}
+
+// Failed reports whether the tx failed.
+func (tx Tx) Failed() bool {
+	return tx.Code != 0
+}
+
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+type EventAttribute struct {
+	Key, Value string
+}
+
+// Txs returns the txs of the chain with their events, ordered by height.
+// Cosmos chains also have a tx per block with begin and end block events.
+func (db *DB) Txs(ctx context.Context, chainID string) ([]Tx, error) {
+	chainPkey, err := db.q.ChainPkey(ctx, db.testCaseID, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := db.q.Transactions(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	events, err := db.q.TxEvents(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+
+	byID := make(map[int64]int, len(results))
+	txs := make([]Tx, len(results))
+	for i, res := range results {
+		byID[res.ID] = i
+		txs[i] = Tx{
+			Height: res.Height,
+			Data:   res.Tx,
+			Code:   uint32(res.Code),
+		}
+	}
+	for _, e := range events {
+		i, ok := byID[e.TxID]
+		if !ok {
+			continue
+		}
+		event := Event{Type: e.Type}
+		for _, attr := range e.Attributes {
+			event.Attributes = append(event.Attributes, EventAttribute{Key: attr.Key, Value: attr.Value})
+		}
+		txs[i].Events = append(txs[i].Events, event)
+	}
+	return txs, nil
+}
+
+// FailedTxs returns the txs of the chain that failed.
+func (db *DB) FailedTxs(ctx context.Context, chainID string) ([]Tx, error) {
+	txs, err := db.Txs(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	var failed []Tx
+	for _, tx := range txs {
+		if tx.Failed() {
+			failed = append(failed, tx)
+		}
+	}
+	return failed, nil
+}
+
+type CosmosMessage struct {
+	Height int64
+	// Index is the message's position within its tx.
+	Index int
+	// Type is the URI of the message's proto definition, e.g. /ibc.applications.transfer.v1.MsgTransfer.
+	Type string
+	// Sender is the sender, from_address, delegator_address or signer of the message, if any.
+	Sender string
+	// TxFailed reports whether the message's tx failed.
+	TxFailed bool
+	// Raw is the message as JSON.
+	Raw json.RawMessage
+}
+
+// CosmosMessages returns the messages of the chain's txs ordered by height,
+// only those of msgType unless it is empty.
+func (db *DB) CosmosMessages(ctx context.Context, chainID, msgType string) ([]CosmosMessage, error) {
+	chainPkey, err := db.q.ChainPkey(ctx, db.testCaseID, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := db.q.CosmosMessages(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query cosmos messages: %w", err)
+	}
+
+	var msgs []CosmosMessage
+	for _, res := range results {
+		if msgType != "" && res.Type != msgType {
+			continue
+		}
+		msgs = append(msgs, CosmosMessage{
+			Height:   res.Height,
+			Index:    res.Index,
+			Type:     res.Type,
+			Sender:   res.Sender.String,
+			TxFailed: res.TxCode != 0,
+			Raw:      json.RawMessage(res.Raw),
+		})
+	}
+	return msgs, nil
+}
+
+// Statuses of a Packet.
+const (
+	PacketSent         = "sent"
+	PacketReceived     = "received"
+	PacketAcknowledged = "acknowledged"
+	PacketTimedOut     = "timed out"
+)
+
+// Packet is the lifecycle of an IBC packet across the chains of the test case.
+// Heights are 0 until the corresponding event is collected.
+type Packet struct {
+	Sequence   uint64
+	SrcChainID string
+	SrcPort    string
+	SrcChannel string
+	// DstChainID is empty until the packet is received.
+	DstChainID string
+	DstPort    string
+	DstChannel string
+	Status     string
+
+	SendHeight     int64
+	RecvHeight     int64
+	WriteAckHeight int64
+	AckHeight      int64
+	TimeoutHeight  int64
+
+	// Latencies from the time of the send_packet block, at millisecond precision.
+	// CompleteLatency is until the packet is acknowledged or timed out. Both are 0 until then.
+	RecvLatency     time.Duration
+	CompleteLatency time.Duration
+
+	// Data and Ack are the packet_data and packet_ack event attributes,
+	// or their hex encoded variants if only those are emitted.
+	Data string
+	Ack  string
+}
+
+// Packets returns the IBC packets sent by the chains of the test case, ordered by send height.
+func (db *DB) Packets(ctx context.Context) ([]Packet, error) {
+	results, err := db.q.Packets(ctx, db.testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query packets: %w", err)
+	}
+
+	packets := make([]Packet, len(results))
+	for i, res := range results {
+		packets[i] = Packet{
+			Sequence:        uint64(res.Sequence),
+			SrcChainID:      res.SrcChainID,
+			SrcPort:         res.SrcPort,
+			SrcChannel:      res.SrcChannel,
+			DstChainID:      res.DstChainID.String,
+			DstPort:         res.DstPort,
+			DstChannel:      res.DstChannel,
+			Status:          res.Status,
+			SendHeight:      res.SendHeight,
+			RecvHeight:      res.RecvHeight.Int64,
+			WriteAckHeight:  res.WriteAckHeight.Int64,
+			AckHeight:       res.AckHeight.Int64,
+			TimeoutHeight:   res.TimeoutHeight.Int64,
+			RecvLatency:     time.Duration(res.RecvLatency.Int64) * time.Millisecond,
+			CompleteLatency: time.Duration(res.CompleteLatency.Int64) * time.Millisecond,
+			Data:            res.Data.String,
+			Ack:             res.Ack.String,
+		}
+	}
+	return packets, nil
+}
+
+// Result is the result of a free-form query.
+type Result struct {
+	Columns []string
+	// Rows have a value per column: nil, int64, float64, string or []byte.
+	Rows [][]any
+}
+
+// SQL runs a free-form read-only query against the database, such as a SELECT on the v_tx_flattened,
+// v_cosmos_messages, v_evm_txs, v_evm_logs and v_ibc_packets views.
+// Only a single SELECT, WITH, EXPLAIN or VALUES statement is accepted; statements that write fail.
+//
+// The database may hold other test cases: filter on the test_case_id column with TestCaseID.
+func (db *DB) SQL(ctx context.Context, query string, args ...any) (Result, error) {
+	res, err := db.q.SQL(ctx, query, args...)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Columns: res.Columns, Rows: res.Rows}, nil
+}
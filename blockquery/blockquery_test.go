@@ -0,0 +1,165 @@
+package blockquery
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+const transferTx = `{"body":{"messages":[
+  {"@type":"/ibc.applications.transfer.v1.MsgTransfer","sender":"cosmos1sender","source_port":"transfer","source_channel":"channel-0"},
+  {"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1other"}
+]}}`
+
+var sendPacketAttrs = []blockdb.EventAttribute{
+	{Key: "packet_sequence", Value: "1"},
+	{Key: "packet_src_port", Value: "transfer"},
+	{Key: "packet_src_channel", Value: "channel-0"},
+	{Key: "packet_dst_port", Value: "transfer"},
+	{Key: "packet_dst_channel", Value: "channel-1"},
+}
+
+func TestDB(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "blocks.db")
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, blockdb.Migrate(db, "abc123"))
+
+	// An earlier run of the same test, and another test, must not be read.
+	_, err = db.Exec(`INSERT INTO test_case(name, created_at, git_sha) VALUES ('TestIBC', '2022-06-01T00:00:00Z', 'abc123')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO chain(chain_id, chain_type, fk_test_id) VALUES ('gaia-1', 'cosmos', last_insert_rowid())`)
+	require.NoError(t, err)
+
+	other, err := blockdb.CreateTestCase(ctx, db, "TestOther", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "gaia-1", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, []blockdb.Tx{{Data: []byte(transferTx), Code: 5}}))
+
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestIBC", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "gaia-1", "cosmos")
+	require.NoError(t, err)
+	sentAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, chain.SaveBlockAt(ctx, 2, sentAt, []blockdb.Tx{
+		{
+			Data: []byte(transferTx),
+			Events: []blockdb.Event{
+				{Type: "send_packet", Attributes: sendPacketAttrs},
+				{Type: "empty"},
+			},
+		},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 3, []blockdb.Tx{
+		{Data: []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1sender"}]}}`), Code: 11},
+	}))
+	dst, err := tc.AddChain(ctx, "juno-1", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, dst.SaveBlockAt(ctx, 7, sentAt.Add(2500*time.Millisecond), []blockdb.Tx{
+		{
+			Data:   []byte(`{"body":{"messages":[]}}`),
+			Events: []blockdb.Event{{Type: "recv_packet", Attributes: sendPacketAttrs}},
+		},
+	}))
+	require.NoError(t, db.Close())
+
+	bq, err := Open(ctx, dbPath, "TestIBC")
+	require.NoError(t, err)
+	defer bq.Close()
+
+	t.Run("txs", func(t *testing.T) {
+		txs, err := bq.Txs(ctx, "gaia-1")
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+
+		require.EqualValues(t, 2, txs[0].Height)
+		require.False(t, txs[0].Failed())
+		require.Equal(t, []Event{
+			{Type: "send_packet", Attributes: []EventAttribute{
+				{Key: "packet_sequence", Value: "1"},
+				{Key: "packet_src_port", Value: "transfer"},
+				{Key: "packet_src_channel", Value: "channel-0"},
+				{Key: "packet_dst_port", Value: "transfer"},
+				{Key: "packet_dst_channel", Value: "channel-1"},
+			}},
+			{Type: "empty"},
+		}, txs[0].Events)
+
+		require.EqualValues(t, 3, txs[1].Height)
+		require.True(t, txs[1].Failed())
+		require.Empty(t, txs[1].Events)
+
+		failed, err := bq.FailedTxs(ctx, "gaia-1")
+		require.NoError(t, err)
+		require.Len(t, failed, 1)
+		require.EqualValues(t, 11, failed[0].Code)
+	})
+
+	t.Run("cosmos messages", func(t *testing.T) {
+		msgs, err := bq.CosmosMessages(ctx, "gaia-1", "/ibc.applications.transfer.v1.MsgTransfer")
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.EqualValues(t, 2, msgs[0].Height)
+		require.Equal(t, "cosmos1sender", msgs[0].Sender)
+		require.False(t, msgs[0].TxFailed)
+		require.JSONEq(t, `{"@type":"/ibc.applications.transfer.v1.MsgTransfer","sender":"cosmos1sender","source_port":"transfer","source_channel":"channel-0"}`, string(msgs[0].Raw))
+
+		msgs, err = bq.CosmosMessages(ctx, "gaia-1", "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 3)
+		require.Equal(t, "cosmos1other", msgs[1].Sender)
+		require.True(t, msgs[2].TxFailed)
+	})
+
+	t.Run("packets", func(t *testing.T) {
+		packets, err := bq.Packets(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []Packet{{
+			Sequence:    1,
+			SrcChainID:  "gaia-1",
+			SrcPort:     "transfer",
+			SrcChannel:  "channel-0",
+			DstChainID:  "juno-1",
+			DstPort:     "transfer",
+			DstChannel:  "channel-1",
+			Status:      PacketReceived,
+			SendHeight:  2,
+			RecvHeight:  7,
+			RecvLatency: 2500 * time.Millisecond,
+		}}, packets)
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		res, err := bq.SQL(ctx, `SELECT block_height, tx_code FROM v_tx_flattened WHERE test_case_id = ? AND chain_id = 'gaia-1' ORDER BY block_height`, bq.TestCaseID())
+		require.NoError(t, err)
+		require.Equal(t, []string{"block_height", "tx_code"}, res.Columns)
+		require.Equal(t, [][]any{{int64(2), int64(0)}, {int64(3), int64(11)}}, res.Rows)
+
+		// Writes are rejected.
+		_, err = bq.SQL(ctx, `DELETE FROM tx`)
+		require.Error(t, err)
+		_, err = bq.SQL(ctx, `COMMIT; DELETE FROM tx; SELECT 1`)
+		require.Error(t, err)
+		txs, err := bq.Txs(ctx, "gaia-1")
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := bq.Txs(ctx, "osmosis-1")
+		require.ErrorContains(t, err, `chain "osmosis-1" not found`)
+
+		_, err = Open(ctx, dbPath, "TestMissing")
+		require.ErrorContains(t, err, `test case "TestMissing" not found`)
+
+		_, err = Open(ctx, filepath.Join(t.TempDir(), "missing.db"), "TestIBC")
+		require.Error(t, err)
+	})
+}
@@ -45,6 +45,10 @@ type Broadcaster struct {
 	factoryOptions []FactoryOpt
 	// clientContextOptions is a slice of broadcast.ClientContextOpt which enables arbitrary configuration of the client.Context.
 	clientContextOptions []ClientContextOpt
+
+	// dynamicGasPrice makes transactions pay the current min gas price of the chain's feemarket module
+	// instead of ChainConfig.GasPrices.
+	dynamicGasPrice bool
 }
 
 // NewBroadcaster returns a instance of Broadcaster which can be used with broadcast.Tx to
@@ -70,6 +74,27 @@ func (b *Broadcaster) ConfigureClientContextOptions(opts ...ClientContextOpt) {
 	b.clientContextOptions = append(b.clientContextOptions, opts...)
 }
 
+// ConfigureDynamicGasPrice makes the factory returned by GetFactory use the current min gas price
+// of the chain's feemarket module, for chains whose base fee changes with the load.
+func (b *Broadcaster) ConfigureDynamicGasPrice() {
+	b.dynamicGasPrice = true
+}
+
+// SimulateGas returns a FactoryOpt simulating transactions to estimate their gas,
+// which is then multiplied by the factory's gas adjustment.
+func SimulateGas() FactoryOpt {
+	return func(factory tx.Factory) tx.Factory {
+		return factory.WithSimulateAndExecute(true)
+	}
+}
+
+// FeeGranter returns a FactoryOpt making granter pay the fees through a fee grant, as --fee-granter does.
+func FeeGranter(granter sdk.AccAddress) FactoryOpt {
+	return func(factory tx.Factory) tx.Factory {
+		return factory.WithFeeGranter(granter)
+	}
+}
+
 // GetFactory returns an instance of tx.Factory that is configured with this Broadcaster's CosmosChain
 // and the provided user. ConfigureFactoryOptions can be used to specify arbitrary options to configure the returned
 // factory.
@@ -90,6 +115,13 @@ func (b *Broadcaster) GetFactory(ctx context.Context, user User) (tx.Factory, er
 	}
 
 	f := b.defaultTxFactory(clientContext, account)
+	if b.dynamicGasPrice {
+		price, err := b.chain.FeemarketGasPrice(ctx, b.chain.cfg.Denom)
+		if err != nil {
+			return tx.Factory{}, err
+		}
+		f = f.WithGasPrices(price.String())
+	}
 	for _, opt := range b.factoryOptions {
 		f = opt(f)
 	}
@@ -185,6 +217,8 @@ func (b *Broadcaster) defaultTxFactory(clientCtx client.Context, account client.
 
 // BroadcastTx uses the provided Broadcaster to broadcast all the provided messages which will be signed
 // by the User provided. The sdk.TxResponse and an error are returned.
+// Once the transaction is included in a block, the response holds the gas wanted and used,
+// and TxFee returns the fee that was paid.
 func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser User, msgs ...sdk.Msg) (sdk.TxResponse, error) {
 	f, err := broadcaster.GetFactory(ctx, broadcastingUser)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -57,6 +58,9 @@ type ChainNode struct {
 	// Additional processes that need to be run on a per-validator basis.
 	Sidecars SidecarProcesses
 
+	// If set, overrides the chain's ResourceLimits for this node's container.
+	ResourceLimits *ibc.ResourceLimits
+
 	lock sync.Mutex
 	log  *zap.Logger
 
@@ -66,6 +70,10 @@ type ChainNode struct {
 	hostRPCPort  string
 	hostAPIPort  string
 	hostGRPCPort string
+
+	// Ethereum JSON-RPC ports, only set for chains with an EVM.
+	hostEVMRPCPort string
+	hostEVMWSPort  string
 }
 
 func NewChainNode(log *zap.Logger, validator bool, chain *CosmosChain, dockerClient *dockerclient.Client, networkID string, testName string, image ibc.DockerImage, index int) *ChainNode {
@@ -344,6 +352,11 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	a["api"] = api
 
+	if evm := tn.Chain.Config().EVM; evm != nil {
+		// Enable public Ethereum JSON-RPC
+		a["json-rpc"] = evmAppConfig(*evm)
+	}
+
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
@@ -386,6 +399,12 @@ func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
 
 // FindTxs implements blockdb.BlockSaver.
 func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	block, err := tn.FindBlock(ctx, height)
+	return block.Txs, err
+}
+
+// FindBlock implements blockdb.BlockFinder.
+func (tn *ChainNode) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
 	h := int64(height)
 	var eg errgroup.Group
 	var blockRes *coretypes.ResultBlockResults
@@ -399,7 +418,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		return err
 	})
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return blockdb.Block{}, err
 	}
 	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
 	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
@@ -420,6 +439,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		newTx.Data = b
 
 		rTx := blockRes.TxsResults[i]
+		newTx.Code = rTx.Code
 
 		newTx.Events = make([]blockdb.Event, len(rTx.Events))
 		for j, e := range rTx.Events {
@@ -478,7 +498,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		txs = append(txs, endBlockTx)
 	}
 
-	return txs, nil
+	return blockdb.Block{Time: block.Block.Time, Txs: txs}, nil
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
@@ -653,16 +673,31 @@ func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, erro
 	return gen, nil
 }
 
+// ArchiveHome writes the whole home directory of the node, including its keyring, to w as a tar stream.
+// The node should be stopped, so that the archive is consistent.
+func (tn *ChainNode) ArchiveHome(ctx context.Context, w io.Writer) error {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	return fr.ArchiveVolume(ctx, tn.VolumeName, w)
+}
+
+// RestoreHome replaces the home directory of the node with the tar stream written by ArchiveHome.
+// The node should be stopped.
+func (tn *ChainNode) RestoreHome(ctx context.Context, r io.Reader) error {
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	return fw.RestoreVolume(ctx, tn.VolumeName, r)
+}
+
 // CreateKey creates a key in the keyring backend test for the given node
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.ExecBin(ctx,
+	cmd := []string{
 		"keys", "add", name,
 		"--coin-type", tn.Chain.Config().CoinType,
 		"--keyring-backend", keyring.BackendTest,
-	)
+	}
+	_, _, err := tn.ExecBin(ctx, append(cmd, tn.keyAlgoFlags()...)...)
 	return err
 }
 
@@ -671,7 +706,7 @@ func (tn *ChainNode) RecoverKey(ctx context.Context, keyName, mnemonic string) e
 	command := []string{
 		"sh",
 		"-c",
-		fmt.Sprintf(`echo %q | %s keys add %s --recover --keyring-backend %s --coin-type %s --home %s --output json`, mnemonic, tn.Chain.Config().Bin, keyName, keyring.BackendTest, tn.Chain.Config().CoinType, tn.HomeDir()),
+		fmt.Sprintf(`echo %q | %s keys add %s --recover --keyring-backend %s --coin-type %s --home %s --output json %s`, mnemonic, tn.Chain.Config().Bin, keyName, keyring.BackendTest, tn.Chain.Config().CoinType, tn.HomeDir(), strings.Join(tn.keyAlgoFlags(), " ")),
 	}
 
 	tn.lock.Lock()
@@ -1360,7 +1395,13 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		cmd = []string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}
 	}
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), tn.HostName(), cmd)
+	limits := chainCfg.ResourceLimits
+	if tn.ResourceLimits != nil {
+		limits = tn.ResourceLimits
+	}
+	tn.containerLifecycle.SetResourceLimits(limits)
+
+	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, tn.exposedPorts(), tn.Bind(), tn.HostName(), cmd)
 }
 
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
@@ -1389,6 +1430,14 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}
 	tn.hostRPCPort, tn.hostGRPCPort, tn.hostAPIPort = hostPorts[0], hostPorts[1], hostPorts[2]
 
+	if tn.Chain.Config().EVM != nil {
+		hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, evmRPCPort, evmWSPort)
+		if err != nil {
+			return err
+		}
+		tn.hostEVMRPCPort, tn.hostEVMWSPort = hostPorts[0], hostPorts[1]
+	}
+
 	err = tn.NewClient("tcp://" + tn.hostRPCPort)
 	if err != nil {
 		return err
@@ -1559,7 +1608,8 @@ func (nodes ChainNodes) logger() *zap.Logger {
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
+	repo, tag := tn.Image.RepositoryAndTag()
+	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: tn.Bind(),
@@ -7,7 +7,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -35,7 +34,6 @@ import (
 	ccvconsumertypes "github.com/cosmos/interchain-security/v3/x/ccv/consumer/types"
 	ccvclient "github.com/cosmos/interchain-security/v3/x/ccv/provider/client"
 	ccvprovidertypes "github.com/cosmos/interchain-security/v3/x/ccv/provider/types"
-	dockertypes "github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/icza/dyno"
@@ -131,26 +129,52 @@ func (c *CosmosChain) Nodes() ChainNodes {
 
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
+	_, err := c.addNodes(ctx, configFileOverrides, inc, false, nil)
+	return err
+}
+
+// addNodes adds inc new validator or fullnodes to the network, peering with the existing nodes,
+// and returns them once started. If set, preStart is called for each node right before its container starts.
+// Validator nodes are only added to the validator set once they are bonded.
+func (c *CosmosChain) addNodes(
+	ctx context.Context,
+	configFileOverrides map[string]any,
+	inc int,
+	validator bool,
+	preStart func(context.Context, *ChainNode) error,
+) (ChainNodes, error) {
 	// Get peer string for existing nodes
 	peers := c.Nodes().PeerString(ctx)
 
 	// Get genesis.json
 	genbz, err := c.Validators[0].GenesisFileContent(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	prevCount := c.numFullNodes
-	c.numFullNodes += inc
+	var prevCount int
+	if validator {
+		prevCount = c.NumValidators
+		c.NumValidators += inc
+	} else {
+		prevCount = c.numFullNodes
+		c.numFullNodes += inc
+	}
 	if err := c.initializeChainNodes(ctx, c.testName, c.getFullNode().DockerClient, c.getFullNode().NetworkID); err != nil {
-		return err
+		return nil, err
+	}
+
+	var added ChainNodes
+	if validator {
+		added = c.Validators[prevCount:]
+	} else {
+		added = c.FullNodes[prevCount:]
 	}
 
 	var eg errgroup.Group
-	for i := prevCount; i < c.numFullNodes; i++ {
-		i := i
+	for _, fn := range added {
+		fn := fn
 		eg.Go(func() error {
-			fn := c.FullNodes[i]
 			if err := fn.InitFullNodeFiles(ctx); err != nil {
 				return err
 			}
@@ -180,10 +204,18 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 			if err := fn.CreateNodeContainer(ctx); err != nil {
 				return err
 			}
+			if preStart != nil {
+				if err := preStart(ctx, fn); err != nil {
+					return err
+				}
+			}
 			return fn.StartContainer(ctx)
 		})
 	}
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return added, nil
 }
 
 // Implements Chain interface
@@ -303,6 +335,10 @@ func (c *CosmosChain) BuildWallet(ctx context.Context, keyName string, mnemonic
 // be restored in the relayer node using the mnemonic. After it is built, that address is included in
 // genesis with some funds.
 func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (ibc.Wallet, error) {
+	if c.cfg.EVM != nil {
+		return c.newEVMRelayerWallet(keyName)
+	}
+
 	coinType, err := strconv.ParseUint(c.cfg.CoinType, 10, 32)
 	if err != nil {
 		return nil, fmt.Errorf("invalid coin type: %w", err)
@@ -634,37 +670,38 @@ func (c *CosmosChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
 	return int64(fees)
 }
 
-func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, containerRepo, version string) {
-	c.cfg.Images[0].Version = version
+// UpgradeVersion switches the chain's nodes to the image containerRepo:version, and pulls it.
+// The nodes must be restarted to run the new image.
+func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, containerRepo, version string) error {
+	image := c.cfg.Images[0]
+	image.Repository, image.Version, image.Build = containerRepo, version, nil
+	return c.UpgradeImage(ctx, cli, image)
+}
+
+// UpgradeImage switches the chain's nodes to image, and pulls or builds it.
+// The nodes must be restarted to run the new image.
+func (c *CosmosChain) UpgradeImage(ctx context.Context, cli *client.Client, image ibc.DockerImage) error {
+	if image.UidGid == "" {
+		image.UidGid = c.cfg.Images[0].UidGid
+	}
+	c.cfg.Images[0] = image
 	for _, n := range c.Validators {
-		n.Image.Version = version
-		n.Image.Repository = containerRepo
+		n.Image = image
 	}
 	for _, n := range c.FullNodes {
-		n.Image.Version = version
-		n.Image.Repository = containerRepo
+		n.Image = image
 	}
-	c.pullImages(ctx, cli)
+	return c.pullImages(ctx, cli)
 }
 
-func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
+// pullImages pulls the chain's images, or builds those that are built from source.
+func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) error {
 	for _, image := range c.Config().Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
+		if err := dockerutil.EnsureImage(ctx, c.log, cli, image); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 // NewChainNode constructs a new cosmos chain node with a docker volume.
@@ -775,7 +812,9 @@ func (c *CosmosChain) initializeChainNodes(
 	networkID string,
 ) error {
 	chainCfg := c.Config()
-	c.pullImages(ctx, cli)
+	if err := c.pullImages(ctx, cli); err != nil {
+		return err
+	}
 	image := chainCfg.Images[0]
 
 	newVals := make(ChainNodes, c.NumValidators)
@@ -1477,6 +1516,14 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *CosmosChain) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
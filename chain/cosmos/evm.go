@@ -0,0 +1,348 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/docker/go-connections/nat"
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// Ports of the Ethereum JSON-RPC servers of Ethermint-style chains.
+const (
+	evmRPCPort = "8545/tcp"
+	evmWSPort  = "8546/tcp"
+)
+
+// evmReceiptTimeout bounds the wait for an EVM transaction to be included in a block.
+const evmReceiptTimeout = time.Minute
+
+// exposedPorts returns the ports exposed by the node's container,
+// which include the Ethereum JSON-RPC ports for chains with an EVM.
+func (tn *ChainNode) exposedPorts() nat.PortSet {
+	if tn.Chain.Config().EVM == nil {
+		return sentryPorts
+	}
+	ports := make(nat.PortSet, len(sentryPorts)+2)
+	for p := range sentryPorts {
+		ports[p] = struct{}{}
+	}
+	ports[nat.Port(evmRPCPort)] = struct{}{}
+	ports[nat.Port(evmWSPort)] = struct{}{}
+	return ports
+}
+
+// evmAppConfig returns the app.toml json-rpc section enabling the public Ethereum JSON-RPC servers.
+func evmAppConfig(evm ibc.EVMConfig) testutil.Toml {
+	jsonRPC := make(testutil.Toml)
+	jsonRPC["enable"] = true
+	jsonRPC["address"] = "0.0.0.0:8545"
+	jsonRPC["ws-address"] = "0.0.0.0:8546"
+	jsonRPC["api"] = strings.Join(evm.Namespaces(), ",")
+	return jsonRPC
+}
+
+// keyAlgoFlags returns the flags selecting the key algorithm of the keys commands,
+// which are only needed for chains with an EVM.
+func (tn *ChainNode) keyAlgoFlags() []string {
+	if evm := tn.Chain.Config().EVM; evm != nil {
+		return []string{"--algo", evm.Algo()}
+	}
+	return nil
+}
+
+// ExportEVMPrivateKey returns the Ethereum private key of the key named keyName.
+// Only supported by chains with an EVM.
+func (tn *ChainNode) ExportEVMPrivateKey(ctx context.Context, keyName string) (*ecdsa.PrivateKey, error) {
+	if tn.Chain.Config().EVM == nil {
+		return nil, errors.New("chain has no EVM")
+	}
+
+	stdout, _, err := tn.ExecBin(ctx, "keys", "unsafe-export-eth-key", keyName, "--keyring-backend", "test")
+	if err != nil {
+		return nil, err
+	}
+	return crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(stdout)), "0x"))
+}
+
+// EVMPrivateKeyFromMnemonic derives the Ethereum private key of the first account of mnemonic,
+// as eth_secp256k1 keys of Ethermint-style chains are derived.
+func EVMPrivateKeyFromMnemonic(mnemonic string, coinType uint32) (*ecdsa.PrivateKey, error) {
+	bz, err := hd.Secp256k1.Derive()(mnemonic, "", hd.CreateHDPath(coinType, 0, 0).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return crypto.ToECDSA(bz)
+}
+
+// newEVMRelayerWallet creates a wallet with a new mnemonic whose address is derived as Ethereum does.
+func (c *CosmosChain) newEVMRelayerWallet(keyName string) (ibc.Wallet, error) {
+	coinType, err := strconv.ParseUint(c.cfg.CoinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type: %w", err)
+	}
+
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entropy: %w", err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
+	}
+
+	key, err := EVMPrivateKeyFromMnemonic(mnemonic, uint32(coinType))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWallet(keyName, crypto.PubkeyToAddress(key.PublicKey).Bytes(), mnemonic, c.cfg), nil
+}
+
+// GetEVMRPCAddress returns the address of the Ethereum JSON-RPC server within the docker network.
+func (c *CosmosChain) GetEVMRPCAddress() string {
+	return fmt.Sprintf("http://%s:8545", c.getFullNode().HostName())
+}
+
+// GetEVMWSAddress returns the address of the Ethereum JSON-RPC websocket server within the docker network.
+func (c *CosmosChain) GetEVMWSAddress() string {
+	return fmt.Sprintf("ws://%s:8546", c.getFullNode().HostName())
+}
+
+// GetHostEVMRPCAddress returns the address of the Ethereum JSON-RPC server accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *CosmosChain) GetHostEVMRPCAddress() string {
+	return "http://" + c.getFullNode().hostEVMRPCPort
+}
+
+// GetHostEVMWSAddress returns the address of the Ethereum JSON-RPC websocket server accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *CosmosChain) GetHostEVMWSAddress() string {
+	return "ws://" + c.getFullNode().hostEVMWSPort
+}
+
+// EVMClient dials the Ethereum JSON-RPC server of the chain from the host.
+// The caller is responsible for closing the client.
+func (c *CosmosChain) EVMClient(ctx context.Context) (*ethclient.Client, error) {
+	if c.cfg.EVM == nil {
+		return nil, fmt.Errorf("chain %s has no EVM", c.cfg.Name)
+	}
+	return ethclient.DialContext(ctx, c.GetHostEVMRPCAddress())
+}
+
+// GetEVMBalance returns the balance of the native denom held by the hex or bech32 address, as reported by the EVM.
+func (c *CosmosChain) GetEVMBalance(ctx context.Context, address string) (math.Int, error) {
+	addr, err := c.EVMAddress(address)
+	if err != nil {
+		return math.Int{}, err
+	}
+
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return math.Int{}, err
+	}
+	defer client.Close()
+
+	balance, err := client.BalanceAt(ctx, addr, nil)
+	if err != nil {
+		return math.Int{}, err
+	}
+	return math.NewIntFromBigInt(balance), nil
+}
+
+// EVMAddress converts a hex or bech32 address of the chain to an Ethereum address.
+func (c *CosmosChain) EVMAddress(address string) (common.Address, error) {
+	if common.IsHexAddress(address) {
+		return common.HexToAddress(address), nil
+	}
+	bz, err := types.GetFromBech32(address, c.cfg.Bech32Prefix)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(bz), nil
+}
+
+// EVMPrivateKey returns the Ethereum private key of the key named keyName, e.g. the faucet,
+// for signing EVM transactions from the host.
+func (c *CosmosChain) EVMPrivateKey(ctx context.Context, keyName string) (*ecdsa.PrivateKey, error) {
+	return c.getFullNode().ExportEVMPrivateKey(ctx, keyName)
+}
+
+// EVMChainID returns the chain ID of the EVM, as reported by its JSON-RPC server.
+func (c *CosmosChain) EVMChainID(ctx context.Context) (*big.Int, error) {
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.ChainID(ctx)
+}
+
+// EVMTransaction is an EVM transaction sent by SendEVMTransaction.
+type EVMTransaction struct {
+	// To is the hex or bech32 recipient, or empty to deploy the contract in Data.
+	To    string
+	Value *big.Int
+	Data  []byte
+}
+
+// SendEVMTransaction signs tx with the Ethereum private key of the key named keyName, sends it,
+// and returns its receipt once included in a block.
+// It returns an error if the transaction reverted.
+//
+// The nonce is that of the pending state, so transactions from the same key must not be sent concurrently.
+func (c *CosmosChain) SendEVMTransaction(ctx context.Context, keyName string, tx EVMTransaction) (*ethtypes.Receipt, error) {
+	key, err := c.EVMPrivateKey(ctx, keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export EVM key %s: %w", keyName, err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	var to *common.Address
+	if tx.To != "" {
+		addr, err := c.EVMAddress(tx.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to address %q: %w", tx.To, err)
+		}
+		to = &addr
+	}
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query EVM chain ID: %w", err)
+	}
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nonce of %s: %w", from, err)
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gas price: %w", err)
+	}
+	gas, err := client.EstimateGas(ctx, goethereum.CallMsg{From: from, To: to, Value: value, Data: tx.Data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	signed, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(chainID), &ethtypes.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		To:       to,
+		Value:    value,
+		Data:     tx.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := client.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	receipt, err := waitForEVMReceipt(ctx, client, signed.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", signed.Hash())
+	}
+	return receipt, nil
+}
+
+// DeployEVMContract deploys the contract creation bytecode from the key named keyName and returns the receipt,
+// whose ContractAddress is the address of the new contract.
+func (c *CosmosChain) DeployEVMContract(ctx context.Context, keyName string, bytecode []byte) (*ethtypes.Receipt, error) {
+	if len(bytecode) == 0 {
+		return nil, errors.New("empty contract bytecode")
+	}
+	return c.SendEVMTransaction(ctx, keyName, EVMTransaction{Data: bytecode})
+}
+
+// CallEVMContract executes a read-only call of the contract at the hex or bech32 address to
+// with the ABI encoded data against the latest block, and returns the result.
+func (c *CosmosChain) CallEVMContract(ctx context.Context, to string, data []byte) ([]byte, error) {
+	addr, err := c.EVMAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract address %q: %w", to, err)
+	}
+
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	res, err := client.CallContract(ctx, goethereum.CallMsg{To: &addr, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract %s: %w", to, err)
+	}
+	return res, nil
+}
+
+// EVMTransactionReceipt waits for the EVM transaction with the given hash to be included in a block,
+// and returns its receipt, including the logs it emitted.
+func (c *CosmosChain) EVMTransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return waitForEVMReceipt(ctx, client, hash)
+}
+
+// EVMLogs returns the logs matching query, such as the events emitted by a contract within a range of blocks.
+func (c *CosmosChain) EVMLogs(ctx context.Context, query goethereum.FilterQuery) ([]ethtypes.Log, error) {
+	client, err := c.EVMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	logs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+	return logs, nil
+}
+
+// waitForEVMReceipt polls for the receipt of the transaction with the given hash.
+func waitForEVMReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash) (*ethtypes.Receipt, error) {
+	var receipt *ethtypes.Receipt
+	if err := testutil.WaitForCondition(evmReceiptTimeout, 500*time.Millisecond, func() (bool, error) {
+		var err error
+		receipt, err = client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, goethereum.NotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for receipt of transaction %s: %w", hash, err)
+	}
+	return receipt, nil
+}
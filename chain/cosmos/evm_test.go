@@ -0,0 +1,25 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVMPrivateKeyFromMnemonic(t *testing.T) {
+	// Well-known development mnemonic whose first Ethereum account is stable across tooling.
+	const mnemonic = "test test test test test test test test test test test junk"
+
+	key, err := cosmos.EVMPrivateKeyFromMnemonic(mnemonic, 60)
+	require.NoError(t, err)
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", addr.Hex())
+
+	w := cosmos.NewWallet("user", addr.Bytes(), mnemonic, ibc.ChainConfig{Bech32Prefix: "evmos"}).(*cosmos.CosmosWallet)
+	require.Equal(t, addr.Hex(), w.HexAddress())
+	require.Equal(t, "evmos17w0adeg64ky0daxwd2ugyuneellmjgnxpu2u3g", w.FormattedAddress())
+}
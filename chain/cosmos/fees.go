@@ -0,0 +1,55 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// FeemarketGasPrice returns the current min gas price in denom of the chain's feemarket module,
+// whose base fee changes with the load of the chain.
+func (tn *ChainNode) FeemarketGasPrice(ctx context.Context, denom string) (sdk.DecCoin, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "feemarket", "gas-price", denom)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	var res struct {
+		Price sdk.DecCoin `json:"price"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to unmarshal gas price: %w", err)
+	}
+	return res.Price, nil
+}
+
+// FeemarketGasPrice returns the current min gas price in denom of the chain's feemarket module.
+func (c *CosmosChain) FeemarketGasPrice(ctx context.Context, denom string) (sdk.DecCoin, error) {
+	return c.getFullNode().FeemarketGasPrice(ctx, denom)
+}
+
+// TxFee returns the fee paid by the transaction of resp, which must have been queried
+// once included in a block, as BroadcastTx does.
+func TxFee(resp sdk.TxResponse) (sdk.Coins, error) {
+	if resp.Tx != nil {
+		if tx, ok := resp.Tx.GetCachedValue().(*txtypes.Tx); ok && tx.AuthInfo != nil && tx.AuthInfo.Fee != nil {
+			return tx.AuthInfo.Fee.Amount, nil
+		}
+	}
+
+	// Fall back to the fee attribute of the tx event.
+	for _, event := range resp.Events {
+		if event.Type != "tx" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "fee" {
+				return sdk.ParseCoinsNormalized(attr.Value)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no fee found in transaction %s", resp.TxHash)
+}
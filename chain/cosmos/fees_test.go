@@ -0,0 +1,34 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxFee(t *testing.T) {
+	fee := sdk.NewCoins(sdk.NewInt64Coin("ujuno", 5000))
+
+	tx, err := codectypes.NewAnyWithValue(&txtypes.Tx{
+		AuthInfo: &txtypes.AuthInfo{Fee: &txtypes.Fee{Amount: fee, GasLimit: 200_000}},
+	})
+	require.NoError(t, err)
+
+	got, err := cosmos.TxFee(sdk.TxResponse{Tx: tx})
+	require.NoError(t, err)
+	require.Equal(t, fee, got)
+
+	got, err = cosmos.TxFee(sdk.TxResponse{Events: []abcitypes.Event{
+		{Type: "tx", Attributes: []abcitypes.EventAttribute{{Key: "fee", Value: "5000ujuno"}}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, fee, got)
+
+	_, err = cosmos.TxFee(sdk.TxResponse{TxHash: "ABCD"})
+	require.EqualError(t, err, "no fee found in transaction ABCD")
+}
@@ -0,0 +1,154 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Defaults of HardForkOptions.
+const (
+	defaultHardForkBlocksAfterRestart = 5
+	defaultHardForkTimeout            = 2 * time.Minute
+)
+
+// HardForkOptions configures CosmosChain.HardFork.
+type HardForkOptions struct {
+	// Height at which the state is exported. If zero, the latest height of the chain is used.
+	// If set, the test must have waited for the chain to reach it, e.g. with a halt height.
+	Height int64
+
+	// ModifyGenesis, if set, transforms the exported genesis before it is distributed,
+	// e.g. cosmos.ModifyGenesis([]cosmos.GenesisKV{...}).
+	ModifyGenesis func(ibc.ChainConfig, []byte) ([]byte, error)
+
+	// BlocksAfterRestart is the number of blocks the restarted chain must produce. Defaults to 5.
+	BlocksAfterRestart uint64
+	// Timeout bounds the wait for the restarted chain to produce blocks. Defaults to 2 minutes.
+	Timeout time.Duration
+
+	// Relayer, if set, updates the clients of each of RelayerPaths once the chain restarted,
+	// verifying that counterparty light clients follow the chain across the restart.
+	Relayer             ibc.Relayer
+	RelayerExecReporter ibc.RelayerExecReporter
+	RelayerPaths        []string
+}
+
+// HardForkResult describes a chain restarted from an exported state.
+type HardForkResult struct {
+	// ExportHeight is the height at which the state was exported.
+	ExportHeight int64
+	// Genesis is the genesis the chain was restarted from, after ModifyGenesis.
+	Genesis []byte
+	// RestartHeight is the height of the chain once it produced BlocksAfterRestart blocks.
+	RestartHeight uint64
+}
+
+func (o HardForkOptions) withDefaults() HardForkOptions {
+	if o.BlocksAfterRestart == 0 {
+		o.BlocksAfterRestart = defaultHardForkBlocksAfterRestart
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultHardForkTimeout
+	}
+	return o
+}
+
+// HardFork restarts the chain from its own exported state, as in a coordinated chain restart:
+// it stops all nodes, exports the state at the given height, lets the test transform the genesis,
+// resets the data of every node, distributes the new genesis and starts the nodes again.
+// It verifies that the chain produces blocks past the export height and, if a relayer is given,
+// that the clients of the relayer paths can be updated.
+func (c *CosmosChain) HardFork(ctx context.Context, opts HardForkOptions) (HardForkResult, error) {
+	var result HardForkResult
+
+	opts = opts.withDefaults()
+	if opts.Relayer != nil && opts.RelayerExecReporter == nil {
+		return result, errors.New("relayer exec reporter must be set with a relayer")
+	}
+	if opts.Relayer == nil && len(opts.RelayerPaths) > 0 {
+		return result, errors.New("relayer must be set with relayer paths")
+	}
+
+	result.ExportHeight = opts.Height
+	if result.ExportHeight == 0 {
+		height, err := c.Height(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to get height before export: %w", err)
+		}
+		result.ExportHeight = int64(height)
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return result, fmt.Errorf("failed to stop nodes: %w", err)
+	}
+
+	state, err := c.ExportState(ctx, result.ExportHeight)
+	if err != nil {
+		return result, fmt.Errorf("failed to export state at height %d: %w", result.ExportHeight, err)
+	}
+	result.Genesis = []byte(state)
+
+	if opts.ModifyGenesis != nil {
+		result.Genesis, err = opts.ModifyGenesis(c.Config(), result.Genesis)
+		if err != nil {
+			return result, fmt.Errorf("failed to modify exported genesis: %w", err)
+		}
+	}
+	if !json.Valid(result.Genesis) {
+		return result, errors.New("exported genesis is not valid JSON")
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			if err := n.UnsafeResetAll(ctx); err != nil {
+				return fmt.Errorf("failed to reset node %s: %w", n.Name(), err)
+			}
+			return n.OverwriteGenesisFile(ctx, result.Genesis)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return result, err
+	}
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return result, fmt.Errorf("failed to start nodes: %w", err)
+	}
+
+	restartCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	if err := testutil.WaitForBlocks(restartCtx, int(opts.BlocksAfterRestart), c); err != nil {
+		return result, fmt.Errorf("chain did not produce blocks after restart: %w", err)
+	}
+
+	result.RestartHeight, err = c.Height(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get height after restart: %w", err)
+	}
+	if int64(result.RestartHeight) <= result.ExportHeight {
+		return result, fmt.Errorf("chain height %d did not pass the export height %d", result.RestartHeight, result.ExportHeight)
+	}
+
+	for _, path := range opts.RelayerPaths {
+		if err := opts.Relayer.UpdateClients(ctx, opts.RelayerExecReporter, path); err != nil {
+			return result, fmt.Errorf("failed to update clients of path %s after restart: %w", path, err)
+		}
+	}
+
+	c.log.Info("Chain restarted from exported state",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.Int64("export_height", result.ExportHeight),
+		zap.Uint64("restart_height", result.RestartHeight),
+	)
+
+	return result, nil
+}
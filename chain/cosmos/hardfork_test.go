@@ -0,0 +1,14 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardForkRequiresRelayerForPaths(t *testing.T) {
+	c := &CosmosChain{}
+	_, err := c.HardFork(context.Background(), HardForkOptions{RelayerPaths: []string{"gaia-osmo"}})
+	require.ErrorContains(t, err, "relayer must be set with relayer paths")
+}
@@ -0,0 +1,229 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// offlineTxCounter makes the names of offline transaction files unique.
+var offlineTxCounter atomic.Uint64
+
+// offlineTxFile returns a new file name, relative to the node's home, for a file of an offline signing flow.
+func offlineTxFile(kind string) string {
+	return fmt.Sprintf("offline-tx-%d-%s.json", offlineTxCounter.Add(1), kind)
+}
+
+// CreateMultisigKey creates a multisig key named name in the node's keyring, requiring threshold
+// of the signatures of signerKeyNames, which must already be in the keyring. It returns the multisig address.
+func (tn *ChainNode) CreateMultisigKey(ctx context.Context, name string, threshold int, signerKeyNames []string) (string, error) {
+	if threshold < 1 || threshold > len(signerKeyNames) {
+		return "", fmt.Errorf("invalid threshold %d for %d signers", threshold, len(signerKeyNames))
+	}
+
+	tn.lock.Lock()
+	_, _, err := tn.ExecBin(ctx,
+		"keys", "add", name,
+		"--multisig", strings.Join(signerKeyNames, ","),
+		"--multisig-threshold", strconv.Itoa(threshold),
+		"--keyring-backend", keyring.BackendTest,
+	)
+	tn.lock.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("failed to create multisig key: %w", err)
+	}
+
+	return tn.AccountKeyBech32(ctx, name)
+}
+
+// GenerateTx generates the unsigned transaction of the tx command, sent from the key name or address from,
+// and writes it to a file in the node's home. It returns the path of the file relative to the home.
+// For example, pass ("bank", "send", from, to, "1ujuno") for command.
+func (tn *ChainNode) GenerateTx(ctx context.Context, from string, command ...string) (string, error) {
+	stdout, stderr, err := tn.Exec(ctx, tn.TxCommand(from, append(command, "--generate-only")...), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate tx (stderr=%q): %w", stderr, err)
+	}
+
+	file := offlineTxFile("unsigned")
+	if err := tn.WriteFile(ctx, stdout, file); err != nil {
+		return "", fmt.Errorf("failed to write unsigned tx: %w", err)
+	}
+	return file, nil
+}
+
+// SignTx signs the transaction in txFile with signerKeyName, on behalf of multisigAddress if not empty,
+// and returns the path of the signature file relative to the home.
+// Without a multisig address, the file holds the signed transaction, ready for BroadcastTxFile.
+func (tn *ChainNode) SignTx(ctx context.Context, txFile, signerKeyName, multisigAddress string) (string, error) {
+	out := offlineTxFile("signed-" + signerKeyName)
+	command := []string{
+		"tx", "sign", path.Join(tn.HomeDir(), txFile),
+		"--from", signerKeyName,
+		"--chain-id", tn.Chain.Config().ChainID,
+		"--keyring-backend", keyring.BackendTest,
+		"--output-document", path.Join(tn.HomeDir(), out),
+	}
+	if multisigAddress != "" {
+		command = append(command, "--multisig", multisigAddress)
+	}
+
+	if _, stderr, err := tn.Exec(ctx, tn.NodeCommand(command...), nil); err != nil {
+		return "", fmt.Errorf("failed to sign tx with %s (stderr=%q): %w", signerKeyName, stderr, err)
+	}
+	return out, nil
+}
+
+// MultiSign combines the signatures in signatureFiles of the transaction in txFile into a transaction
+// signed by the multisig key multisigKeyName, and returns the path of its file relative to the home.
+func (tn *ChainNode) MultiSign(ctx context.Context, txFile, multisigKeyName string, signatureFiles []string) (string, error) {
+	out := offlineTxFile("multisigned")
+	command := []string{"tx", "multisign", path.Join(tn.HomeDir(), txFile), multisigKeyName}
+	for _, f := range signatureFiles {
+		command = append(command, path.Join(tn.HomeDir(), f))
+	}
+	command = append(command,
+		"--chain-id", tn.Chain.Config().ChainID,
+		"--keyring-backend", keyring.BackendTest,
+		"--output-document", path.Join(tn.HomeDir(), out),
+	)
+
+	if _, stderr, err := tn.Exec(ctx, tn.NodeCommand(command...), nil); err != nil {
+		return "", fmt.Errorf("failed to combine signatures (stderr=%q): %w", stderr, err)
+	}
+	return out, nil
+}
+
+// BroadcastTxFile broadcasts the signed transaction in txFile, waits for 2 blocks if successful,
+// then returns the tx hash.
+func (tn *ChainNode) BroadcastTxFile(ctx context.Context, txFile string) (string, error) {
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	stdout, _, err := tn.Exec(ctx, tn.NodeCommand("tx", "broadcast", path.Join(tn.HomeDir(), txFile), "--output", "json"), nil)
+	if err != nil {
+		return "", err
+	}
+	output := CosmosTx{}
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", err
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
+	}
+	if err := testutil.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", err
+	}
+	return output.TxHash, nil
+}
+
+// CreateMultisigWallet creates a multisig wallet named keyName, controlled by threshold of the signers,
+// whose keys must be in the keyring of the chain, as for users created with GetAndFundTestUsers.
+func (c *CosmosChain) CreateMultisigWallet(ctx context.Context, keyName string, threshold int, signers []ibc.Wallet) (ibc.Wallet, error) {
+	keyNames := make([]string, len(signers))
+	for i, s := range signers {
+		keyNames[i] = s.KeyName()
+	}
+
+	addr, err := c.getFullNode().CreateMultisigKey(ctx, keyName, threshold, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	addrBytes, err := sdk.GetFromBech32(addr, c.cfg.Bech32Prefix)
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(keyName, addrBytes, "", c.cfg), nil
+}
+
+// MultisigTx runs the tx command from the multisig wallet: it generates the unsigned transaction,
+// collects the signature of each of the signers, combines them and broadcasts the result.
+// signers must meet the threshold of the multisig wallet.
+func (c *CosmosChain) MultisigTx(ctx context.Context, multisig ibc.Wallet, signers []ibc.Wallet, command ...string) (*sdk.TxResponse, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+
+	tn := c.getFullNode()
+	txFile, err := tn.GenerateTx(ctx, multisig.KeyName(), command...)
+	if err != nil {
+		return nil, err
+	}
+
+	sigFiles := make([]string, len(signers))
+	for i, s := range signers {
+		if sigFiles[i], err = tn.SignTx(ctx, txFile, s.KeyName(), multisig.FormattedAddress()); err != nil {
+			return nil, err
+		}
+	}
+
+	signedFile, err := tn.MultiSign(ctx, txFile, multisig.KeyName(), sigFiles)
+	if err != nil {
+		return nil, err
+	}
+	return c.broadcastTxFile(ctx, signedFile)
+}
+
+// broadcastTxFile broadcasts the signed transaction in txFile and returns its result.
+func (c *CosmosChain) broadcastTxFile(ctx context.Context, txFile string) (*sdk.TxResponse, error) {
+	txHash, err := c.getFullNode().BroadcastTxFile(ctx, txFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast tx: %w", err)
+	}
+	return c.GetTransaction(txHash)
+}
+
+// execTx executes the tx command signed by keyName and returns its result.
+func (c *CosmosChain) execTx(ctx context.Context, keyName string, command ...string) (*sdk.TxResponse, error) {
+	txHash, err := c.getFullNode().ExecTx(ctx, keyName, command...)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTransaction(txHash)
+}
+
+// AuthzGrant grants grantee an authorization of authType (generic, send or delegate, ...) from granter.
+// extraFlags configure the authorization, e.g. "--msg-type", "/cosmos.bank.v1beta1.MsgSend" for generic ones.
+func (c *CosmosChain) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee, authType string, extraFlags ...string) (*sdk.TxResponse, error) {
+	command := append([]string{"authz", "grant", grantee, authType}, extraFlags...)
+	return c.execTx(ctx, granter.KeyName(), command...)
+}
+
+// AuthzExec executes the tx command on behalf of granterAddress with the authorization granted to grantee.
+// For example, pass ("bank", "send", granterAddress, to, "1ujuno") for command.
+func (c *CosmosChain) AuthzExec(ctx context.Context, grantee ibc.Wallet, granterAddress string, command ...string) (*sdk.TxResponse, error) {
+	tn := c.getFullNode()
+	txFile, err := tn.GenerateTx(ctx, granterAddress, command...)
+	if err != nil {
+		return nil, err
+	}
+	return c.execTx(ctx, grantee.KeyName(), "authz", "exec", path.Join(tn.HomeDir(), txFile))
+}
+
+// AuthzRevoke revokes the authorization of grantee to execute msgType on behalf of granter.
+func (c *CosmosChain) AuthzRevoke(ctx context.Context, granter ibc.Wallet, grantee, msgType string) (*sdk.TxResponse, error) {
+	return c.execTx(ctx, granter.KeyName(), "authz", "revoke", grantee, msgType)
+}
+
+// FeeGrant grants grantee an allowance to pay fees from granter.
+// extraFlags configure the allowance, e.g. "--spend-limit", "1000ujuno".
+// The grantee uses it with "--fee-granter" on its transactions, or the FeeGranter FactoryOpt.
+func (c *CosmosChain) FeeGrant(ctx context.Context, granter ibc.Wallet, grantee string, extraFlags ...string) (*sdk.TxResponse, error) {
+	command := append([]string{"feegrant", "grant", granter.FormattedAddress(), grantee}, extraFlags...)
+	return c.execTx(ctx, granter.KeyName(), command...)
+}
+
+// FeeGrantRevoke revokes the fee allowance of grantee from granter.
+func (c *CosmosChain) FeeGrantRevoke(ctx context.Context, granter ibc.Wallet, grantee string) (*sdk.TxResponse, error) {
+	return c.execTx(ctx, granter.KeyName(), "feegrant", "revoke", granter.FormattedAddress(), grantee)
+}
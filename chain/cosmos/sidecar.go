@@ -39,6 +39,9 @@ type SidecarProcess struct {
 	startCmd     []string
 	homeDir      string
 
+	// If set, overrides the ResourceLimits of the matching ibc.SidecarConfig.
+	ResourceLimits *ibc.ResourceLimits
+
 	containerLifecycle *dockerutil.ContainerLifecycle
 }
 
@@ -104,9 +107,24 @@ func (s *SidecarProcess) logger() *zap.Logger {
 }
 
 func (s *SidecarProcess) CreateContainer(ctx context.Context) error {
+	s.containerLifecycle.SetResourceLimits(s.resourceLimits())
 	return s.containerLifecycle.CreateContainer(ctx, s.TestName, s.NetworkID, s.Image, s.ports, s.Bind(), s.HostName(), s.startCmd)
 }
 
+// resourceLimits returns the limits for the sidecar's container,
+// falling back to the chain's SidecarConfig with the same process name.
+func (s *SidecarProcess) resourceLimits() *ibc.ResourceLimits {
+	if s.ResourceLimits != nil {
+		return s.ResourceLimits
+	}
+	for _, cfg := range s.Chain.Config().SidecarConfigs {
+		if cfg.ProcessName == s.ProcessName && cfg.ValidatorProcess == s.validatorProcess {
+			return cfg.ResourceLimits
+		}
+	}
+	return nil
+}
+
 func (s *SidecarProcess) StartContainer(ctx context.Context) error {
 	return s.containerLifecycle.StartContainer(ctx)
 }
@@ -177,7 +195,8 @@ func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte,
 
 // Exec enables the execution of arbitrary CLI cmds against the process.
 func (s *SidecarProcess) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
+	repo, tag := s.Image.RepositoryAndTag()
+	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: s.Bind(),
@@ -0,0 +1,163 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// Defaults of StateSyncOptions.
+const defaultStateSyncTimeout = time.Minute
+
+// SnapshotConfigFileOverrides returns the app.toml overrides making nodes take a state sync snapshot
+// every interval blocks and keep the last keepRecent of them, for use as ChainConfig.ConfigFileOverrides.
+// Pruning is aligned with the interval, since snapshots can only be taken of heights that are not pruned.
+func SnapshotConfigFileOverrides(interval uint64, keepRecent uint32) map[string]any {
+	stateSync := make(testutil.Toml)
+	stateSync["snapshot-interval"] = interval
+	stateSync["snapshot-keep-recent"] = keepRecent
+
+	appToml := make(testutil.Toml)
+	appToml["state-sync"] = stateSync
+	appToml["pruning"] = "custom"
+	appToml["pruning-keep-recent"] = interval
+	appToml["pruning-keep-every"] = interval
+	appToml["pruning-interval"] = interval
+
+	return map[string]any{"config/app.toml": appToml}
+}
+
+// StateSyncOptions configures CosmosChain.AddStateSyncFullNode.
+type StateSyncOptions struct {
+	// SnapshotInterval is the snapshot interval of the validators. The trust height is set this many blocks
+	// before the latest height, so that a snapshot exists after it.
+	SnapshotInterval uint64
+	// ConfigFileOverrides are applied to the new node, in addition to its state sync settings.
+	ConfigFileOverrides map[string]any
+	// Timeout bounds the wait for the new node to catch up with the chain. Defaults to 1 minute.
+	Timeout time.Duration
+}
+
+// AddStateSyncFullNode adds a fullnode that joins the network through state sync instead of block syncing from genesis.
+// The validators must be taking snapshots, e.g. with SnapshotConfigFileOverrides.
+// The trust height and hash are taken from a validator's RPC, which also serves as the light client RPC servers.
+// It returns the node once it caught up with the chain.
+func (c *CosmosChain) AddStateSyncFullNode(ctx context.Context, opts StateSyncOptions) (*ChainNode, error) {
+	if opts.SnapshotInterval == 0 {
+		return nil, fmt.Errorf("snapshot interval must be set")
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultStateSyncTimeout
+	}
+
+	rpcNode := c.Validators[0]
+	latestHeight, err := rpcNode.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest height: %w", err)
+	}
+	if latestHeight <= opts.SnapshotInterval {
+		return nil, fmt.Errorf("chain height %d has not reached the snapshot interval %d", latestHeight, opts.SnapshotInterval)
+	}
+
+	trustHeight := int64(latestHeight - opts.SnapshotInterval)
+	block, err := rpcNode.Client.Block(ctx, &trustHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trusted block %d: %w", trustHeight, err)
+	}
+
+	overrides, err := stateSyncConfigFileOverrides(
+		opts.ConfigFileOverrides,
+		c.stateSyncRPCServers(),
+		trustHeight,
+		hex.EncodeToString(block.BlockID.Hash),
+	)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.addNodes(ctx, overrides, 1, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	fn := nodes[0]
+
+	syncCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	if err := testutil.WaitForInSync(syncCtx, c, fn); err != nil {
+		return nil, fmt.Errorf("state synced node did not catch up: %w", err)
+	}
+
+	// A node that state synced does not have the blocks before the snapshot.
+	status, err := fn.Client.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of state synced node: %w", err)
+	}
+	if status.SyncInfo.EarliestBlockHeight <= 1 {
+		return nil, fmt.Errorf("node %s block synced from genesis instead of state syncing", fn.Name())
+	}
+
+	return fn, nil
+}
+
+// stateSyncRPCServers returns the RPC servers the light client of a state syncing node verifies against.
+// State sync requires at least two, so the address of a single validator is repeated.
+func (c *CosmosChain) stateSyncRPCServers() string {
+	servers := make([]string, 0, len(c.Validators)+1)
+	for _, v := range c.Validators {
+		servers = append(servers, fmt.Sprintf("tcp://%s:26657", v.HostName()))
+	}
+	if len(servers) == 1 {
+		servers = append(servers, servers[0])
+	}
+	return strings.Join(servers, ",")
+}
+
+// stateSyncConfigFileOverrides returns a copy of overrides with state sync enabled in config.toml.
+// Existing config.toml overrides, including other keys of its statesync section, are kept.
+func stateSyncConfigFileOverrides(overrides map[string]any, rpcServers string, trustHeight int64, trustHash string) (map[string]any, error) {
+	merged := make(map[string]any, len(overrides)+1)
+	for file, override := range overrides {
+		merged[file] = override
+	}
+
+	configToml, err := copyToml(merged["config/config.toml"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid config/config.toml overrides: %w", err)
+	}
+
+	stateSync, err := copyToml(configToml["statesync"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid config/config.toml statesync overrides: %w", err)
+	}
+	stateSync["enable"] = true
+	stateSync["rpc_servers"] = rpcServers
+	stateSync["trust_height"] = trustHeight
+	stateSync["trust_hash"] = trustHash
+	configToml["statesync"] = stateSync
+
+	merged["config/config.toml"] = configToml
+	return merged, nil
+}
+
+// copyToml returns a shallow copy of v, which may be nil, a testutil.Toml or a map[string]any.
+func copyToml(v any) (testutil.Toml, error) {
+	var m map[string]any
+	switch v := v.(type) {
+	case nil:
+	case testutil.Toml:
+		m = v
+	case map[string]any:
+		m = v
+	default:
+		return nil, fmt.Errorf("expected a map, found %T", v)
+	}
+
+	c := make(testutil.Toml, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c, nil
+}
@@ -0,0 +1,64 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSyncConfigFileOverrides(t *testing.T) {
+	overrides := map[string]any{
+		"config/app.toml":    testutil.Toml{"minimum-gas-prices": "0ujuno"},
+		"config/config.toml": testutil.Toml{"log_level": "debug"},
+	}
+
+	merged, err := stateSyncConfigFileOverrides(overrides, "tcp://val-0:26657,tcp://val-0:26657", 20, "ABCD")
+	require.NoError(t, err)
+
+	require.Equal(t, overrides["config/app.toml"], merged["config/app.toml"])
+	require.Equal(t, testutil.Toml{
+		"log_level": "debug",
+		"statesync": testutil.Toml{
+			"enable":       true,
+			"rpc_servers":  "tcp://val-0:26657,tcp://val-0:26657",
+			"trust_height": int64(20),
+			"trust_hash":   "ABCD",
+		},
+	}, merged["config/config.toml"])
+
+	// The caller's overrides are left untouched.
+	require.Equal(t, testutil.Toml{"log_level": "debug"}, overrides["config/config.toml"])
+}
+
+func TestStateSyncConfigFileOverrides_Map(t *testing.T) {
+	overrides := map[string]any{
+		"config/config.toml": map[string]any{
+			"log_level": "debug",
+			"statesync": map[string]any{"chunk_fetchers": 8, "enable": false},
+		},
+	}
+
+	merged, err := stateSyncConfigFileOverrides(overrides, "tcp://val-0:26657,tcp://val-1:26657", 20, "ABCD")
+	require.NoError(t, err)
+	require.Equal(t, testutil.Toml{
+		"log_level": "debug",
+		"statesync": testutil.Toml{
+			"chunk_fetchers": 8,
+			"enable":         true,
+			"rpc_servers":    "tcp://val-0:26657,tcp://val-1:26657",
+			"trust_height":   int64(20),
+			"trust_hash":     "ABCD",
+		},
+	}, merged["config/config.toml"])
+
+	_, err = stateSyncConfigFileOverrides(map[string]any{"config/config.toml": "log_level = 'debug'"}, "", 20, "ABCD")
+	require.Error(t, err)
+}
+
+func TestSnapshotConfigFileOverrides(t *testing.T) {
+	appToml := SnapshotConfigFileOverrides(10, 2)["config/app.toml"].(testutil.Toml)
+	require.Equal(t, testutil.Toml{"snapshot-interval": uint64(10), "snapshot-keep-recent": uint32(2)}, appToml["state-sync"])
+	require.Equal(t, "custom", appToml["pruning"])
+	require.Equal(t, uint64(10), appToml["pruning-keep-recent"])
+}
@@ -0,0 +1,276 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// Defaults of ChainUpgrade.
+const (
+	defaultUpgradeHaltHeightDelta    = 10
+	defaultUpgradeBlocksAfterUpgrade = 5
+	defaultUpgradeTimeout            = 2 * time.Minute
+)
+
+// ChainUpgrade describes a software upgrade of a chain through governance, run by CosmosChain.Upgrade.
+type ChainUpgrade struct {
+	// Name of the upgrade plan, which must match the upgrade handler of the new binary.
+	Name string
+	// Image the nodes run after the upgrade.
+	Image ibc.DockerImage
+
+	// ProposerKeyName is the key submitting the proposal; it must hold Deposit.
+	ProposerKeyName string
+	// Deposit of the proposal, e.g. "500000000ujuno". Must be at least the chain's min deposit.
+	Deposit string
+
+	// HaltHeightDelta is the number of blocks after the proposal submission at which the chain halts.
+	// It must leave enough time for the voting period to end. Defaults to 10.
+	HaltHeightDelta uint64
+	// BlocksAfterUpgrade is the number of blocks the upgraded chain must produce. Defaults to 5.
+	BlocksAfterUpgrade uint64
+	// Timeout bounds each wait of the upgrade: for the chain to halt and to resume. Defaults to 2 minutes.
+	Timeout time.Duration
+
+	// PreUpgrade, if set, is called once the chain halted, before the nodes are stopped.
+	PreUpgrade func(ctx context.Context, c *CosmosChain) error
+	// PostUpgrade, if set, is called once the upgraded chain produced BlocksAfterUpgrade blocks.
+	PostUpgrade func(ctx context.Context, c *CosmosChain) error
+}
+
+// UpgradeReport records the outcome and timing of a chain upgrade.
+type UpgradeReport struct {
+	ProposalID string
+	HaltHeight uint64
+
+	// Build information of the binary before and after the upgrade.
+	// PreUpgradeBuild is nil if the old binary could not report it.
+	PreUpgradeBuild  *BinaryBuildInformation
+	PostUpgradeBuild *BinaryBuildInformation
+
+	Submitted time.Time // proposal submitted
+	Passed    time.Time // proposal passed
+	Halted    time.Time // chain halted at HaltHeight
+	Restarted time.Time // nodes restarted with the new image
+	Resumed   time.Time // upgraded chain produced BlocksAfterUpgrade blocks
+}
+
+// HaltDuration is the time from the proposal submission to the chain halt.
+func (r UpgradeReport) HaltDuration() time.Duration {
+	return r.Halted.Sub(r.Submitted)
+}
+
+// DowntimeDuration is the time from the chain halt to the upgraded chain producing blocks.
+func (r UpgradeReport) DowntimeDuration() time.Duration {
+	return r.Resumed.Sub(r.Halted)
+}
+
+// TotalDuration is the time of the whole upgrade.
+func (r UpgradeReport) TotalDuration() time.Duration {
+	return r.Resumed.Sub(r.Submitted)
+}
+
+func (u ChainUpgrade) withDefaults() ChainUpgrade {
+	if u.HaltHeightDelta == 0 {
+		u.HaltHeightDelta = defaultUpgradeHaltHeightDelta
+	}
+	if u.BlocksAfterUpgrade == 0 {
+		u.BlocksAfterUpgrade = defaultUpgradeBlocksAfterUpgrade
+	}
+	if u.Timeout == 0 {
+		u.Timeout = defaultUpgradeTimeout
+	}
+	return u
+}
+
+func (u ChainUpgrade) validate() (err error) {
+	if u.Name == "" {
+		err = multierr.Append(err, errors.New("upgrade name must be set"))
+	}
+	if u.Image.Build == nil && (u.Image.Repository == "" || u.Image.Version == "") {
+		err = multierr.Append(err, errors.New("upgrade image repository and version, or build, must be set"))
+	}
+	if u.ProposerKeyName == "" {
+		err = multierr.Append(err, errors.New("proposer key name must be set"))
+	}
+	if u.Deposit == "" {
+		err = multierr.Append(err, errors.New("deposit must be set"))
+	}
+	return err
+}
+
+// binaryChanged reports whether the build information of two binaries differ.
+func binaryChanged(pre, post *BinaryBuildInformation) bool {
+	return pre.Version != post.Version || pre.Commit != post.Commit
+}
+
+// Upgrade runs a software upgrade of the chain through governance:
+// it submits the upgrade proposal, votes yes with all validators, waits for the chain to halt at the upgrade height,
+// restarts all nodes with the upgrade image and waits for the chain to produce blocks again.
+// It verifies that the chain halted at the expected height and that the nodes run a different binary afterwards.
+// The chain's voting period must end within HaltHeightDelta blocks.
+func (c *CosmosChain) Upgrade(ctx context.Context, upgrade ChainUpgrade) (UpgradeReport, error) {
+	var report UpgradeReport
+
+	upgrade = upgrade.withDefaults()
+	if err := upgrade.validate(); err != nil {
+		return report, fmt.Errorf("invalid upgrade: %w", err)
+	}
+
+	report.PreUpgradeBuild = c.getFullNode().GetBuildInformation(ctx)
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get height before upgrade proposal: %w", err)
+	}
+	report.HaltHeight = height + upgrade.HaltHeightDelta
+
+	report.Submitted = time.Now()
+	prop, err := c.UpgradeProposal(ctx, upgrade.ProposerKeyName, SoftwareUpgradeProposal{
+		Deposit:     upgrade.Deposit,
+		Title:       "Upgrade " + upgrade.Name,
+		Name:        upgrade.Name,
+		Description: fmt.Sprintf("Upgrade to %s at height %d", upgrade.Image.Ref(), report.HaltHeight),
+		Height:      report.HaltHeight,
+	})
+	if err != nil {
+		return report, err
+	}
+	report.ProposalID = prop.ProposalID
+
+	if err := c.VoteOnProposalAllValidators(ctx, prop.ProposalID, ProposalVoteYes); err != nil {
+		return report, fmt.Errorf("failed to vote on upgrade proposal: %w", err)
+	}
+
+	if _, err := PollForProposalStatus(ctx, c, height, report.HaltHeight, prop.ProposalID, ProposalStatusPassed); err != nil {
+		return report, fmt.Errorf("upgrade proposal did not pass before the halt height: %w", err)
+	}
+	report.Passed = time.Now()
+
+	report.Halted, err = c.waitForHalt(ctx, report.HaltHeight, upgrade.Timeout)
+	if err != nil {
+		return report, err
+	}
+
+	if upgrade.PreUpgrade != nil {
+		if err := upgrade.PreUpgrade(ctx, c); err != nil {
+			return report, fmt.Errorf("pre-upgrade hook: %w", err)
+		}
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return report, fmt.Errorf("failed to stop nodes: %w", err)
+	}
+	if err := c.UpgradeImage(ctx, c.getFullNode().DockerClient, upgrade.Image); err != nil {
+		return report, fmt.Errorf("failed to pull upgrade image: %w", err)
+	}
+	if err := c.StartAllNodes(ctx); err != nil {
+		return report, fmt.Errorf("failed to start upgraded nodes: %w", err)
+	}
+	report.Restarted = time.Now()
+
+	resumeCtx, cancel := context.WithTimeout(ctx, upgrade.Timeout)
+	defer cancel()
+	if err := testutil.WaitForBlocks(resumeCtx, int(upgrade.BlocksAfterUpgrade), c); err != nil {
+		return report, fmt.Errorf("chain did not produce blocks after upgrade: %w", err)
+	}
+	report.Resumed = time.Now()
+
+	report.PostUpgradeBuild = c.getFullNode().GetBuildInformation(ctx)
+	if report.PostUpgradeBuild == nil {
+		return report, errors.New("failed to get build information of the upgraded binary")
+	}
+	if report.PreUpgradeBuild != nil && !binaryChanged(report.PreUpgradeBuild, report.PostUpgradeBuild) {
+		return report, fmt.Errorf("binary did not change after upgrade: version %s, commit %s",
+			report.PostUpgradeBuild.Version, report.PostUpgradeBuild.Commit)
+	}
+
+	c.log.Info("Chain upgraded",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("upgrade", upgrade.Name),
+		zap.String("image", upgrade.Image.Ref()),
+		zap.Uint64("halt_height", report.HaltHeight),
+		zap.Duration("halt_duration", report.HaltDuration()),
+		zap.Duration("downtime", report.DowntimeDuration()),
+		zap.Duration("total_duration", report.TotalDuration()),
+	)
+
+	if upgrade.PostUpgrade != nil {
+		if err := upgrade.PostUpgrade(ctx, c); err != nil {
+			return report, fmt.Errorf("post-upgrade hook: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// Polling of the chain height while waiting for it to halt at the upgrade height.
+const (
+	upgradeHaltPollInterval = 500 * time.Millisecond
+	// The height must not advance for this long after reaching the halt height
+	// for the chain to be considered halted, i.e. a few block times.
+	upgradeHaltStableWindow = 3 * blockTime * time.Second
+)
+
+// waitForHalt waits for the chain to stop producing blocks at haltHeight, and returns when it reached it.
+func (c *CosmosChain) waitForHalt(ctx context.Context, haltHeight uint64, timeout time.Duration) (time.Time, error) {
+	haltCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return waitForHeightHalt(haltCtx, c.Height, haltHeight, upgradeHaltPollInterval, upgradeHaltStableWindow)
+}
+
+// waitForHeightHalt polls height until it equals haltHeight and stops advancing for window.
+// It returns the time the halt height was first observed, which is when the chain halted.
+// It fails if the height exceeds haltHeight, or if ctx is done before the chain halted.
+func waitForHeightHalt(
+	ctx context.Context,
+	height func(context.Context) (uint64, error),
+	haltHeight uint64,
+	pollInterval, window time.Duration,
+) (time.Time, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		reachedAt time.Time
+		last      uint64
+		lastErr   error
+	)
+	for {
+		h, err := height(ctx)
+		switch {
+		case err != nil:
+			// The RPC may briefly fail while the nodes halt, so keep polling until the timeout.
+			lastErr = err
+		case h > haltHeight:
+			return time.Time{}, fmt.Errorf("chain passed the halt height %d, at height %d", haltHeight, h)
+		case h == haltHeight:
+			if reachedAt.IsZero() {
+				reachedAt = time.Now()
+			}
+			if time.Since(reachedAt) >= window {
+				return reachedAt, nil
+			}
+		}
+		if err == nil {
+			last = h
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return time.Time{}, fmt.Errorf("chain did not halt at height %d, last height %d: %w (last error: %v)", haltHeight, last, ctx.Err(), lastErr)
+			}
+			return time.Time{}, fmt.Errorf("chain did not halt at height %d, last height %d: %w", haltHeight, last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainUpgradeDefaults(t *testing.T) {
+	u := ChainUpgrade{}.withDefaults()
+	require.Equal(t, uint64(defaultUpgradeHaltHeightDelta), u.HaltHeightDelta)
+	require.Equal(t, uint64(defaultUpgradeBlocksAfterUpgrade), u.BlocksAfterUpgrade)
+	require.Equal(t, defaultUpgradeTimeout, u.Timeout)
+
+	err := u.validate()
+	require.ErrorContains(t, err, "upgrade name must be set")
+	require.ErrorContains(t, err, "deposit must be set")
+
+	u = ChainUpgrade{
+		Name:            "v2",
+		Image:           ibc.DockerImage{Repository: "ghcr.io/strangelove-ventures/heighliner/juno", Version: "v8.0.0"},
+		ProposerKeyName: "user",
+		Deposit:         "500000000ujuno",
+		Timeout:         time.Minute,
+	}.withDefaults()
+	require.NoError(t, u.validate())
+	require.Equal(t, time.Minute, u.Timeout)
+
+	// An image built from source needs neither a repository nor a version.
+	u.Image = ibc.DockerImage{Build: &ibc.DockerBuild{Context: "../juno"}}
+	require.NoError(t, u.validate())
+
+	u.Image = ibc.DockerImage{Version: "v8.0.0"}
+	require.ErrorContains(t, u.validate(), "upgrade image repository and version")
+}
+
+func TestBinaryChanged(t *testing.T) {
+	pre := &BinaryBuildInformation{Version: "v6.0.0", Commit: "abc"}
+	require.False(t, binaryChanged(pre, &BinaryBuildInformation{Version: "v6.0.0", Commit: "abc"}))
+	require.True(t, binaryChanged(pre, &BinaryBuildInformation{Version: "v8.0.0", Commit: "def"}))
+	require.True(t, binaryChanged(pre, &BinaryBuildInformation{Version: "v6.0.0", Commit: "def"}))
+}
+
+func TestUpgradeReportDurations(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := UpgradeReport{
+		Submitted: start,
+		Halted:    start.Add(30 * time.Second),
+		Resumed:   start.Add(50 * time.Second),
+	}
+	require.Equal(t, 30*time.Second, r.HaltDuration())
+	require.Equal(t, 20*time.Second, r.DowntimeDuration())
+	require.Equal(t, 50*time.Second, r.TotalDuration())
+}
+
+// fakeHeights returns a height func that returns heights in order, then repeats the last one.
+func fakeHeights(heights ...uint64) func(context.Context) (uint64, error) {
+	var mu sync.Mutex
+	return func(context.Context) (uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		h := heights[0]
+		if len(heights) > 1 {
+			heights = heights[1:]
+		}
+		return h, nil
+	}
+}
+
+func TestWaitForHeightHalt(t *testing.T) {
+	t.Parallel()
+
+	const (
+		poll   = time.Millisecond
+		window = 20 * time.Millisecond
+	)
+
+	t.Run("halts", func(t *testing.T) {
+		start := time.Now()
+		halted, err := waitForHeightHalt(context.Background(), fakeHeights(8, 9, 9, 10), 10, poll, window)
+		require.NoError(t, err)
+		require.True(t, halted.After(start))
+		// Returns once the window elapsed, reporting when the halt height was reached.
+		require.GreaterOrEqual(t, time.Since(halted), window)
+	})
+
+	t.Run("passes halt height", func(t *testing.T) {
+		_, err := waitForHeightHalt(context.Background(), fakeHeights(9, 10, 11), 10, poll, window)
+		require.ErrorContains(t, err, "chain passed the halt height 10, at height 11")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := waitForHeightHalt(ctx, fakeHeights(5), 10, poll, window)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "last height 5")
+	})
+
+	t.Run("height errors", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := waitForHeightHalt(ctx, func(context.Context) (uint64, error) {
+			return 0, errors.New("connection refused")
+		}, 10, poll, window)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "connection refused")
+	})
+}
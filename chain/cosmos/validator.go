@@ -0,0 +1,262 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// createValidatorGas is the gas funded to new validators, on top of their self delegation, for create-validator.
+const createValidatorGas = 1_000_000
+
+// StakingValidator is the subset of a staking validator returned by `query staking validator`.
+type StakingValidator struct {
+	OperatorAddress string   `json:"operator_address"`
+	Jailed          bool     `json:"jailed"`
+	Status          string   `json:"status"`
+	Tokens          math.Int `json:"tokens"`
+}
+
+// Bonded reports whether the validator is part of the active set.
+func (v StakingValidator) Bonded() bool {
+	return v.Status == "BOND_STATUS_BONDED"
+}
+
+// SigningInfo is the liveness information of a validator returned by `query slashing signing-info`.
+type SigningInfo struct {
+	Address             string    `json:"address"`
+	JailedUntil         time.Time `json:"jailed_until"`
+	Tombstoned          bool      `json:"tombstoned"`
+	MissedBlocksCounter string    `json:"missed_blocks_counter"`
+}
+
+// ValidatorPubKey returns the consensus public key of the node in JSON, as expected by create-validator.
+func (tn *ChainNode) ValidatorPubKey(ctx context.Context) (string, error) {
+	stdout, stderr, err := tn.ExecBin(ctx, "tendermint", "show-validator")
+	if err != nil {
+		return "", fmt.Errorf("failed to show validator pubkey (stderr=%q): %w", stderr, err)
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
+
+// ValidatorOperatorAddress returns the operator address of the node's validator key.
+func (tn *ChainNode) ValidatorOperatorAddress(ctx context.Context) (string, error) {
+	return tn.KeyBech32(ctx, valKey, "val")
+}
+
+// CreateValidator bonds the node as a validator with selfDelegation, signing with keyName.
+func (tn *ChainNode) CreateValidator(ctx context.Context, keyName string, selfDelegation types.Coin) error {
+	pubKey, err := tn.ValidatorPubKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tn.ExecTx(ctx, keyName,
+		"staking", "create-validator",
+		"--amount", selfDelegation.String(),
+		"--pubkey", pubKey,
+		"--moniker", CondenseMoniker(tn.Name()),
+		"--commission-rate", "0.1",
+		"--commission-max-rate", "0.2",
+		"--commission-max-change-rate", "0.01",
+		"--min-self-delegation", "1",
+		"--gas", "auto",
+	)
+	return err
+}
+
+// Unjail unjails the node's validator, signing with keyName.
+func (tn *ChainNode) Unjail(ctx context.Context, keyName string) error {
+	_, err := tn.ExecTx(ctx, keyName, "slashing", "unjail")
+	return err
+}
+
+// StakingQueryValidator returns the staking validator with the operator address valoper.
+func (tn *ChainNode) StakingQueryValidator(ctx context.Context, valoper string) (StakingValidator, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "staking", "validator", valoper)
+	if err != nil {
+		return StakingValidator{}, err
+	}
+
+	var val StakingValidator
+	if err := json.Unmarshal(stdout, &val); err != nil {
+		return StakingValidator{}, fmt.Errorf("failed to unmarshal validator: %w", err)
+	}
+	return val, nil
+}
+
+// SlashingQuerySigningInfo returns the signing info of the validator with the consensus public key pubKey,
+// as returned by ValidatorPubKey.
+func (tn *ChainNode) SlashingQuerySigningInfo(ctx context.Context, pubKey string) (SigningInfo, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "slashing", "signing-info", pubKey)
+	if err != nil {
+		return SigningInfo{}, err
+	}
+
+	var info SigningInfo
+	if err := json.Unmarshal(stdout, &info); err != nil {
+		return SigningInfo{}, fmt.Errorf("failed to unmarshal signing info: %w", err)
+	}
+	return info, nil
+}
+
+// AddValidators adds inc validators to the network after genesis.
+// Each new node joins as a fullnode, is funded by funderKeyName with selfDelegation plus fees,
+// and then bonds itself with create-validator once in sync with the chain.
+func (c *CosmosChain) AddValidators(
+	ctx context.Context,
+	configFileOverrides map[string]any,
+	inc int,
+	funderKeyName string,
+	selfDelegation types.Coin,
+) (ChainNodes, error) {
+	vals, err := c.addNodes(ctx, configFileOverrides, inc, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	heighters := make([]testutil.ChainHeighter, len(vals))
+	for i, val := range vals {
+		heighters[i] = val
+	}
+	if err := testutil.WaitForInSync(ctx, c, heighters...); err != nil {
+		return nil, fmt.Errorf("waiting for new validators to sync: %w", err)
+	}
+
+	fees := math.NewInt(c.GetGasFeesInNativeDenom(createValidatorGas))
+	for _, val := range vals {
+		if err := val.CreateKey(ctx, valKey); err != nil {
+			return nil, err
+		}
+		addr, err := val.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return nil, err
+		}
+
+		funds := types.NewCoins(selfDelegation).Add(types.NewCoin(c.cfg.Denom, fees))
+		for _, coin := range funds {
+			// Funds are sent one after another, since they come from the same account.
+			if err := c.SendFunds(ctx, funderKeyName, ibc.WalletAmount{
+				Address: addr,
+				Denom:   coin.Denom,
+				Amount:  coin.Amount,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to fund validator %s: %w", val.Name(), err)
+			}
+		}
+
+		if err := val.CreateValidator(ctx, valKey, selfDelegation); err != nil {
+			return nil, fmt.Errorf("failed to create validator %s: %w", val.Name(), err)
+		}
+	}
+
+	return vals, nil
+}
+
+// JailValidator stops the validator val until it is jailed for downtime, then restarts it.
+// The chain's slashing params (e.g. signed_blocks_window) determine how long this takes,
+// so tests will usually shorten them in genesis.
+func (c *CosmosChain) JailValidator(ctx context.Context, val *ChainNode, timeout time.Duration) error {
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	observer := c.otherNode(val)
+	if observer == nil {
+		return fmt.Errorf("no other node to observe validator %s", val.Name())
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return err
+	}
+
+	if err := testutil.WaitForCondition(timeout, time.Second, func() (bool, error) {
+		v, err := observer.StakingQueryValidator(ctx, valoper)
+		if err != nil {
+			return false, err
+		}
+		return v.Jailed, nil
+	}); err != nil {
+		return fmt.Errorf("waiting for validator %s to be jailed: %w", val.Name(), err)
+	}
+
+	if err := val.StartContainer(ctx); err != nil {
+		return err
+	}
+	return testutil.WaitForInSync(ctx, c, val)
+}
+
+// UnjailValidator unjails the validator val, which must have been jailed for longer than
+// the chain's downtime_jail_duration.
+func (c *CosmosChain) UnjailValidator(ctx context.Context, val *ChainNode) error {
+	if err := val.Unjail(ctx, valKey); err != nil {
+		return fmt.Errorf("failed to unjail validator %s: %w", val.Name(), err)
+	}
+
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	v, err := val.StakingQueryValidator(ctx, valoper)
+	if err != nil {
+		return err
+	}
+	if v.Jailed {
+		return fmt.Errorf("validator %s is still jailed", val.Name())
+	}
+	return nil
+}
+
+// DoubleSign makes the validator val double sign by starting a new fullnode with a copy of its
+// priv_validator_key.json, which leads to the validator being slashed and tombstoned once the
+// evidence is committed. The returned node is the double signer; tests should remove it once done.
+func (c *CosmosChain) DoubleSign(ctx context.Context, val *ChainNode) (*ChainNode, error) {
+	privVal, err := val.privValFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := c.addNodes(ctx, nil, 1, false, func(ctx context.Context, fn *ChainNode) error {
+		return fn.overwritePrivValFile(ctx, privVal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// WaitForTombstone waits until the validator val is tombstoned.
+func (c *CosmosChain) WaitForTombstone(ctx context.Context, val *ChainNode, timeout time.Duration) error {
+	pubKey, err := val.ValidatorPubKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	return testutil.WaitForCondition(timeout, time.Second, func() (bool, error) {
+		info, err := c.getFullNode().SlashingQuerySigningInfo(ctx, pubKey)
+		if err != nil {
+			return false, err
+		}
+		return info.Tombstoned, nil
+	})
+}
+
+// otherNode returns a node of the chain other than tn, preferring fullnodes.
+func (c *CosmosChain) otherNode(tn *ChainNode) *ChainNode {
+	for _, nodes := range []ChainNodes{c.FullNodes, c.Validators} {
+		for _, n := range nodes {
+			if n != tn {
+				return n
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakingValidatorJSON(t *testing.T) {
+	const out = `{
+		"operator_address": "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+		"consensus_pubkey": {"@type": "/cosmos.crypto.ed25519.PubKey", "key": "AAAA"},
+		"jailed": true,
+		"status": "BOND_STATUS_UNBONDING",
+		"tokens": "1000000",
+		"delegator_shares": "1000000.000000000000000000"
+	}`
+
+	var val cosmos.StakingValidator
+	require.NoError(t, json.Unmarshal([]byte(out), &val))
+	require.Equal(t, "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", val.OperatorAddress)
+	require.True(t, val.Jailed)
+	require.False(t, val.Bonded())
+	require.Equal(t, math.NewInt(1_000_000), val.Tokens)
+}
+
+func TestSigningInfoJSON(t *testing.T) {
+	const out = `{
+		"address": "cosmosvalcons1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5wfc2w4",
+		"start_height": "0",
+		"index_offset": "12",
+		"jailed_until": "9999-12-31T23:59:59.999999999Z",
+		"tombstoned": true,
+		"missed_blocks_counter": "3"
+	}`
+
+	var info cosmos.SigningInfo
+	require.NoError(t, json.Unmarshal([]byte(out), &info))
+	require.True(t, info.Tombstoned)
+	require.Equal(t, 9999, info.JailedUntil.Year())
+	require.Equal(t, "3", info.MissedBlocksCounter)
+}
@@ -2,6 +2,7 @@ package cosmos
 
 import (
 	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
@@ -46,3 +47,8 @@ func (w *CosmosWallet) Address() []byte {
 func (w *CosmosWallet) FormattedAddressWithPrefix(prefix string) string {
 	return types.MustBech32ifyAddressBytes(prefix, w.address)
 }
+
+// HexAddress returns the address in the Ethereum hex format, as used by the EVM of Ethermint-style chains.
+func (w *CosmosWallet) HexAddress() string {
+	return common.BytesToAddress(w.address).Hex()
+}
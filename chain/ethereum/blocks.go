@@ -0,0 +1,203 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+var (
+	_ blockdb.TxFinder     = &EthereumChain{}
+	_ blockdb.BlockFinder  = &EthereumChain{}
+	_ blockdb.EVMABIFinder = &EthereumChain{}
+)
+
+// RegisterABI registers the JSON ABI of the contract at address, so that the block database
+// and its TUI can decode the calls to the contract and its logs.
+// The ABI is saved with the next block saved to the block database.
+func (c *EthereumChain) RegisterABI(address string, abiJSON string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid contract address %q", address)
+	}
+	if _, err := abi.JSON(strings.NewReader(abiJSON)); err != nil {
+		return fmt.Errorf("invalid abi of %s: %w", address, err)
+	}
+
+	c.abisMu.Lock()
+	defer c.abisMu.Unlock()
+	c.abis[strings.ToLower(address)] = abiJSON
+	return nil
+}
+
+// EVMABIs implements blockdb.EVMABIFinder, returning a copy of the registered ABIs keyed by lowercase address.
+func (c *EthereumChain) EVMABIs() map[string]string {
+	c.abisMu.Lock()
+	defer c.abisMu.Unlock()
+
+	abis := make(map[string]string, len(c.abis))
+	for addr, a := range c.abis {
+		abis[addr] = a
+	}
+	return abis
+}
+
+// FindTxs implements blockdb.TxFinder, returning the transactions of the block at height with their receipts.
+func (c *EthereumChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	block, err := c.FindBlock(ctx, height)
+	return block.Txs, err
+}
+
+// FindBlock implements blockdb.BlockFinder.
+func (c *EthereumChain) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return blockdb.Block{}, err
+	}
+	defer client.Close()
+
+	var block *struct {
+		Timestamp    hexutil.Uint64    `json:"timestamp"`
+		Transactions []json.RawMessage `json:"transactions"`
+	}
+	if err := client.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(height), true); err != nil {
+		return blockdb.Block{}, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+	if block == nil {
+		// Worded as the Tendermint error, which the blockdb.Collector expects while waiting for the next block.
+		return blockdb.Block{}, fmt.Errorf("height %d must be less than or equal to the current blockchain height", height)
+	}
+
+	abis := c.EVMABIs()
+
+	txs := make([]blockdb.Tx, 0, len(block.Transactions))
+	for _, rawTx := range block.Transactions {
+		var hash struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if err := json.Unmarshal(rawTx, &hash); err != nil {
+			return blockdb.Block{}, fmt.Errorf("failed to decode transaction of block %d: %w", height, err)
+		}
+
+		var rawReceipt json.RawMessage
+		if err := client.CallContext(ctx, &rawReceipt, "eth_getTransactionReceipt", hash.Hash); err != nil {
+			return blockdb.Block{}, fmt.Errorf("failed to get receipt of transaction %s: %w", hash.Hash, err)
+		}
+
+		tx, err := blockdbTx(rawTx, rawReceipt, abis)
+		if err != nil {
+			return blockdb.Block{}, fmt.Errorf("transaction %s: %w", hash.Hash, err)
+		}
+		txs = append(txs, tx)
+	}
+	return blockdb.Block{Time: time.Unix(int64(block.Timestamp), 0), Txs: txs}, nil
+}
+
+// rpcTransaction has the fields of a JSON-RPC transaction that are saved to the block database.
+type rpcTransaction struct {
+	Hash     common.Hash     `json:"hash"`
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Input    hexutil.Bytes   `json:"input"`
+}
+
+// rpcReceipt has the fields of a JSON-RPC transaction receipt that are saved to the block database.
+type rpcReceipt struct {
+	ContractAddress   *common.Address `json:"contractAddress"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	Status            hexutil.Uint64  `json:"status"`
+	Logs              []struct {
+		Address  common.Address `json:"address"`
+		Topics   []common.Hash  `json:"topics"`
+		Data     hexutil.Bytes  `json:"data"`
+		LogIndex hexutil.Uint64 `json:"logIndex"`
+	} `json:"logs"`
+}
+
+// blockdbTx converts a JSON-RPC transaction and its receipt, attaching the ABIs of the contracts they refer to.
+func blockdbTx(rawTx, rawReceipt json.RawMessage, abis map[string]string) (blockdb.Tx, error) {
+	var tx rpcTransaction
+	if err := json.Unmarshal(rawTx, &tx); err != nil {
+		return blockdb.Tx{}, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	var receipt rpcReceipt
+	if err := json.Unmarshal(rawReceipt, &receipt); err != nil {
+		return blockdb.Tx{}, fmt.Errorf("failed to decode receipt: %w", err)
+	}
+
+	evmTx := &blockdb.EVMTx{
+		Hash:    tx.Hash.Hex(),
+		From:    tx.From.Hex(),
+		Value:   "0",
+		Gas:     uint64(tx.Gas),
+		GasUsed: uint64(receipt.GasUsed),
+		Status:  uint64(receipt.Status),
+		Input:   hexutil.Encode(tx.Input),
+	}
+	if tx.To != nil {
+		evmTx.To = tx.To.Hex()
+	}
+	if receipt.ContractAddress != nil {
+		evmTx.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	if tx.Value != nil {
+		evmTx.Value = tx.Value.ToInt().String()
+	}
+	// The effective gas price of the receipt accounts for dynamic fee transactions.
+	switch {
+	case receipt.EffectiveGasPrice != nil:
+		evmTx.GasPrice = receipt.EffectiveGasPrice.ToInt().String()
+	case tx.GasPrice != nil:
+		evmTx.GasPrice = tx.GasPrice.ToInt().String()
+	default:
+		evmTx.GasPrice = "0"
+	}
+
+	addrs := []string{evmTx.To, evmTx.ContractAddress}
+	for _, l := range receipt.Logs {
+		topics := make([]string, len(l.Topics))
+		for i, topic := range l.Topics {
+			topics[i] = topic.Hex()
+		}
+		evmTx.Logs = append(evmTx.Logs, blockdb.EVMLog{
+			Index:   uint64(l.LogIndex),
+			Address: l.Address.Hex(),
+			Topics:  topics,
+			Data:    hexutil.Encode(l.Data),
+		})
+		addrs = append(addrs, l.Address.Hex())
+	}
+
+	for _, addr := range addrs {
+		if a, ok := abis[strings.ToLower(addr)]; ok && addr != "" {
+			if evmTx.ABIs == nil {
+				evmTx.ABIs = make(map[string]string)
+			}
+			evmTx.ABIs[strings.ToLower(addr)] = a
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Transaction json.RawMessage `json:"transaction"`
+		Receipt     json.RawMessage `json:"receipt"`
+	}{rawTx, rawReceipt})
+	if err != nil {
+		return blockdb.Tx{}, err
+	}
+
+	var code uint32
+	if evmTx.Status != 1 {
+		code = 1
+	}
+	return blockdb.Tx{Data: data, Code: code, EVM: evmTx}, nil
+}
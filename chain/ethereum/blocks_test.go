@@ -0,0 +1,77 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRegisterABI(t *testing.T) {
+	c := NewEthereumChain(t.Name(), DefaultEthereumAnvilChainConfig("anvil"), zap.NewNop())
+
+	require.NoError(t, c.RegisterABI("0x5FbDB2315678afecb367f032d93F642f64180aa3", `[]`))
+	require.Equal(t, map[string]string{"0x5fbdb2315678afecb367f032d93f642f64180aa3": `[]`}, c.abis)
+
+	require.Error(t, c.RegisterABI("not an address", `[]`))
+	require.Error(t, c.RegisterABI("0x5FbDB2315678afecb367f032d93F642f64180aa3", `{`))
+}
+
+func TestBlockdbTx(t *testing.T) {
+	const (
+		rawTx = `{
+  "hash": "0x2b1e6a6ab54d5b8e1d5e8f53bc0c4a7b1f0c8a5f8a8c2d6f3e1b9a4c7d0e2f31",
+  "from": "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+  "to": "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+  "value": "0x21e19e0c9bab2400000",
+  "gas": "0xea60",
+  "gasPrice": "0x77359400",
+  "input": "0xa9059cbb"
+}`
+		rawReceipt = `{
+  "contractAddress": null,
+  "gasUsed": "0xc738",
+  "effectiveGasPrice": "0x3b9aca00",
+  "status": "0x1",
+  "logs": [{
+    "address": "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+    "topics": ["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],
+    "data": "0x",
+    "logIndex": "0x3"
+  }]
+}`
+	)
+
+	tx, err := blockdbTx(json.RawMessage(rawTx), json.RawMessage(rawReceipt), map[string]string{
+		"0x5fbdb2315678afecb367f032d93f642f64180aa3": `[]`,
+		"0x70997970c51812dc3a010c7d01b50e0d17dc79c8": `[]`,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &blockdb.EVMTx{
+		Hash:     "0x2b1e6a6ab54d5b8e1d5e8f53bc0c4a7b1f0c8a5f8a8c2d6f3e1b9a4c7d0e2f31",
+		From:     "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		To:       "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		Value:    "10000000000000000000000",
+		GasPrice: "1000000000",
+		Gas:      60000,
+		GasUsed:  51000,
+		Status:   1,
+		Input:    "0xa9059cbb",
+		Logs: []blockdb.EVMLog{{
+			Index:   3,
+			Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+			Topics:  []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+			Data:    "0x",
+		}},
+		ABIs: map[string]string{"0x5fbdb2315678afecb367f032d93f642f64180aa3": `[]`},
+	}, tx.EVM)
+	require.Zero(t, tx.Code)
+
+	var data map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(tx.Data, &data))
+	require.JSONEq(t, rawTx, string(data["transaction"]))
+	require.JSONEq(t, rawReceipt, string(data["receipt"]))
+}
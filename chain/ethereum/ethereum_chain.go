@@ -3,14 +3,14 @@ package ethereum
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
-	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
@@ -51,6 +51,10 @@ type EthereumChain struct {
 	genesisWallets GenesisWallets
 
 	keystoreMap map[string]string
+
+	// Contract ABIs registered with RegisterABI, keyed by lowercase address.
+	abisMu sync.Mutex
+	abis   map[string]string
 }
 
 func DefaultEthereumAnvilChainConfig(
@@ -80,6 +84,8 @@ func DefaultEthereumAnvilChainConfig(
 				no matching manifest for linux/arm64/v8 in the manifest list entries
 
 			Update: To fix: We built the image locally but also pushed an image to 'biphan4/foundry'
+
+			A foundry checkout can also be built for the host platform by setting Build on the image.
 		*/
 		Images: []ibc.DockerImage{
 			{
@@ -99,6 +105,7 @@ func NewEthereumChain(testName string, chainConfig ibc.ChainConfig, log *zap.Log
 		log:            log,
 		genesisWallets: NewGenesisWallet(),
 		keystoreMap:    make(map[string]string),
+		abis:           make(map[string]string),
 	}
 }
 
@@ -108,7 +115,9 @@ func (c *EthereumChain) Config() ibc.ChainConfig {
 
 func (c *EthereumChain) Initialize(ctx context.Context, testName string, cli *dockerclient.Client, networkID string) error {
 	chainCfg := c.Config()
-	c.pullImages(ctx, cli)
+	if err := c.pullImages(ctx, cli); err != nil {
+		return err
+	}
 	image := chainCfg.Images[0]
 
 	c.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, cli, c.Name())
@@ -174,38 +183,18 @@ func (c *EthereumChain) Bind() []string {
 	}
 }
 
-func (c *EthereumChain) pullImages(ctx context.Context, cli *dockerclient.Client) {
+// pullImages pulls the chain's images, or builds those that are built from source.
+func (c *EthereumChain) pullImages(ctx context.Context, cli *dockerclient.Client) error {
 	for _, image := range c.Config().Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		fmt.Println("Pulled images\n")
-		fmt.Println(image.Repository)
-		fmt.Println(image.Version)
-
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
+		if err := dockerutil.EnsureImage(ctx, c.log, cli, image); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
-	cmd := []string{
-		c.cfg.Bin,
-		"--host", "0.0.0.0", // Anyone can call
-		"--block-time", "2", // 2 second block times
-		"--accounts", "10", // We currently only use the first account for the faucet, but tests may expect the default
-		"--balance", "10000000", // Genesis accounts loaded with 10mil ether, change as needed
-	}
+	cmd := c.anvilArgs()
 
 	var mounts []mount.Mount
 	if loadState, ok := c.cfg.ConfigFileOverrides["--load-state"].(string); ok {
@@ -213,7 +202,10 @@ func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGe
 		if err != nil {
 			return err
 		}
-		localJsonFile := filepath.Join(pwd, loadState)
+		localJsonFile := loadState
+		if !filepath.IsAbs(localJsonFile) {
+			localJsonFile = filepath.Join(pwd, loadState)
+		}
 		dockerJsonFile := c.HomeDir() + path.Base(loadState)
 		mounts = []mount.Mount{
 			{
@@ -227,6 +219,7 @@ func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGe
 	// Might need mounts later, but 'deactivate' for now
 	c.log.Info(fmt.Sprintf("%v", mounts))
 
+	c.containerLifecycle.SetResourceLimits(c.cfg.ResourceLimits)
 	err := c.containerLifecycle.CreateContainerWithMounts(ctx, c.testName, c.NetworkID, c.cfg.Images[0], natPorts, c.Bind(), mounts, c.HostName(), cmd)
 	if err != nil {
 		return err
@@ -249,6 +242,44 @@ func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGe
 	return testutil.WaitForBlocks(ctx, 2, c)
 }
 
+// anvilArgs returns the anvil command of the chain. Keys of ChainConfig.ConfigFileOverrides that are anvil flags,
+// such as "--block-time" or "--fork-url", replace the defaults or are appended; true booleans are passed as switches.
+// "--load-state" is handled separately by Start, since the file must be mounted.
+func (c *EthereumChain) anvilArgs() []string {
+	flags := map[string]any{
+		"--block-time": blockTime, // 2 second block times
+		"--accounts":   10,        // We currently only use the first account for the faucet, but tests may expect the default
+		"--balance":    10000000,  // Genesis accounts loaded with 10mil ether, change as needed
+	}
+	for k, v := range c.cfg.ConfigFileOverrides {
+		if strings.HasPrefix(k, "--") && k != "--load-state" {
+			flags[k] = v
+		}
+	}
+
+	names := make([]string, 0, len(flags))
+	for k := range flags {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	cmd := []string{
+		c.cfg.Bin,
+		"--host", "0.0.0.0", // Anyone can call
+	}
+	for _, k := range names {
+		switch v := flags[k].(type) {
+		case bool:
+			if v {
+				cmd = append(cmd, k)
+			}
+		default:
+			cmd = append(cmd, k, fmt.Sprint(v))
+		}
+	}
+	return cmd
+}
+
 func (c *EthereumChain) HostName() string {
 	return dockerutil.CondenseHostName(c.Name())
 }
@@ -307,7 +338,8 @@ func (c *EthereumChain) BuildWallet(ctx context.Context, keyName string, mnemoni
 }
 
 func (c *EthereumChain) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
-	job := dockerutil.NewImage(c.logger(), c.DockerClient, c.NetworkID, c.testName, c.cfg.Images[0].Repository, c.cfg.Images[0].Version)
+	repo, tag := c.cfg.Images[0].RepositoryAndTag()
+	job := dockerutil.NewImage(c.logger(), c.DockerClient, c.NetworkID, c.testName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: c.Bind(),
@@ -330,3 +362,14 @@ func (c *EthereumChain) GetRPCAddress() string {
 func (c *EthereumChain) GetHostRPCAddress() string {
 	return "http://" + c.hostRPCPort
 }
+
+// GetWSAddress returns the WebSocket endpoint of the chain within the docker network.
+// Anvil serves WebSocket connections on its JSON-RPC port.
+func (c *EthereumChain) GetWSAddress() string {
+	return fmt.Sprintf("ws://%s:8545", c.HostName())
+}
+
+// GetHostWSAddress returns the WebSocket endpoint of the chain reachable from the host.
+func (c *EthereumChain) GetHostWSAddress() string {
+	return "ws://" + c.hostRPCPort
+}
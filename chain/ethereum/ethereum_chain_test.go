@@ -0,0 +1,57 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAnvilArgs(t *testing.T) {
+	cfg := DefaultEthereumAnvilChainConfig("anvil")
+
+	c := NewEthereumChain(t.Name(), cfg, zap.NewNop())
+	require.Equal(t, []string{
+		"anvil", "--host", "0.0.0.0",
+		"--accounts", "10",
+		"--balance", "10000000",
+		"--block-time", "2",
+	}, c.anvilArgs())
+
+	cfg.ConfigFileOverrides = map[string]any{
+		"--block-time":    1,
+		"--chain-id":      "1337",
+		"--steps-tracing": true,
+		"--no-mining":     false,
+		"--load-state":    "state.json",
+		"config/app.toml": "ignored",
+	}
+	c = NewEthereumChain(t.Name(), cfg, zap.NewNop())
+	require.Equal(t, []string{
+		"anvil", "--host", "0.0.0.0",
+		"--accounts", "10",
+		"--balance", "10000000",
+		"--block-time", "1",
+		"--chain-id", "1337",
+		"--steps-tracing",
+	}, c.anvilArgs())
+}
+
+func TestTransactionArgs(t *testing.T) {
+	const from = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+	args, err := Transaction{From: from, Data: []byte{0x60, 0x80}}.args()
+	require.NoError(t, err)
+	require.Equal(t, from, args.From.Hex())
+	require.Nil(t, args.To, "deployments have no recipient")
+
+	args, err = Transaction{From: from, To: from}.args()
+	require.NoError(t, err)
+	require.Equal(t, from, args.To.Hex())
+
+	_, err = Transaction{From: "juno1abc"}.args()
+	require.Error(t, err)
+
+	_, err = Transaction{From: from, To: "0x1234"}.args()
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package ethereum
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// receiptTimeout bounds the wait for a transaction to be mined.
+const receiptTimeout = time.Minute
+
+// Transaction is a transaction sent by SendTransaction.
+type Transaction struct {
+	// From must be an account anvil signs for, such as its genesis accounts.
+	From string
+	// To is the recipient, or empty to deploy the contract in Data.
+	To    string
+	Value *big.Int
+	Data  []byte
+}
+
+// txArgs are the arguments of eth_sendTransaction.
+type txArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Data  hexutil.Bytes   `json:"data,omitempty"`
+}
+
+func (tx Transaction) args() (txArgs, error) {
+	if !common.IsHexAddress(tx.From) {
+		return txArgs{}, fmt.Errorf("invalid from address %q", tx.From)
+	}
+	args := txArgs{
+		From:  common.HexToAddress(tx.From),
+		Value: (*hexutil.Big)(tx.Value),
+		Data:  tx.Data,
+	}
+	if tx.To != "" {
+		if !common.IsHexAddress(tx.To) {
+			return txArgs{}, fmt.Errorf("invalid to address %q", tx.To)
+		}
+		to := common.HexToAddress(tx.To)
+		args.To = &to
+	}
+	return args, nil
+}
+
+// dial connects to the JSON-RPC endpoint of the chain from the host.
+func (c *EthereumChain) dial(ctx context.Context) (*rpc.Client, error) {
+	client, err := rpc.DialContext(ctx, c.GetHostRPCAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", c.GetHostRPCAddress(), err)
+	}
+	return client, nil
+}
+
+// SendTransaction sends tx, signed by anvil for its From account, and returns its receipt once mined.
+// It returns an error if the transaction reverted.
+func (c *EthereumChain) SendTransaction(ctx context.Context, tx Transaction) (*types.Receipt, error) {
+	args, err := tx.args()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var hash common.Hash
+	if err := client.CallContext(ctx, &hash, "eth_sendTransaction", args); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	var receipt *types.Receipt
+	ec := ethclient.NewClient(client)
+	if err := testutil.WaitForCondition(receiptTimeout, 500*time.Millisecond, func() (bool, error) {
+		receipt, err = ec.TransactionReceipt(ctx, hash)
+		if errors.Is(err, goethereum.NotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for receipt of transaction %s: %w", hash, err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", hash)
+	}
+	return receipt, nil
+}
+
+// DeployContract deploys the contract creation bytecode from the account from and returns the receipt,
+// whose ContractAddress is the address of the new contract.
+func (c *EthereumChain) DeployContract(ctx context.Context, from string, bytecode []byte) (*types.Receipt, error) {
+	if len(bytecode) == 0 {
+		return nil, errors.New("empty contract bytecode")
+	}
+	return c.SendTransaction(ctx, Transaction{From: from, Data: bytecode})
+}
+
+// CallContract executes a read-only call of the contract at to with the ABI encoded data
+// against the latest block, and returns the result.
+func (c *EthereumChain) CallContract(ctx context.Context, to string, data []byte) ([]byte, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid contract address %q", to)
+	}
+	addr := common.HexToAddress(to)
+
+	client, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	res, err := ethclient.NewClient(client).CallContract(ctx, goethereum.CallMsg{To: &addr, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract %s: %w", to, err)
+	}
+	return res, nil
+}
+
+// Mine mines the given number of blocks immediately, regardless of the block time.
+func (c *EthereumChain) Mine(ctx context.Context, blocks uint64) error {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.CallContext(ctx, nil, "anvil_mine", hexutil.Uint64(blocks)); err != nil {
+		return fmt.Errorf("failed to mine %d blocks: %w", blocks, err)
+	}
+	return nil
+}
+
+// Snapshot snapshots the state of the chain and returns the snapshot id, to be passed to Revert.
+func (c *EthereumChain) Snapshot(ctx context.Context) (string, error) {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	var id string
+	if err := client.CallContext(ctx, &id, "evm_snapshot"); err != nil {
+		return "", fmt.Errorf("failed to snapshot state: %w", err)
+	}
+	return id, nil
+}
+
+// Revert reverts the state of the chain to the snapshot id. A snapshot can only be reverted to once.
+func (c *EthereumChain) Revert(ctx context.Context, id string) error {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var reverted bool
+	if err := client.CallContext(ctx, &reverted, "evm_revert", id); err != nil {
+		return fmt.Errorf("failed to revert to snapshot %s: %w", id, err)
+	}
+	if !reverted {
+		return fmt.Errorf("snapshot %s not found", id)
+	}
+	return nil
+}
+
+// DumpState returns the state of the chain as JSON, to be loaded with the "--load-state" ConfigFileOverrides.
+func (c *EthereumChain) DumpState(ctx context.Context) ([]byte, error) {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var state hexutil.Bytes
+	if err := client.CallContext(ctx, &state, "anvil_dumpState"); err != nil {
+		return nil, fmt.Errorf("failed to dump state: %w", err)
+	}
+
+	// Recent anvil versions gzip the dumped state, which --load-state does not accept.
+	if !bytes.HasPrefix(state, []byte{0x1f, 0x8b}) {
+		return state, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(state))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress state: %w", err)
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
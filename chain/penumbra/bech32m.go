@@ -0,0 +1,138 @@
+package penumbra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Penumbra addresses are encoded with bech32m (BIP-350) and are longer than the 90 characters allowed by BIP-173,
+// so neither the bech32 package used by the SDK nor its length limit apply.
+
+const (
+	bech32Charset     = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32mConstant   = 0x2bc830a3
+	bech32ChecksumLen = 6
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups data from groups of fromBits bits to groups of toBits bits.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+		maxv = uint32(1)<<toBits - 1
+	)
+	for _, b := range data {
+		if uint32(b)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d", b)
+		}
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+// EncodeBech32m encodes data with the human-readable part hrp using bech32m.
+func EncodeBech32m(hrp string, data []byte) (string, error) {
+	if hrp == "" {
+		return "", errors.New("empty human-readable part")
+	}
+	hrp = strings.ToLower(hrp)
+
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	polymod := bech32Polymod(append(append(bech32HrpExpand(hrp), values...), make([]byte, bech32ChecksumLen)...)) ^ bech32mConstant
+	for i := 0; i < bech32ChecksumLen; i++ {
+		values = append(values, byte(polymod>>uint(5*(5-i))&31))
+	}
+
+	var b strings.Builder
+	b.Grow(len(hrp) + 1 + len(values))
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	return b.String(), nil
+}
+
+// DecodeBech32m decodes a bech32m string into its human-readable part and data.
+func DecodeBech32m(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.New("mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+bech32ChecksumLen+1 > len(s) {
+		return "", nil, errors.New("invalid separator position")
+	}
+	hrp := s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, fmt.Errorf("invalid character in human-readable part: %q", hrp[i])
+		}
+	}
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid character in data: %q", s[i])
+		}
+		values = append(values, byte(v))
+	}
+
+	if bech32Polymod(append(bech32HrpExpand(hrp), values...)) != bech32mConstant {
+		return "", nil, errors.New("invalid bech32m checksum")
+	}
+
+	data, err := convertBits(values[:len(values)-bech32ChecksumLen], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
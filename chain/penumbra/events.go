@@ -0,0 +1,90 @@
+package penumbra
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// IBC event types emitted by pd, which follow the ibc-go event specification.
+const (
+	eventAcknowledgePacket = "acknowledge_packet"
+	eventTimeoutPacket     = "timeout_packet"
+)
+
+// packetFromEvent builds a packet from the packet_* attributes of an IBC event.
+// The acknowledge_packet and timeout_packet events do not include the packet data,
+// so Data is only populated when the event has a packet_data_hex attribute.
+func packetFromEvent(event abcitypes.Event) (ibc.Packet, error) {
+	events := []abcitypes.Event{event}
+	attr := func(key string) string {
+		v, _ := tendermint.AttributeValue(events, event.Type, key)
+		return v
+	}
+
+	seq, err := strconv.ParseUint(attr("packet_sequence"), 10, 64)
+	if err != nil {
+		return ibc.Packet{}, fmt.Errorf("invalid packet_sequence: %w", err)
+	}
+
+	packet := ibc.Packet{
+		Sequence:      seq,
+		SourcePort:    attr("packet_src_port"),
+		SourceChannel: attr("packet_src_channel"),
+		DestPort:      attr("packet_dst_port"),
+		DestChannel:   attr("packet_dst_channel"),
+		TimeoutHeight: attr("packet_timeout_height"),
+	}
+
+	if ts := attr("packet_timeout_timestamp"); ts != "" {
+		nanos, err := strconv.ParseUint(ts, 10, 64)
+		if err != nil {
+			return ibc.Packet{}, fmt.Errorf("invalid packet_timeout_timestamp: %w", err)
+		}
+		packet.TimeoutTimestamp = ibc.Nanoseconds(nanos)
+	}
+
+	if data := attr("packet_data_hex"); data != "" {
+		if packet.Data, err = hex.DecodeString(data); err != nil {
+			return ibc.Packet{}, fmt.Errorf("invalid packet_data_hex: %w", err)
+		}
+	}
+
+	return packet, nil
+}
+
+// packetAcknowledgements returns the packets of the acknowledge_packet events in events.
+func packetAcknowledgements(events []abcitypes.Event) ([]ibc.PacketAcknowledgement, error) {
+	var acks []ibc.PacketAcknowledgement
+	for _, event := range events {
+		if event.Type != eventAcknowledgePacket {
+			continue
+		}
+		packet, err := packetFromEvent(event)
+		if err != nil {
+			return nil, fmt.Errorf("%s event: %w", event.Type, err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{Packet: packet})
+	}
+	return acks, nil
+}
+
+// packetTimeouts returns the packets of the timeout_packet events in events.
+func packetTimeouts(events []abcitypes.Event) ([]ibc.PacketTimeout, error) {
+	var timeouts []ibc.PacketTimeout
+	for _, event := range events {
+		if event.Type != eventTimeoutPacket {
+			continue
+		}
+		packet, err := packetFromEvent(event)
+		if err != nil {
+			return nil, fmt.Errorf("%s event: %w", event.Type, err)
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{Packet: packet})
+	}
+	return timeouts, nil
+}
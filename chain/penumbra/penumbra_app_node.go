@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"cosmossdk.io/math"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
@@ -64,8 +66,7 @@ func (p *PenumbraAppNode) HomeDir() string {
 }
 
 func (p *PenumbraAppNode) CreateKey(ctx context.Context, keyName string) error {
-	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
-	cmd := []string{"pcli", "-d", keyPath, "keys", "generate"}
+	cmd := []string{"pcli", "-d", p.keyPath(keyName), "keys", "generate"}
 	_, stderr, err := p.Exec(ctx, cmd, nil)
 	// already exists error is okay
 	if err != nil && !strings.Contains(string(stderr), "already exists, refusing to overwrite it") {
@@ -76,8 +77,7 @@ func (p *PenumbraAppNode) CreateKey(ctx context.Context, keyName string) error {
 
 // RecoverKey restores a key from a given mnemonic.
 func (p *PenumbraAppNode) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
-	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
-	cmd := []string{"pcli", "-d", keyPath, "keys", "import", "phrase", mnemonic}
+	cmd := []string{"pcli", "-d", p.keyPath(keyName), "keys", "import", "phrase", mnemonic}
 	_, stderr, err := p.Exec(ctx, cmd, nil)
 	// already exists error is okay
 	if err != nil && !strings.Contains(string(stderr), "already exists, refusing to overwrite it") {
@@ -89,10 +89,9 @@ func (p *PenumbraAppNode) RecoverKey(ctx context.Context, keyName, mnemonic stri
 // initializes validator definition template file
 // wallet must be generated first
 func (p *PenumbraAppNode) InitValidatorFile(ctx context.Context, valKeyName string) error {
-	keyPath := filepath.Join(p.HomeDir(), "keys", valKeyName)
 	cmd := []string{
 		"pcli",
-		"-d", keyPath,
+		"-d", p.keyPath(valKeyName),
 		"validator", "definition", "template",
 		"--file", p.ValidatorDefinitionTemplateFilePathContainer(),
 	}
@@ -155,44 +154,119 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 	return err
 }
 
+// GetAddress returns the raw bytes of the default address of the key named keyName.
 func (p *PenumbraAppNode) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	cmd := []string{"pcli", "-d", p.HomeDir(), "addr", "list"}
-	stdout, _, err := p.Exec(ctx, cmd, nil)
+	address, err := p.GetAddressBech32m(ctx, keyName)
 	if err != nil {
 		return nil, err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
-		if len(fields) < 3 {
-			continue
-		}
-		if fields[1] == keyName {
-			// TODO penumbra address is bech32m. need to decode to bytes here
-			return []byte(fields[2]), nil
-		}
+	_, bz, err := DecodeBech32m(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode address %s: %w", address, err)
 	}
-	return []byte{}, errors.New("address not found")
+	return bz, nil
 }
 
+// GetAddressBech32m returns the bech32m encoded default address, i.e. the address of account 0,
+// of the key named keyName.
 func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string) (string, error) {
-	cmd := []string{"pcli", "-d", p.HomeDir(), "addr", "list"}
+	cmd := []string{"pcli", "-d", p.keyPath(keyName), "addr", "list"}
 	stdout, _, err := p.Exec(ctx, cmd, nil)
 	if err != nil {
 		return "", err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
+	if address, ok := parseAddressList(string(stdout)); ok {
+		return address, nil
+	}
+	return "", errors.New("address not found")
+}
+
+// parseAddressList returns the address of account 0 from the table printed by pcli addr list,
+// whose rows are the index, label, and address.
+func parseAddressList(out string) (string, bool) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
 		if len(fields) < 3 {
 			continue
 		}
-		if fields[1] == keyName {
-			return fields[2], nil
+		if fields[0] == "0" {
+			return fields[len(fields)-1], true
 		}
 	}
-	return "", errors.New("address not found")
+	return "", false
+}
+
+// GetBalance returns the balance of denom held by the key named keyName, as reported by the pcli view service
+// after synchronizing with this node.
+func (p *PenumbraAppNode) GetBalance(ctx context.Context, keyName string, denom string) (math.Int, error) {
+	cmd := []string{"pcli", "-d", p.keyPath(keyName), "--node", p.HostName(), "view", "balance"}
+	stdout, _, err := p.Exec(ctx, cmd, nil)
+	if err != nil {
+		return math.Int{}, err
+	}
+	return parseBalance(string(stdout), denom)
+}
+
+// parseBalance sums the amounts of denom in the table printed by pcli view balance, e.g. "0  1000upenumbra".
+// Amounts printed in the display denomination, e.g. "1.5penumbra" for "upenumbra", are converted to the base denomination.
+func parseBalance(out string, denom string) (math.Int, error) {
+	total := math.ZeroInt()
+	for _, line := range strings.Split(out, "\n") {
+		for _, field := range strings.Fields(strings.ReplaceAll(line, "|", " ")) {
+			m := balanceRegex.FindStringSubmatch(field)
+			if m == nil {
+				continue
+			}
+			amount, d := m[1], m[2]
+
+			var exp uint64
+			switch {
+			case d == denom:
+			case "u"+d == denom:
+				exp = 6
+			default:
+				continue
+			}
+
+			dec, err := math.LegacyNewDecFromStr(amount)
+			if err != nil {
+				return math.Int{}, fmt.Errorf("invalid balance %q: %w", field, err)
+			}
+			dec = dec.Mul(math.LegacyNewDec(10).Power(exp))
+			if !dec.IsInteger() {
+				return math.Int{}, fmt.Errorf("balance %q is not an integer amount of %s", field, denom)
+			}
+			total = total.Add(dec.TruncateInt())
+		}
+	}
+	return total, nil
+}
+
+var balanceRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([a-zA-Z][a-zA-Z0-9/_\-.]*)$`)
+
+// ExportState snapshots the latest committed state of this node into dir, relative to the home directory.
+// The node's storage is a RocksDB database, so the export is a database directory rather than a document.
+func (p *PenumbraAppNode) ExportState(ctx context.Context, dir string) (string, error) {
+	exportDir := filepath.Join(p.HomeDir(), dir)
+	cmd := []string{"pd", "export", "--home", p.HomeDir(), "--export-directory", exportDir}
+	if _, stderr, err := p.Exec(ctx, cmd, nil); err != nil {
+		return "", fmt.Errorf("pd export: %s: %w", strings.TrimSpace(string(stderr)), err)
+	}
+	return exportDir, nil
+}
+
+// KeyNames returns the names of the keys created or recovered on the node.
+func (p *PenumbraAppNode) KeyNames(ctx context.Context) ([]string, error) {
+	cmd := []string{"sh", "-c", "ls -1 " + filepath.Join(p.HomeDir(), "keys") + " 2>/dev/null || true"}
+	stdout, _, err := p.Exec(ctx, cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(stdout)), nil
+}
 
+func (p *PenumbraAppNode) keyPath(keyName string) string {
+	return filepath.Join(p.HomeDir(), "keys", keyName)
 }
 
 func (p *PenumbraAppNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
@@ -212,6 +286,7 @@ func (p *PenumbraAppNode) SendIBCTransfer(
 func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 	cmd := []string{"pd", "start", "--host", "0.0.0.0", "--home", p.HomeDir()}
 
+	p.containerLifecycle.SetResourceLimits(p.Chain.Config().ResourceLimits)
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd)
 }
 
@@ -236,7 +311,8 @@ func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and block until the container exits
 func (p *PenumbraAppNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	repo, tag := p.Image.RepositoryAndTag()
+	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
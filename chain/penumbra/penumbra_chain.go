@@ -1,22 +1,23 @@
 package penumbra
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
+	"sync"
 
 	"cosmossdk.io/math"
 	"github.com/BurntSushi/toml"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
-	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
@@ -43,6 +44,9 @@ type PenumbraChain struct {
 	numFullNodes  int
 	PenumbraNodes PenumbraNodes
 	keyring       keyring.Keyring
+
+	keyNamesMu sync.Mutex
+	keyNames   map[string]string // Raw address to key name.
 }
 
 type PenumbraValidatorDefinition struct {
@@ -86,15 +90,42 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 		numValidators: numValidators,
 		numFullNodes:  numFullNodes,
 		keyring:       kr,
+		keyNames:      make(map[string]string),
 	}
 }
 
+// Acknowledgements returns the packets acknowledged in the block at height.
+// Implements Chain interface
 func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
+	events, err := c.txEvents(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return packetAcknowledgements(events)
 }
 
+// Timeouts returns the packets timed out in the block at height.
+// Implements Chain interface
 func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
+	events, err := c.txEvents(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return packetTimeouts(events)
+}
+
+// txEvents returns the events of all transactions in the block at height, from the Tendermint node's block results.
+func (c *PenumbraChain) txEvents(ctx context.Context, height uint64) ([]abcitypes.Event, error) {
+	h := int64(height)
+	res, err := c.getRelayerNode().TendermintNode.Client.BlockResults(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("block results at height %d: %w", height, err)
+	}
+	var events []abcitypes.Event
+	for _, tx := range res.TxsResults {
+		events = append(events, tx.Events...)
+	}
+	return events, nil
 }
 
 // Implements Chain interface
@@ -143,8 +174,10 @@ func (c *PenumbraChain) GetHostGRPCAddress() string {
 	return c.getRelayerNode().PenumbraAppNode.hostGRPCPort
 }
 
+// HomeDir is the home directory of the penumbra node used for queries and keys.
+// Implements Chain interface
 func (c *PenumbraChain) HomeDir() string {
-	panic(errors.New("HomeDir not implemented yet"))
+	return c.getRelayerNode().PenumbraAppNode.HomeDir()
 }
 
 // Implements Chain interface
@@ -158,7 +191,17 @@ func (c *PenumbraChain) RecoverKey(ctx context.Context, name, mnemonic string) e
 
 // Implements Chain interface
 func (c *PenumbraChain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	return c.getRelayerNode().PenumbraAppNode.GetAddress(ctx, keyName)
+	addr, err := c.getRelayerNode().PenumbraAppNode.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remember the key of the address so that balances can be queried by address.
+	c.keyNamesMu.Lock()
+	c.keyNames[string(addr)] = keyName
+	c.keyNamesMu.Unlock()
+
+	return addr, nil
 }
 
 // BuildWallet will return a Penumbra wallet
@@ -227,18 +270,98 @@ func (c *PenumbraChain) SendIBCTransfer(
 	return c.getRelayerNode().PenumbraAppNode.SendIBCTransfer(ctx, channelID, keyName, amount, options)
 }
 
+// PenumbraStateExport describes a snapshot of penumbra's state, as returned by ExportState.
+type PenumbraStateExport struct {
+	ChainID string `json:"chain_id"`
+	Height  int64  `json:"height"`
+	AppHash string `json:"app_hash"`
+
+	// Directory of the exported RocksDB database, within the penumbra node's volume.
+	ExportDirectory string `json:"export_directory"`
+}
+
+// ExportState exports the state of the chain at height, which must be the latest committed height,
+// e.g. after halting the chain. pd cannot export historical state.
+// The state is exported as a database directory in the node's volume, and the returned JSON is a
+// PenumbraStateExport describing it.
 // Implements Chain interface
 func (c *PenumbraChain) ExportState(ctx context.Context, height int64) (string, error) {
-	panic("implement me")
+	node := c.getRelayerNode()
+
+	info, err := node.TendermintNode.Client.ABCIInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("abci info: %w", err)
+	}
+	if last := info.Response.LastBlockHeight; last != height {
+		return "", fmt.Errorf("cannot export state at height %d: penumbra only exports the latest state, at height %d", height, last)
+	}
+
+	dir, err := node.PenumbraAppNode.ExportState(ctx, fmt.Sprintf("exports/%d", height))
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(PenumbraStateExport{
+		ChainID:         c.cfg.ChainID,
+		Height:          height,
+		AppHash:         hex.EncodeToString(info.Response.LastBlockAppHash),
+		ExportDirectory: dir,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
 func (c *PenumbraChain) Height(ctx context.Context) (uint64, error) {
 	return c.getRelayerNode().TendermintNode.Height(ctx)
 }
 
+// GetBalance returns the balance of denom held by address, as reported by the pcli view service.
+// Penumbra balances are private, so they can only be viewed with the key owning address,
+// which must have been created or recovered on this chain, e.g. by BuildWallet.
+// GetBalance returns an error for addresses of any other key.
 // Implements Chain interface
 func (c *PenumbraChain) GetBalance(ctx context.Context, address string, denom string) (math.Int, error) {
-	panic("implement me")
+	_, addr, err := DecodeBech32m(address)
+	if err != nil {
+		return math.Int{}, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	keyName, err := c.keyNameOf(ctx, addr)
+	if err != nil {
+		return math.Int{}, err
+	}
+	if keyName == "" {
+		return math.Int{}, fmt.Errorf("cannot view the balance of address %s: penumbra balances are private, and no key on chain %s owns it", address, c.cfg.ChainID)
+	}
+	return c.getRelayerNode().PenumbraAppNode.GetBalance(ctx, keyName, denom)
+}
+
+// keyNameOf returns the name of the key owning the raw address addr, or "" if no key on the chain owns it.
+// Addresses fetched by GetAddress are remembered; other keys are looked up on the node.
+func (c *PenumbraChain) keyNameOf(ctx context.Context, addr []byte) (string, error) {
+	c.keyNamesMu.Lock()
+	keyName, ok := c.keyNames[string(addr)]
+	c.keyNamesMu.Unlock()
+	if ok {
+		return keyName, nil
+	}
+
+	keyNames, err := c.getRelayerNode().PenumbraAppNode.KeyNames(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list keys: %w", err)
+	}
+	for _, name := range keyNames {
+		keyAddr, err := c.GetAddress(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get address of key %s: %w", name, err)
+		}
+		if bytes.Equal(keyAddr, addr) {
+			return name, nil
+		}
+	}
+	return "", nil
 }
 
 // Implements Chain interface
@@ -331,20 +454,8 @@ func (c *PenumbraChain) initializeChainNodes(
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
 	for _, image := range chainCfg.Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
+		if err := dockerutil.EnsureImage(ctx, c.log, cli, image); err != nil {
+			return err
 		}
 	}
 	for i := 0; i < count; i++ {
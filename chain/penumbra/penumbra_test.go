@@ -0,0 +1,122 @@
+package penumbra
+
+import (
+	"bytes"
+	"testing"
+
+	"cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+func TestBech32m(t *testing.T) {
+	// Test vectors from BIP-350.
+	hrp, data, err := DecodeBech32m("A1LQFN3A")
+	require.NoError(t, err)
+	require.Equal(t, "a", hrp)
+	require.Empty(t, data)
+
+	// Valid bech32, but not bech32m.
+	_, _, err = DecodeBech32m("A12UEL5L")
+	require.EqualError(t, err, "invalid bech32m checksum")
+
+	// Penumbra addresses are 80 bytes, well over the bech32 length limit.
+	addr := bytes.Repeat([]byte{0xab, 0x01, 0xfe, 0x42}, 20)
+	encoded, err := EncodeBech32m("penumbrav2t", addr)
+	require.NoError(t, err)
+	require.Greater(t, len(encoded), 90)
+
+	hrp, data, err = DecodeBech32m(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "penumbrav2t", hrp)
+	require.Equal(t, addr, data)
+
+	w := NewWallet("user", addr, "", ibc.ChainConfig{Bech32Prefix: "penumbrav2t"})
+	require.Equal(t, encoded, w.FormattedAddress())
+
+	corrupted := []byte(encoded)
+	corrupted[20] ^= 1
+	_, _, err = DecodeBech32m(string(corrupted))
+	require.Error(t, err)
+}
+
+func TestParseAddressList(t *testing.T) {
+	const out = ` Index  Label      Address
+ 0      Default    penumbrav2t1abc
+ 1      Ephemeral  penumbrav2t1def
+`
+	address, ok := parseAddressList(out)
+	require.True(t, ok)
+	require.Equal(t, "penumbrav2t1abc", address)
+
+	_, ok = parseAddressList("")
+	require.False(t, ok)
+}
+
+func TestParseBalance(t *testing.T) {
+	const out = ` Account  Amount
+ 0        1.5penumbra
+ 0        250upenumbra
+ 1        100gm
+ 0        100000udelegation_penumbravalid1xyz
+`
+	balance, err := parseBalance(out, "upenumbra")
+	require.NoError(t, err)
+	require.Equal(t, math.NewInt(1_500_250), balance)
+
+	balance, err = parseBalance(out, "gm")
+	require.NoError(t, err)
+	require.Equal(t, math.NewInt(100), balance)
+
+	balance, err = parseBalance(out, "uatom")
+	require.NoError(t, err)
+	require.True(t, balance.IsZero())
+}
+
+func TestPacketEvents(t *testing.T) {
+	attrs := func(kv ...string) []abcitypes.EventAttribute {
+		var attrs []abcitypes.EventAttribute
+		for i := 0; i < len(kv); i += 2 {
+			attrs = append(attrs, abcitypes.EventAttribute{Key: kv[i], Value: kv[i+1]})
+		}
+		return attrs
+	}
+	packetAttrs := attrs(
+		"packet_sequence", "7",
+		"packet_src_port", "transfer",
+		"packet_src_channel", "channel-0",
+		"packet_dst_port", "transfer",
+		"packet_dst_channel", "channel-3",
+		"packet_timeout_height", "1-300",
+		"packet_timeout_timestamp", "1690000000000000000",
+	)
+	events := []abcitypes.Event{
+		{Type: "send_packet", Attributes: append(packetAttrs, attrs("packet_data_hex", "7b7d")...)},
+		{Type: eventAcknowledgePacket, Attributes: packetAttrs},
+		{Type: eventTimeoutPacket, Attributes: packetAttrs},
+	}
+
+	want := ibc.Packet{
+		Sequence:         7,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		DestPort:         "transfer",
+		DestChannel:      "channel-3",
+		TimeoutHeight:    "1-300",
+		TimeoutTimestamp: 1690000000000000000,
+	}
+
+	acks, err := packetAcknowledgements(events)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketAcknowledgement{{Packet: want}}, acks)
+
+	timeouts, err := packetTimeouts(events)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketTimeout{{Packet: want}}, timeouts)
+
+	sent, err := packetFromEvent(events[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("{}"), sent.Data)
+}
@@ -1,7 +1,8 @@
 package penumbra
 
 import (
-	"github.com/cosmos/cosmos-sdk/types"
+	"fmt"
+
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
@@ -29,7 +30,7 @@ func (w *PenumbraWallet) KeyName() string {
 
 // Get Address formatted with chain's prefix
 func (w *PenumbraWallet) FormattedAddress() string {
-	return types.MustBech32ifyAddressBytes(w.chainCfg.Bech32Prefix, w.address)
+	return w.FormattedAddressWithPrefix(w.chainCfg.Bech32Prefix)
 }
 
 // Get mnemonic, only used for relayer wallets
@@ -42,6 +43,11 @@ func (w *PenumbraWallet) Address() []byte {
 	return w.address
 }
 
+// Get Address formatted with prefix, using bech32m as penumbra does
 func (w *PenumbraWallet) FormattedAddressWithPrefix(prefix string) string {
-	return types.MustBech32ifyAddressBytes(prefix, w.address)
+	address, err := EncodeBech32m(prefix, w.address)
+	if err != nil {
+		panic(fmt.Errorf("failed to encode penumbra address: %w", err))
+	}
+	return address
 }
@@ -0,0 +1,227 @@
+package polkadot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	gethrpc "github.com/misko9/go-substrate-rpc-client/v4/gethrpc"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/misko9/go-substrate-rpc-client/v4/types/codec"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Page size when iterating over storage keys for a state export.
+const exportStatePageSize = 1000
+
+// substrateAPI returns the RPC client of the node whose chain is tracked by Height:
+// the first parachain node if there is one, otherwise the first relay chain node.
+func (c *PolkadotChain) substrateAPI() *gsrpc.SubstrateAPI {
+	if len(c.ParachainNodes) > 0 && len(c.ParachainNodes[0]) > 0 {
+		return c.ParachainNodes[0][0].api
+	}
+	return c.RelayChainNodes[0].api
+}
+
+func blockHash(api *gsrpc.SubstrateAPI, height uint64) (gstypes.Hash, error) {
+	hash, err := api.RPC.Chain.GetBlockHash(height)
+	if err != nil {
+		return gstypes.Hash{}, fmt.Errorf("block hash at height %d: %w", height, err)
+	}
+	return hash, nil
+}
+
+// exportState returns all storage of the chain at height as a raw chain spec genesis,
+// i.e. {"genesis":{"raw":{"top":{"0x<key>":"0x<value>"}}}}.
+func exportState(ctx context.Context, api *gsrpc.SubstrateAPI, height uint64) (string, error) {
+	hash, err := blockHash(api, height)
+	if err != nil {
+		return "", err
+	}
+
+	top := make(map[string]string)
+	var startKey *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		var hexKeys []string
+		if err := api.Client.Call(&hexKeys, "state_getKeysPaged", "0x", exportStatePageSize, startKey, hash.Hex()); err != nil {
+			return "", fmt.Errorf("state_getKeysPaged: %w", err)
+		}
+		if len(hexKeys) == 0 {
+			break
+		}
+
+		keys := make([]gstypes.StorageKey, len(hexKeys))
+		for i, k := range hexKeys {
+			if keys[i], err = codec.HexDecodeString(k); err != nil {
+				return "", fmt.Errorf("decode storage key %s: %w", k, err)
+			}
+		}
+
+		changeSets, err := api.RPC.State.QueryStorageAt(keys, hash)
+		if err != nil {
+			return "", fmt.Errorf("query storage at %s: %w", hash.Hex(), err)
+		}
+		for _, cs := range changeSets {
+			for _, change := range cs.Changes {
+				if !change.HasStorageData {
+					continue
+				}
+				top[change.StorageKey.Hex()] = codec.HexEncodeToString(change.StorageData)
+			}
+		}
+
+		if len(hexKeys) < exportStatePageSize {
+			break
+		}
+		startKey = &hexKeys[len(hexKeys)-1]
+	}
+
+	var spec struct {
+		Genesis struct {
+			Raw struct {
+				Top map[string]string `json:"top"`
+			} `json:"raw"`
+		} `json:"genesis"`
+	}
+	spec.Genesis.Raw.Top = top
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// rpcMethodNotFound is the JSON-RPC error code returned for methods the node does not serve.
+const rpcMethodNotFound = -32601
+
+// queryIbcEvents returns the events emitted by the IBC pallet in the block at height.
+// Chains without the IBC pallet, e.g. relay chains, do not serve ibc_queryEvents and have no IBC events.
+func queryIbcEvents(ctx context.Context, api *gsrpc.SubstrateAPI, height uint64) ([]ibcEvent, error) {
+	res, err := api.RPC.IBC.QueryIbcEvents(ctx, []gstypes.BlockNumberOrHash{{Number: uint32(height)}})
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcMethodNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query ibc events at height %d: %w", height, err)
+	}
+	return decodeIbcEvents(res)
+}
+
+// extrinsicData is the JSON representation of an extrinsic stored in the block database.
+type extrinsicData struct {
+	Index  int    `json:"index"`
+	Signed bool   `json:"signed"`
+	Signer string `json:"signer,omitempty"`
+	Nonce  string `json:"nonce,omitempty"`
+	Tip    string `json:"tip,omitempty"`
+	Pallet string `json:"pallet"`
+	Call   string `json:"call"`
+	Args   string `json:"args"`
+}
+
+// findTxs returns the extrinsics of the block at height as transactions.
+// Events emitted by the IBC pallet are added as an artificial transaction, as the cosmos chain does for
+// begin and end block events, so that they can be queried from the block database.
+func findTxs(ctx context.Context, api *gsrpc.SubstrateAPI, height uint64) ([]blockdb.Tx, error) {
+	hash, err := blockHash(api, height)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.RPC.Chain.GetBlock(hash)
+	if err != nil {
+		return nil, fmt.Errorf("block at height %d: %w", height, err)
+	}
+	meta, err := api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return nil, fmt.Errorf("metadata at height %d: %w", height, err)
+	}
+
+	txs := make([]blockdb.Tx, 0, len(block.Block.Extrinsics)+1)
+	for i, ext := range block.Block.Extrinsics {
+		data := extrinsicData{
+			Index:  i,
+			Signed: ext.IsSigned(),
+			Args:   codec.HexEncodeToString(ext.Method.Args),
+		}
+		data.Pallet, data.Call = callName(meta, ext.Method.CallIndex)
+		if data.Signed {
+			sig := ext.Signature
+			if sig.Signer.IsID {
+				data.Signer, _ = EncodeAddressSS58(sig.Signer.AsID.ToBytes())
+			}
+			data.Nonce = uCompactString(sig.Nonce)
+			data.Tip = uCompactString(sig.Tip)
+		}
+
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("encode extrinsic %d: %w", i, err)
+		}
+		txs = append(txs, blockdb.Tx{
+			Data: b,
+			Events: []blockdb.Event{{
+				Type: "extrinsic",
+				Attributes: []blockdb.EventAttribute{
+					{Key: "pallet", Value: data.Pallet},
+					{Key: "call", Value: data.Call},
+					{Key: "signer", Value: data.Signer},
+				},
+			}},
+		})
+	}
+
+	events, err := queryIbcEvents(ctx, api, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		ibcTx := blockdb.Tx{
+			Data: []byte(`{"data":"ibc_events","note":"this is a transaction artificially created for debugging purposes"}`),
+		}
+		for _, e := range events {
+			be, err := e.blockdbEvent()
+			if err != nil {
+				return nil, err
+			}
+			ibcTx.Events = append(ibcTx.Events, be)
+		}
+		txs = append(txs, ibcTx)
+	}
+
+	return txs, nil
+}
+
+// callName resolves the pallet and call names of a call index using the chain metadata.
+// The indexes are returned instead if the call is not found.
+func callName(meta *gstypes.Metadata, ci gstypes.CallIndex) (pallet, call string) {
+	pallet, call = fmt.Sprint(ci.SectionIndex), fmt.Sprint(ci.MethodIndex)
+	m := meta.AsMetadataV14
+	for _, mod := range m.Pallets {
+		if !mod.HasCalls || uint8(mod.Index) != ci.SectionIndex {
+			continue
+		}
+		pallet = string(mod.Name)
+		if typ, ok := m.EfficientLookup[mod.Calls.Type.Int64()]; ok {
+			for _, v := range typ.Def.Variant.Variants {
+				if uint8(v.Index) == ci.MethodIndex {
+					call = string(v.Name)
+				}
+			}
+		}
+	}
+	return pallet, call
+}
+
+func uCompactString(u gstypes.UCompact) string {
+	i := big.Int(u)
+	return i.String()
+}
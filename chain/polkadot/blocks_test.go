@@ -0,0 +1,60 @@
+package polkadot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	gethrpc "github.com/misko9/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/misko9/go-substrate-rpc-client/v4/rpc"
+	"github.com/misko9/go-substrate-rpc-client/v4/rpc/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// inprocClient adapts an in-process RPC client to the client of the substrate API.
+type inprocClient struct {
+	*gethrpc.Client
+}
+
+func (inprocClient) URL() string { return "inproc" }
+
+func inprocAPI(t *testing.T, services ...gethrpc.API) *gsrpc.SubstrateAPI {
+	t.Helper()
+
+	srv := gethrpc.NewServer()
+	for _, s := range services {
+		require.NoError(t, srv.RegisterName(s.Namespace, s.Service))
+	}
+	cl := inprocClient{gethrpc.DialInProc(srv)}
+	t.Cleanup(func() {
+		cl.Close()
+		srv.Stop()
+	})
+
+	return &gsrpc.SubstrateAPI{RPC: &rpc.RPC{IBC: ibc.NewIBC(cl)}, Client: cl}
+}
+
+type failingIbcService struct{}
+
+func (failingIbcService) QueryEvents(blockNumbers []interface{}) ([]interface{}, error) {
+	return nil, errors.New("database is locked")
+}
+
+func TestQueryIbcEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("method not found", func(t *testing.T) {
+		// Relay chains and parachains without the IBC pallet do not serve ibc_queryEvents.
+		api := inprocAPI(t)
+		events, err := queryIbcEvents(ctx, api, 1)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		api := inprocAPI(t, gethrpc.API{Namespace: "ibc", Service: failingIbcService{}})
+		_, err := queryIbcEvents(ctx, api, 1)
+		require.ErrorContains(t, err, "database is locked")
+	})
+}
@@ -0,0 +1,221 @@
+package polkadot
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Names of the IBC pallet events, as returned by the ibc_queryEvents RPC method.
+const (
+	ibcEventAcknowledgePacket    = "AcknowledgePacket"
+	ibcEventTimeoutPacket        = "TimeoutPacket"
+	ibcEventTimeoutOnClosePacket = "TimeoutOnClosePacket"
+)
+
+// ibcEventPacket is the packet of an IBC pallet event, as serialized by ibc-rs.
+type ibcEventPacket struct {
+	Sequence           uint64             `json:"sequence"`
+	SourcePort         string             `json:"source_port"`
+	SourceChannel      string             `json:"source_channel"`
+	DestinationPort    string             `json:"destination_port"`
+	DestinationChannel string             `json:"destination_channel"`
+	Data               hexBytes           `json:"data"`
+	TimeoutHeight      clienttypes.Height `json:"timeout_height"`
+	TimeoutTimestamp   struct {
+		Time *time.Time `json:"time"`
+	} `json:"timeout_timestamp"`
+}
+
+func (p ibcEventPacket) toPacket() ibc.Packet {
+	packet := ibc.Packet{
+		Sequence:      p.Sequence,
+		SourcePort:    p.SourcePort,
+		SourceChannel: p.SourceChannel,
+		DestPort:      p.DestinationPort,
+		DestChannel:   p.DestinationChannel,
+		Data:          p.Data,
+		TimeoutHeight: p.TimeoutHeight.String(),
+	}
+	if t := p.TimeoutTimestamp.Time; t != nil && !t.IsZero() {
+		packet.TimeoutTimestamp = ibc.Nanoseconds(t.UnixNano())
+	}
+	return packet
+}
+
+// hexBytes decodes bytes serialized as a hex string, as ibc-rs does for packet data and acknowledgements,
+// or as an array of numbers, which is serde's default for byte vectors.
+type hexBytes []byte
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			// Not hex encoded, so keep the string as-is.
+			decoded = []byte(s)
+		}
+		*b = decoded
+		return nil
+	}
+
+	// json.Unmarshal expects base64 for []byte, so decode the numbers individually.
+	var nums []uint16
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return fmt.Errorf("packet bytes must be a string or an array of bytes: %w", err)
+	}
+	decoded := make([]byte, len(nums))
+	for i, n := range nums {
+		if n > 0xff {
+			return fmt.Errorf("packet byte %d out of range: %d", i, n)
+		}
+		decoded[i] = byte(n)
+	}
+	*b = decoded
+	return nil
+}
+
+// packetEvent is an IBC pallet event carrying a packet.
+type packetEvent struct {
+	Packet ibcEventPacket `json:"packet"`
+	Ack    hexBytes       `json:"ack"`
+}
+
+// ibcEvent is a single event from the ibc_queryEvents RPC method,
+// which encodes each event as an object with the event name as its only key.
+type ibcEvent struct {
+	Name  string
+	Value json.RawMessage
+}
+
+func decodeIbcEvents(res gstypes.IBCEventsQueryResult) ([]ibcEvent, error) {
+	var events []ibcEvent
+	for _, m := range res {
+		// Sort for a deterministic order, although each object is expected to have a single key.
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			raw, err := json.Marshal(m[name])
+			if err != nil {
+				return nil, fmt.Errorf("encode ibc event %s: %w", name, err)
+			}
+			events = append(events, ibcEvent{Name: name, Value: raw})
+		}
+	}
+	return events, nil
+}
+
+// packetAcknowledgements returns the packets acknowledged by the counterparty.
+// Acknowledgement is only populated when the pallet includes the acknowledgement bytes in the event.
+func packetAcknowledgements(events []ibcEvent) ([]ibc.PacketAcknowledgement, error) {
+	var acks []ibc.PacketAcknowledgement
+	for _, e := range events {
+		if e.Name != ibcEventAcknowledgePacket {
+			continue
+		}
+		var pe packetEvent
+		if err := json.Unmarshal(e.Value, &pe); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", e.Name, err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{
+			Packet:          pe.Packet.toPacket(),
+			Acknowledgement: pe.Ack,
+		})
+	}
+	return acks, nil
+}
+
+// packetTimeouts returns the packets that timed out, including those timed out by closing the channel.
+func packetTimeouts(events []ibcEvent) ([]ibc.PacketTimeout, error) {
+	var timeouts []ibc.PacketTimeout
+	for _, e := range events {
+		if e.Name != ibcEventTimeoutPacket && e.Name != ibcEventTimeoutOnClosePacket {
+			continue
+		}
+		var pe packetEvent
+		if err := json.Unmarshal(e.Value, &pe); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", e.Name, err)
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{Packet: pe.Packet.toPacket()})
+	}
+	return timeouts, nil
+}
+
+// blockdbEvent converts an IBC event into a blockdb event, flattening nested fields into
+// dot-separated attribute keys, e.g. "packet.source_channel".
+// Byte vectors, such as the packet data, are hex encoded into a single attribute.
+func (e ibcEvent) blockdbEvent() (blockdb.Event, error) {
+	var v interface{}
+	if err := json.Unmarshal(e.Value, &v); err != nil {
+		return blockdb.Event{}, fmt.Errorf("decode %s event: %w", e.Name, err)
+	}
+
+	var attrs []blockdb.EventAttribute
+	flattenAttributes("", v, &attrs)
+	return blockdb.Event{Type: e.Name, Attributes: attrs}, nil
+}
+
+func flattenAttributes(key string, v interface{}, attrs *[]blockdb.EventAttribute) {
+	join := func(k string) string {
+		if key == "" {
+			return k
+		}
+		return key + "." + k
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flattenAttributes(join(k), v[k], attrs)
+		}
+	case []interface{}:
+		if b, ok := jsonBytes(v); ok {
+			// A single attribute, like the packet_data_hex attribute of IBC core events, rather than one per byte.
+			*attrs = append(*attrs, blockdb.EventAttribute{Key: key, Value: hex.EncodeToString(b)})
+			return
+		}
+		for i, x := range v {
+			flattenAttributes(join(strconv.Itoa(i)), x, attrs)
+		}
+	case nil:
+		*attrs = append(*attrs, blockdb.EventAttribute{Key: key})
+	case string:
+		*attrs = append(*attrs, blockdb.EventAttribute{Key: key, Value: v})
+	default:
+		b, _ := json.Marshal(v)
+		*attrs = append(*attrs, blockdb.EventAttribute{Key: key, Value: string(b)})
+	}
+}
+
+// jsonBytes returns the bytes of v if it is a byte vector serialized as an array of numbers,
+// which is serde's default for Vec<u8>. Empty arrays are treated as empty byte vectors.
+func jsonBytes(v []interface{}) ([]byte, bool) {
+	b := make([]byte, len(v))
+	for i, x := range v {
+		n, ok := x.(float64)
+		if !ok || n < 0 || n > 0xff || n != float64(int(n)) {
+			return nil, false
+		}
+		b[i] = byte(n)
+	}
+	return b, true
+}
@@ -0,0 +1,123 @@
+package polkadot
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+const testIbcEvents = `[
+	{"CreateClient": {"height": {"revision_number": 0, "revision_height": 12}, "client_id": "10-grandpa-0"}},
+	{"AcknowledgePacket": {
+		"height": {"revision_number": 0, "revision_height": 12},
+		"packet": {
+			"sequence": 3,
+			"source_port": "transfer",
+			"source_channel": "channel-0",
+			"destination_port": "transfer",
+			"destination_channel": "channel-1",
+			"data": "7B7D",
+			"timeout_height": {"revision_number": 1, "revision_height": 500},
+			"timeout_timestamp": {"time": null}
+		}
+	}},
+	{"TimeoutPacket": {
+		"height": {"revision_number": 0, "revision_height": 12},
+		"packet": {
+			"sequence": 4,
+			"source_port": "transfer",
+			"source_channel": "channel-0",
+			"destination_port": "transfer",
+			"destination_channel": "channel-1",
+			"data": [123, 125],
+			"timeout_height": {"revision_number": 0, "revision_height": 0},
+			"timeout_timestamp": {"time": "2023-05-01T00:00:00Z"}
+		}
+	}}
+]`
+
+func TestIbcEvents(t *testing.T) {
+	var res gstypes.IBCEventsQueryResult
+	require.NoError(t, json.Unmarshal([]byte(testIbcEvents), &res))
+
+	events, err := decodeIbcEvents(res)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	acks, err := packetAcknowledgements(events)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketAcknowledgement{{
+		Packet: ibc.Packet{
+			Sequence:      3,
+			SourcePort:    "transfer",
+			SourceChannel: "channel-0",
+			DestPort:      "transfer",
+			DestChannel:   "channel-1",
+			Data:          []byte("{}"),
+			TimeoutHeight: "1-500",
+		},
+	}}, acks)
+
+	timeouts, err := packetTimeouts(events)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketTimeout{{
+		Packet: ibc.Packet{
+			Sequence:         4,
+			SourcePort:       "transfer",
+			SourceChannel:    "channel-0",
+			DestPort:         "transfer",
+			DestChannel:      "channel-1",
+			Data:             []byte("{}"),
+			TimeoutHeight:    "0-0",
+			TimeoutTimestamp: ibc.Nanoseconds(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC).UnixNano()),
+		},
+	}}, timeouts)
+
+	ev, err := events[0].blockdbEvent()
+	require.NoError(t, err)
+	require.Equal(t, blockdb.Event{
+		Type: "CreateClient",
+		Attributes: []blockdb.EventAttribute{
+			{Key: "client_id", Value: "10-grandpa-0"},
+			{Key: "height.revision_height", Value: "12"},
+			{Key: "height.revision_number", Value: "0"},
+		},
+	}, ev)
+}
+
+func TestIbcEvent_BlockdbEventBytes(t *testing.T) {
+	var res gstypes.IBCEventsQueryResult
+	require.NoError(t, json.Unmarshal([]byte(testIbcEvents), &res))
+	events, err := decodeIbcEvents(res)
+	require.NoError(t, err)
+
+	// Packet data serialized as an array of numbers is a single hex attribute, not one attribute per byte.
+	ev, err := events[2].blockdbEvent()
+	require.NoError(t, err)
+	require.Equal(t, "TimeoutPacket", ev.Type)
+	require.Contains(t, ev.Attributes, blockdb.EventAttribute{Key: "packet.data", Value: "7b7d"})
+	for _, attr := range ev.Attributes {
+		require.NotContains(t, attr.Key, "packet.data.")
+	}
+
+	// Arrays that are not bytes are still flattened by index.
+	var attrs []blockdb.EventAttribute
+	flattenAttributes("", map[string]interface{}{
+		"empty":   []interface{}{},
+		"heights": []interface{}{float64(300), float64(2)},
+		"ids":     []interface{}{"a", "b"},
+	}, &attrs)
+	require.Equal(t, []blockdb.EventAttribute{
+		{Key: "empty", Value: ""},
+		{Key: "heights.0", Value: "300"},
+		{Key: "heights.1", Value: "2"},
+		{Key: "ids.0", Value: "a"},
+		{Key: "ids.1", Value: "b"},
+	}, attrs)
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/avast/retry-go/v4"
@@ -42,6 +44,8 @@ type ParachainNode struct {
 	ChainID         string
 	Flags           []string
 	RelayChainFlags []string
+	// Values set in the parachain chain spec, keyed by dot-separated path.
+	GenesisOverrides map[string]any
 
 	api         *gsrpc.SubstrateAPI
 	hostWsPort  string
@@ -158,6 +162,9 @@ func (pn *ParachainNode) GenerateParachainGenesisFile(ctx context.Context, addit
 	if err := dyno.Set(chainSpec, balances, "genesis", "runtime", "balances", "balances"); err != nil {
 		return nil, fmt.Errorf("error setting parachain balances: %w", err)
 	}
+	if err := applyGenesisOverrides(chainSpec, pn.GenesisOverrides); err != nil {
+		return nil, fmt.Errorf("error applying parachain genesis overrides: %w", err)
+	}
 	editedChainSpec, err := json.MarshalIndent(chainSpec, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling modified parachain chain spec: %w", err)
@@ -166,6 +173,29 @@ func (pn *ParachainNode) GenerateParachainGenesisFile(ctx context.Context, addit
 	return editedChainSpec, nil
 }
 
+// applyGenesisOverrides sets each value of overrides in chainSpec at its dot-separated path.
+// Overrides are applied in order of their paths, so a value may be overridden by one at a longer path.
+// Nested maps decoded from YAML with interface{} keys are converted to string keys so that chainSpec
+// can still be marshaled to JSON.
+func applyGenesisOverrides(chainSpec interface{}, overrides map[string]any) error {
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		var keys []interface{}
+		for _, k := range strings.Split(path, ".") {
+			keys = append(keys, k)
+		}
+		if err := dyno.Set(chainSpec, dyno.ConvertMapI2MapS(overrides[path]), keys...); err != nil {
+			return fmt.Errorf("failed to set %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
 // ParachainID retrieves the node parachain ID.
 func (pn *ParachainNode) ParachainID(ctx context.Context) (int, error) {
 	cmd := []string{
@@ -256,6 +286,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 	cmd = append(cmd, "--", fmt.Sprintf("--chain=%s", pn.RawRelayChainSpecFilePathFull()))
 	cmd = append(cmd, pn.RelayChainFlags...)
 
+	pn.containerLifecycle.SetResourceLimits(pn.Chain.Config().ResourceLimits)
 	return pn.containerLifecycle.CreateContainer(ctx, pn.TestName, pn.NetworkID, pn.Image, exposedPorts, pn.Bind(), pn.HostName(), cmd)
 }
 
@@ -296,7 +327,8 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and block until the container exits.
 func (pn *ParachainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(pn.log, pn.DockerClient, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
+	repo, tag := pn.Image.RepositoryAndTag()
+	job := dockerutil.NewImage(pn.log, pn.DockerClient, pn.NetworkID, pn.TestName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Binds: pn.Bind(),
 		Env:   env,
@@ -331,7 +363,9 @@ func (pn *ParachainNode) SendFunds(ctx context.Context, keyName string, amount i
 		zap.String("To", amount.Address),
 		zap.String("Amount", amount.Amount.String()),
 	)
+	startedAt := time.Now()
 	hash, err := SendFundsTx(pn.api, kp, amount)
+	trackExtrinsic(pn.TestName, pn.Name(), pn.Image, []string{"Balances.transfer", amount.Address, amount.Amount.String()}, hash, err, startedAt)
 	if err != nil {
 		return err
 	}
@@ -359,7 +393,9 @@ func (pn *ParachainNode) SendIbcFunds(
 		zap.String("To", amount.Address),
 		zap.String("Amount", amount.Amount.String()),
 	)
+	startedAt := time.Now()
 	hash, err := SendIbcFundsTx(pn.api, kp, channelID, amount, options)
+	trackExtrinsic(pn.TestName, pn.Name(), pn.Image, []string{"Ibc.transfer", channelID, amount.Address, amount.Denom, amount.Amount.String()}, hash, err, startedAt)
 	if err != nil {
 		pn.log.Info("IBC Transfer not sent", zap.String("hash", fmt.Sprintf("%#x", hash)), zap.String("container", pn.Name()))
 		return err
@@ -0,0 +1,52 @@
+package polkadot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyGenesisOverrides(t *testing.T) {
+	var chainSpec interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"para_id":2000,"genesis":{"runtime":{"parachainInfo":{"parachainId":2000}}}}`), &chainSpec))
+
+	require.NoError(t, applyGenesisOverrides(chainSpec, map[string]any{
+		"para_id": 2087,
+		"genesis.runtime.parachainInfo.parachainId": 2087,
+	}))
+
+	b, err := json.Marshal(chainSpec)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"para_id":2087,"genesis":{"runtime":{"parachainInfo":{"parachainId":2087}}}}`, string(b))
+
+	require.Error(t, applyGenesisOverrides(chainSpec, map[string]any{"genesis.missing.key": 1}))
+}
+
+func TestApplyGenesisOverrides_YAML(t *testing.T) {
+	var chainSpec interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"genesis":{"runtime":{"parachainInfo":{"parachainId":2000}}}}`), &chainSpec))
+
+	// Non-string keys make YAML decode the nested map as map[interface{}]interface{}.
+	var cfg ibc.ParachainConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+genesis-overrides:
+  genesis.runtime.parachainInfo:
+    parachainId: 2087
+    reserved:
+      1: alice
+`), &cfg))
+
+	require.NoError(t, applyGenesisOverrides(chainSpec, cfg.GenesisOverrides))
+
+	reserved, err := dyno.Get(chainSpec, "genesis", "runtime", "parachainInfo", "reserved")
+	require.NoError(t, err)
+	require.IsType(t, map[string]interface{}{}, reserved)
+
+	b, err := json.MarshalIndent(chainSpec, "", "  ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"genesis":{"runtime":{"parachainInfo":{"parachainId":2087,"reserved":{"1":"alice"}}}}}`, string(b))
+}
@@ -6,7 +6,8 @@ import (
 	crand "crypto/rand"
 	"encoding/json"
 	"fmt"
-	"io"
+	stdmath "math"
+	"strconv"
 	"strings"
 
 	"cosmossdk.io/math"
@@ -14,7 +15,6 @@ import (
 	"github.com/StirlingMarketingGroup/go-namecase"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
-	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
@@ -64,14 +64,7 @@ type PolkadotParachainSpec struct {
 }
 
 // ParachainConfig is a shared type that allows callers of this module to configure a parachain.
-type ParachainConfig struct {
-	ChainID         string
-	Bin             string
-	Image           ibc.DockerImage
-	NumNodes        int
-	Flags           []string
-	RelayChainFlags []string
-}
+type ParachainConfig = ibc.ParachainConfig
 
 // IndexedName is a slice of the substrate dev key names used for key derivation.
 var IndexedName = []string{"alice", "bob", "charlie", "dave", "ferdie"}
@@ -95,6 +88,15 @@ func (c *PolkadotChain) Config() ibc.ChainConfig {
 	return c.cfg
 }
 
+// Images returns the images of the relay chain and of every parachain.
+func (c *PolkadotChain) Images() []ibc.DockerImage {
+	images := append([]ibc.DockerImage(nil), c.Config().Images...)
+	for _, parachain := range c.parachainConfig {
+		images = append(images, parachain.Image)
+	}
+	return images
+}
+
 func (c *PolkadotChain) NewRelayChainNode(
 	ctx context.Context,
 	i int,
@@ -195,18 +197,19 @@ func (c *PolkadotChain) NewParachainNode(
 		return nil, fmt.Errorf("error generating node key: %w", err)
 	}
 	pn := &ParachainNode{
-		log:             c.log,
-		Index:           i,
-		Chain:           c,
-		DockerClient:    dockerClient,
-		NetworkID:       networkID,
-		TestName:        testName,
-		NodeKey:         nodeKey,
-		Image:           parachainConfig.Image,
-		Bin:             parachainConfig.Bin,
-		ChainID:         parachainConfig.ChainID,
-		Flags:           parachainConfig.Flags,
-		RelayChainFlags: parachainConfig.RelayChainFlags,
+		log:              c.log,
+		Index:            i,
+		Chain:            c,
+		DockerClient:     dockerClient,
+		NetworkID:        networkID,
+		TestName:         testName,
+		NodeKey:          nodeKey,
+		Image:            parachainConfig.Image,
+		Bin:              parachainConfig.Bin,
+		ChainID:          parachainConfig.ChainID,
+		Flags:            parachainConfig.Flags,
+		RelayChainFlags:  parachainConfig.RelayChainFlags,
+		GenesisOverrides: parachainConfig.GenesisOverrides,
 	}
 
 	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, dockerClient, pn.Name())
@@ -242,26 +245,9 @@ func (c *PolkadotChain) NewParachainNode(
 func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
 	relayChainNodes := []*RelayChainNode{}
 	chainCfg := c.Config()
-	images := []ibc.DockerImage{}
-	images = append(images, chainCfg.Images...)
-	for _, parachain := range c.parachainConfig {
-		images = append(images, parachain.Image)
-	}
-	for _, image := range images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
+	for _, image := range c.Images() {
+		if err := dockerutil.EnsureImage(ctx, c.log, cli, image); err != nil {
+			return err
 		}
 	}
 	for i := 0; i < c.numRelayChainNodes; i++ {
@@ -602,14 +588,21 @@ func (c *PolkadotChain) Height(ctx context.Context) (uint64, error) {
 // ExportState exports the chain state at specific height.
 // Implements Chain interface.
 func (c *PolkadotChain) ExportState(ctx context.Context, height int64) (string, error) {
-	panic("[ExportState] not implemented yet")
+	return exportState(ctx, c.substrateAPI(), uint64(height))
 }
 
 // HomeDir is the home directory of a node running in a docker container. Therefore, this maps to
 // the container's filesystem (not the host).
+// HomeDir returns an empty string until the nodes are created by Initialize.
 // Implements Chain interface.
 func (c *PolkadotChain) HomeDir() string {
-	panic("[HomeDir] not implemented yet")
+	if len(c.ParachainNodes) > 0 && len(c.ParachainNodes[0]) > 0 {
+		return c.ParachainNodes[0][0].NodeHome()
+	}
+	if len(c.RelayChainNodes) > 0 {
+		return c.RelayChainNodes[0].NodeHome()
+	}
+	return ""
 }
 
 func NewMnemonic() (string, error) {
@@ -805,19 +798,29 @@ type AccountInfo struct {
 // GetGasFeesInNativeDenom gets the fees in native denom for an amount of spent gas.
 // Implements Chain interface.
 func (c *PolkadotChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
-	panic("[GetGasFeesInNativeDenom] not implemented yet")
+	gasPrice, _ := strconv.ParseFloat(strings.Replace(c.cfg.GasPrices, c.cfg.Denom, "", 1), 64)
+	fees := float64(gasPaid) * gasPrice
+	return int64(stdmath.Ceil(fees))
 }
 
 // Acknowledgements returns all acknowledgements in a block at height.
 // Implements Chain interface.
 func (c *PolkadotChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("[Acknowledgements] not implemented yet")
+	events, err := queryIbcEvents(ctx, c.substrateAPI(), height)
+	if err != nil {
+		return nil, err
+	}
+	return packetAcknowledgements(events)
 }
 
 // Timeouts returns all timeouts in a block at height.
 // Implements Chain interface.
 func (c *PolkadotChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("[Timeouts] not implemented yet")
+	events, err := queryIbcEvents(ctx, c.substrateAPI(), height)
+	if err != nil {
+		return nil, err
+	}
+	return packetTimeouts(events)
 }
 
 // GetKeyringPair returns the keyring pair from the keyring using keyName
@@ -836,9 +839,9 @@ func (c *PolkadotChain) GetKeyringPair(keyName string) (signature.KeyringPair, e
 	return kp, nil
 }
 
-// FindTxs implements blockdb.BlockSaver (Not implemented yet for polkadot, but we don't want to exit)
+// FindTxs implements blockdb.BlockSaver.
 func (c *PolkadotChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
-	return []blockdb.Tx{}, nil
+	return findTxs(ctx, c.substrateAPI(), height)
 }
 
 // GetIbcBalance returns the Coins type of ibc coins in account
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -89,3 +90,7 @@ func TestWalletMethods(t *testing.T) {
 	require.NoError(t, err, "Error getting recover address for recover comparison")
 	require.Equal(t, userAddress, recoverAddress, "User and recover addresses not equal")
 }
+
+func TestHomeDir_BeforeInitialize(t *testing.T) {
+	require.Empty(t, new(polkadot.PolkadotChain).HomeDir())
+}
@@ -224,6 +224,8 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 		fmt.Sprintf("--public-addr=%s", multiAddress),
 		"--base-path", p.NodeHome(),
 	}
+
+	p.containerLifecycle.SetResourceLimits(p.Chain.Config().ResourceLimits)
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), p.HostName(), cmd)
 }
 
@@ -267,7 +269,8 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 
 // Exec runs a container for a specific job and blocks until the container exits.
 func (p *RelayChainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	repo, tag := p.Image.RepositoryAndTag()
+	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, repo, tag)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
@@ -284,7 +287,9 @@ func (p *RelayChainNode) SendFunds(ctx context.Context, keyName string, amount i
 		return err
 	}
 
+	startedAt := time.Now()
 	hash, err := SendFundsTx(p.api, kp, amount)
+	trackExtrinsic(p.TestName, p.Name(), p.Image, []string{"Balances.transfer", amount.Address, amount.Amount.String()}, hash, err, startedAt)
 	if err != nil {
 		return err
 	}
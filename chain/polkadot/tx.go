@@ -2,15 +2,31 @@ package polkadot
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
 	"github.com/misko9/go-substrate-rpc-client/v4/signature"
 	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 )
 
+// trackExtrinsic reports an extrinsic submitted over RPC like a command run in the node's container,
+// so that transfers appear in test reports next to the CLI transactions of other chains.
+// The command is the pallet call followed by its arguments, and the output is the extrinsic hash.
+func trackExtrinsic(testName, containerName string, image ibc.DockerImage, call []string, hash gstypes.Hash, err error, startedAt time.Time) {
+	res := dockerutil.ContainerExecResult{Err: err}
+	if err != nil {
+		res.ExitCode = -1
+	} else {
+		res.Stdout = []byte(fmt.Sprintf("%#x", hash))
+	}
+	dockerutil.TrackExec(testName, containerName, image.Ref(), call, res, startedAt, time.Now())
+}
+
 // SendFundsTx sends funds to a wallet using the SubstrateAPI
 func SendFundsTx(api *gsrpc.SubstrateAPI, senderKeypair signature.KeyringPair, amount ibc.WalletAmount) (gstypes.Hash, error) {
 	hash := gstypes.Hash{}
@@ -0,0 +1,56 @@
+package polkadot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+type recordedExec struct {
+	containerName, image string
+	command              []string
+	stdout               string
+	exitCode             int
+	err                  error
+}
+
+type execRecorder []recordedExec
+
+func (r *execRecorder) TrackExec(
+	containerName, image string,
+	command []string,
+	stdout, stderr string,
+	exitCode int,
+	startedAt, finishedAt time.Time,
+	err error,
+) {
+	*r = append(*r, recordedExec{containerName, image, command, stdout, exitCode, err})
+}
+
+func TestTrackExtrinsic(t *testing.T) {
+	var rec execRecorder
+	t.Cleanup(dockerutil.RegisterExecReporter(t.Name(), &rec))
+
+	image := ibc.DockerImage{Repository: "parity/polkadot", Version: "v0.9.39"}
+	call := []string{"Balances.transfer", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", "100"}
+
+	trackExtrinsic(t.Name(), "relaychain-0", image, call, gstypes.Hash{0xab}, nil, time.Now())
+	trackExtrinsic(t.Name(), "relaychain-0", image, call, gstypes.Hash{}, errors.New("priority is too low"), time.Now())
+	trackExtrinsic(t.Name()+"/other", "relaychain-0", image, call, gstypes.Hash{}, nil, time.Now())
+
+	require.Len(t, rec, 2)
+	require.Equal(t, recordedExec{
+		containerName: "relaychain-0",
+		image:         "parity/polkadot:v0.9.39",
+		command:       call,
+		stdout:        "0xab00000000000000000000000000000000000000000000000000000000000000",
+	}, rec[0])
+	require.Equal(t, -1, rec[1].exitCode)
+	require.EqualError(t, rec[1].err, "priority is too low")
+}
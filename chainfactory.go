@@ -141,25 +141,17 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 	case "penumbra":
 		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
 	case "polkadot":
-		// TODO Clean this up. RelayChain config should only reference cfg.Images[0] and parachains should iterate through the remaining
-		// Maybe just pass everything in like NewCosmosChain and NewPenumbraChain, let NewPolkadotChain figure it out
-		// Or parachains and ICS consumer chains maybe should be their own chain
-		switch {
-		case strings.Contains(cfg.Name, "composable"):
-			parachains := []polkadot.ParachainConfig{{
-				//Bin:             "composable",
-				Bin:     "parachain-node",
-				ChainID: "dev-2000",
-				//ChainID:         "dali-dev",
-				Image:           cfg.Images[1],
-				NumNodes:        nf,
-				Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
-				RelayChainFlags: []string{"--execution=wasm"},
-			}}
-			return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
-		default:
-			return nil, fmt.Errorf("unexpected error, unknown polkadot parachain: %s", cfg.Name)
+		if len(cfg.Parachains) == 0 {
+			return nil, fmt.Errorf("polkadot chain %s has no parachains configured", cfg.Name)
 		}
+		parachains := make([]polkadot.ParachainConfig, len(cfg.Parachains))
+		for i, pc := range cfg.Parachains {
+			if pc.NumNodes == 0 {
+				pc.NumNodes = nf
+			}
+			parachains[i] = pc
+		}
+		return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
 	default:
 		return nil, fmt.Errorf("unexpected error, unknown chain type: %s for chain: %s", cfg.Type, cfg.Name)
 	}
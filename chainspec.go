@@ -158,48 +158,79 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 		cfg.Images[0].Version = versionSplit[1]
 		cfg.Images[1].Version = versionSplit[0]
 	case "polkadot":
-		// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
-		if s.Version != "" {
-			versionSplit := strings.Split(s.Version, ",")
-			relayChainImageSplit := strings.Split(versionSplit[0], ":")
-			var relayChainVersion string
-			if len(relayChainImageSplit) > 1 {
-				if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
-					relayChainImageSplit[0] != "polkadot" {
-					return nil, fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
-				}
-				relayChainVersion = relayChainImageSplit[1]
-			} else {
-				relayChainVersion = relayChainImageSplit[0]
-			}
-			cfg.Images[0].Version = relayChainVersion
-			switch {
-			case strings.Contains(s.Name, "composable"):
-				if len(versionSplit) != 2 {
-					return nil, fmt.Errorf("unexpected composable version: %s. should be comma separated polkadot:version,composable:version", s.Version)
-				}
-				imageSplit := strings.Split(versionSplit[1], ":")
-				if len(imageSplit) != 2 {
-					return nil, fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
-				}
-				if !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
-					return nil, fmt.Errorf("unexpected parachain: %s", imageSplit[0])
-				}
-				cfg.Images[1].Version = imageSplit[1]
-			default:
-				return nil, fmt.Errorf("unexpected parachain: %s", s.Name)
-			}
-		} else {
-			// Ensure there are at least two images and check the 2nd version is populated
-			if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
-				return nil, fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
-			}
+		if err := s.applyPolkadotVersions(&cfg); err != nil {
+			return nil, err
 		}
 	}
 
 	return &cfg, nil
 }
 
+// applyPolkadotVersions sets the image versions of the relay chain and its parachains.
+//
+// Images beyond the first, i.e. the relay chain image, override the images of the parachains in order.
+// The Version of the ChainSpec is the comma separated relay chain version followed by
+// a parachain_name:parachain_version entry for each parachain, e.g. polkadot:v0.9.19,composable:v2.1.9.
+func (s *ChainSpec) applyPolkadotVersions(cfg *ibc.ChainConfig) error {
+	// The images and parachains may still be shared with the ChainConfig of the spec, which must not change.
+	cfg.Images = append([]ibc.DockerImage(nil), cfg.Images...)
+	parachains := make([]ibc.ParachainConfig, len(cfg.Parachains))
+	for i, pc := range cfg.Parachains {
+		parachains[i] = pc.Clone()
+	}
+	cfg.Parachains = parachains
+
+	if len(cfg.Images) > 1 {
+		if len(cfg.Images)-1 > len(cfg.Parachains) {
+			return fmt.Errorf("polkadot chain has %d parachain images but %d parachains", len(cfg.Images)-1, len(cfg.Parachains))
+		}
+		for i, image := range cfg.Images[1:] {
+			cfg.Parachains[i].Image = image
+		}
+		cfg.Images = cfg.Images[:1]
+	}
+
+	// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
+	if s.Version == "" {
+		for _, pc := range cfg.Parachains {
+			if pc.Image.Version == "" && pc.Image.Build == nil {
+				return fmt.Errorf("parachain %s image version must not be empty", pc.ChainID)
+			}
+		}
+		return nil
+	}
+
+	versionSplit := strings.Split(s.Version, ",")
+	relayChainImageSplit := strings.Split(versionSplit[0], ":")
+	var relayChainVersion string
+	if len(relayChainImageSplit) > 1 {
+		if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
+			relayChainImageSplit[0] != "polkadot" {
+			return fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
+		}
+		relayChainVersion = relayChainImageSplit[1]
+	} else {
+		relayChainVersion = relayChainImageSplit[0]
+	}
+	cfg.Images[0].Version = relayChainVersion
+
+	if len(versionSplit)-1 != len(cfg.Parachains) {
+		return fmt.Errorf("unexpected polkadot version: %s. should be comma separated polkadot:version followed by parachain_name:parachain_version for each of the %d parachain(s)", s.Version, len(cfg.Parachains))
+	}
+	for i, v := range versionSplit[1:] {
+		imageSplit := strings.Split(v, ":")
+		if len(imageSplit) != 2 {
+			return fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", v)
+		}
+		pc := &cfg.Parachains[i]
+		if !strings.Contains(pc.Image.Repository, imageSplit[0]) && pc.Bin != imageSplit[0] {
+			return fmt.Errorf("unexpected parachain: %s", imageSplit[0])
+		}
+		pc.Image.Version = imageSplit[1]
+	}
+	return nil
+}
+
 // suffix returns the automatically generated, concurrency-safe suffix for
 // generating a chain name or chain ID.
 func (s *ChainSpec) suffix() string {
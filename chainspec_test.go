@@ -122,6 +122,101 @@ func TestChainSpec_Config(t *testing.T) {
 		})
 	})
 
+	t.Run("parachains", func(t *testing.T) {
+		t.Run("versions", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name:    "composable",
+				Version: "polkadot:v0.9.19,composable:centauri",
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+			require.Equal(t, "v0.9.19", cfg.Images[0].Version)
+			require.Len(t, cfg.Parachains, 1)
+			require.Equal(t, "parachain-node", cfg.Parachains[0].Bin)
+			require.Equal(t, "dev-2000", cfg.Parachains[0].ChainID)
+			require.Equal(t, "centauri", cfg.Parachains[0].Image.Version)
+		})
+
+		t.Run("parachain images", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name: "composable",
+				ChainConfig: ibc.ChainConfig{
+					Images: []ibc.DockerImage{
+						{Repository: "seunlanlege/centauri-polkadot", Version: "v0.9.27"},
+						{Repository: "seunlanlege/centauri-parachain", Version: "v0.9.27"},
+					},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+			require.Len(t, cfg.Images, 1)
+			require.Equal(t, ibc.DockerImage{Repository: "seunlanlege/centauri-parachain", Version: "v0.9.27"}, cfg.Parachains[0].Image)
+		})
+
+		t.Run("configured parachains", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name: "composable",
+				ChainConfig: ibc.ChainConfig{
+					Images: []ibc.DockerImage{{Repository: "parity/polkadot", Version: "v0.9.39"}},
+					Parachains: []ibc.ParachainConfig{
+						{Bin: "polkadot-parachain", ChainID: "rococo-local-1000", Image: ibc.DockerImage{Repository: "parity/polkadot-parachain", Version: "0.9.390"}},
+						{Bin: "parachain-template-node", ChainID: "local", NumNodes: 1, Image: ibc.DockerImage{Repository: "parachain-template", Version: "v1"},
+							GenesisOverrides: map[string]any{"para_id": 2001}},
+					},
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+			require.Len(t, cfg.Parachains, 2)
+			require.Equal(t, "polkadot-parachain", cfg.Parachains[0].Bin)
+			require.Equal(t, map[string]any{"para_id": 2001}, cfg.Parachains[1].GenesisOverrides)
+		})
+
+		t.Run("spec parachains are not modified", func(t *testing.T) {
+			parachains := []ibc.ParachainConfig{
+				{Bin: "polkadot-parachain", ChainID: "rococo-local-1000", Image: ibc.DockerImage{Repository: "parity/polkadot-parachain", Version: "0.9.390"}},
+			}
+			s := interchaintest.ChainSpec{
+				ChainName: "relay",
+				Version:   "polkadot:v0.9.40,polkadot-parachain:0.9.400",
+				ChainConfig: ibc.ChainConfig{
+					Type:           "polkadot",
+					ChainID:        "rococo-local",
+					Images:         []ibc.DockerImage{{Repository: "parity/polkadot", Version: "v0.9.39"}, {Repository: "parity/polkadot-parachain", Version: "0.9.391"}},
+					Bin:            "polkadot",
+					Bech32Prefix:   "..",
+					Denom:          "uDOT",
+					GasPrices:      "0.0uDOT",
+					GasAdjustment:  1,
+					TrustingPeriod: "336h",
+					Parachains:     parachains,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+			require.Equal(t, "v0.9.40", cfg.Images[0].Version)
+			require.Equal(t, ibc.DockerImage{Repository: "parity/polkadot-parachain", Version: "0.9.400"}, cfg.Parachains[0].Image)
+
+			require.Equal(t, "v0.9.39", s.ChainConfig.Images[0].Version)
+			require.Len(t, s.ChainConfig.Images, 2)
+			require.Equal(t, "0.9.390", parachains[0].Image.Version)
+		})
+
+		t.Run("version count mismatch", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name:    "composable",
+				Version: "polkadot:v0.9.19",
+			}
+
+			_, err := s.Config(zaptest.NewLogger(t))
+			require.ErrorContains(t, err, "parachain_name:parachain_version for each of the 1 parachain(s)")
+		})
+	})
+
 	t.Run("error cases", func(t *testing.T) {
 		t.Run("version required", func(t *testing.T) {
 			s := interchaintest.ChainSpec{
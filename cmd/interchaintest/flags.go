@@ -18,7 +18,13 @@ type mainFlags struct {
 	LogLevel          string
 	MatrixFile        string
 	ReportFile        string
+	JUnitFile         string
+	HTMLFile          string
 	BlockDatabaseFile string
+
+	// Flags of the report subcommand.
+	ConvertFormat  string
+	ConvertOutFile string
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
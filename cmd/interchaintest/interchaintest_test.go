@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -36,6 +37,10 @@ func init() {
 `)
 		debugFlagSet.PrintDefaults()
 		fmt.Fprint(out, `
+  report  Convert a JSON test report to JUnit XML or HTML: report [flags] <report.json>
+`)
+		reportFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
   version  Prints git commit that produced executable.
 `)
 	}
@@ -48,7 +53,10 @@ var testMatrix struct {
 	ChainSets [][]*interchaintest.ChainSpec
 }
 
-var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
+var (
+	debugFlagSet  = flag.NewFlagSet("debug", flag.ExitOnError)
+	reportFlagSet = flag.NewFlagSet("report", flag.ExitOnError)
+)
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
@@ -64,6 +72,12 @@ func TestMain(m *testing.M) {
 			os.Exit(1)
 		}
 		os.Exit(0)
+	case "report":
+		if err := convertReport(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to convert report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	case "version":
 		fmt.Fprintln(os.Stderr, version.GitSha)
 		os.Exit(0)
@@ -148,27 +162,89 @@ func validateTestMatrix() error {
 var reporter *testreporter.Reporter
 
 func configureTestReporter() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get user home dir: %w", err)
-	}
-	fpath := filepath.Join(home, ".interchaintest", "reports")
-	err = os.MkdirAll(fpath, 0755)
-	if err != nil {
-		return fmt.Errorf("mkdirall: %w", err)
+	reportFile := extraFlags.ReportFile
+	if reportFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get user home dir: %w", err)
+		}
+		fpath := filepath.Join(home, ".interchaintest", "reports")
+		err = os.MkdirAll(fpath, 0755)
+		if err != nil {
+			return fmt.Errorf("mkdirall: %w", err)
+		}
+		reportFile = filepath.Join(fpath, fmt.Sprintf("%d.json", time.Now().Unix()))
 	}
 
-	f, err := os.Create(filepath.Join(fpath, fmt.Sprintf("%d.json", time.Now().Unix())))
+	f, err := os.Create(reportFile)
 	if err != nil {
 		return err
 	}
-
 	fmt.Fprintf(os.Stderr, "Writing report to %s\n", f.Name())
+	sinks := []testreporter.Sink{testreporter.NewJSONSink(f)}
+
+	for format, path := range map[string]string{
+		"junit": extraFlags.JUnitFile,
+		"html":  extraFlags.HTMLFile,
+	} {
+		if path == "" {
+			continue
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Writing %s report to %s\n", format, f.Name())
+		sink, err := newReportSink(format, f)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
 
-	reporter = testreporter.NewReporter(f)
+	reporter = testreporter.NewSinkReporter(sinks...)
 	return nil
 }
 
+// newReportSink returns the sink writing a report in format to w.
+func newReportSink(format string, w io.WriteCloser) (testreporter.Sink, error) {
+	switch format {
+	case "json":
+		return testreporter.NewJSONSink(w), nil
+	case "junit":
+		return testreporter.NewJUnitSink(w), nil
+	case "html":
+		return testreporter.NewHTMLSink(w), nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q: want json, junit or html", format)
+	}
+}
+
+// convertReport converts the JSON report given as argument to the report subcommand.
+func convertReport() error {
+	if reportFlagSet.NArg() != 1 {
+		return fmt.Errorf("expected the path of a JSON report as the only argument")
+	}
+	in, err := os.Open(reportFlagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var out io.WriteCloser = os.Stdout
+	if extraFlags.ConvertOutFile != "" {
+		if out, err = os.Create(extraFlags.ConvertOutFile); err != nil {
+			return err
+		}
+	}
+	sink, err := newReportSink(extraFlags.ConvertFormat, out)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+	return testreporter.Convert(in, sink)
+}
+
 func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerFactory, error) {
 	switch name {
 	case "rly", "cosmos/relayer":
@@ -247,8 +323,13 @@ func addFlags() {
 	flag.StringVar(&extraFlags.LogFormat, "log-format", "console", "Chain and relayer log format: console|json")
 	flag.StringVar(&extraFlags.LogLevel, "log-level", "info", "Chain and relayer log level: debug|info|error")
 	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.StringVar(&extraFlags.JUnitFile, "junit-file", "", "Optional path where a JUnit XML test report will be stored, alongside the JSON report.")
+	flag.StringVar(&extraFlags.HTMLFile, "html-file", "", "Optional path where an HTML test report will be stored, alongside the JSON report.")
 
 	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+
+	reportFlagSet.StringVar(&extraFlags.ConvertFormat, "format", "junit", "Report format: junit|html|json")
+	reportFlagSet.StringVar(&extraFlags.ConvertOutFile, "out", "", "Path where the converted report will be stored. Defaults to stdout.")
 }
 
 func parseFlags() {
@@ -257,6 +338,8 @@ func parseFlags() {
 	case "debug":
 		// Ignore errors because configured with flag.ExitOnError.
 		_ = debugFlagSet.Parse(os.Args[2:])
+	case "report":
+		_ = reportFlagSet.Parse(os.Args[2:])
 	}
 }
 
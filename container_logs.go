@@ -0,0 +1,97 @@
+package interchaintest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/zap"
+)
+
+// ContainerLogLine is a single line written by a container to stdout or stderr.
+type ContainerLogLine = dockerutil.LogLine
+
+// ContainerLogs collects the output of every container associated with a test.
+// Instances of ContainerLogs must be retrieved through CaptureContainerLogs.
+type ContainerLogs struct {
+	c *dockerutil.LogCollector
+}
+
+// CaptureContainerLogs streams the stdout and stderr of every container associated with t
+// into one file per container, under ~/.interchaintest/logs/<test name>/,
+// replacing the files of any previous run of the test.
+// The most recent 100,000 lines are also kept in memory; see SetMaxLines.
+// If rep is not nil, every line is also recorded in the report.
+//
+// When t completes, collection stops and t fails if any line matched a pattern passed to AssertNoLog.
+// Call CaptureContainerLogs after DockerSetup so that collection stops before the containers are cleaned up.
+func CaptureContainerLogs(ctx context.Context, t *testing.T, cli *client.Client, rep *testreporter.Reporter) *ContainerLogs {
+	t.Helper()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("user home dir: %v", err)
+	}
+	dir := filepath.Join(home, ".interchaintest", "logs", dockerutil.SanitizeContainerName(t.Name()))
+
+	var lr dockerutil.ContainerLogReporter
+	if rep != nil {
+		lr = rep.ContainerLogReporter(t)
+	}
+
+	c, err := dockerutil.NewLogCollector(zap.NewNop(), cli, t.Name(), dir, lr)
+	if err != nil {
+		t.Fatalf("failed to create log collector: %v", err)
+	}
+	c.Start(ctx)
+
+	t.Cleanup(func() {
+		c.Stop()
+		for _, v := range c.Violations() {
+			t.Errorf("%s", v)
+		}
+	})
+
+	return &ContainerLogs{c: c}
+}
+
+// Dir returns the directory containing the per-container log files.
+func (l *ContainerLogs) Dir() string {
+	return l.c.Dir()
+}
+
+// Follow additionally collects the output of the container with the given ID,
+// for containers that were not created by interchaintest.
+func (l *ContainerLogs) Follow(ctx context.Context, containerID string) error {
+	return l.c.Follow(ctx, containerID)
+}
+
+// WaitForLog blocks until a container whose name contains containerName
+// logs a line matching re, or until ctx is done.
+// An empty containerName matches every container.
+func (l *ContainerLogs) WaitForLog(ctx context.Context, containerName string, re *regexp.Regexp) (ContainerLogLine, error) {
+	return l.c.WaitForLog(ctx, containerName, re)
+}
+
+// AssertNoLog fails the test when it completes if any container logged,
+// or logs in the future, a line matching pattern.
+// AssertNoLog panics if pattern is not a valid regular expression.
+func (l *ContainerLogs) AssertNoLog(pattern string) {
+	l.c.AssertNoLog(regexp.MustCompile(pattern))
+}
+
+// Lines returns the lines kept in memory from containers whose name contains containerName.
+func (l *ContainerLogs) Lines(containerName string) []ContainerLogLine {
+	return l.c.Lines(containerName)
+}
+
+// SetMaxLines sets the number of most recent lines kept in memory for WaitForLog and Lines.
+// Every line is still written to the log files and checked against AssertNoLog patterns.
+func (l *ContainerLogs) SetMaxLines(n int) {
+	l.c.SetMaxLines(n)
+}
@@ -0,0 +1,76 @@
+package cosmos_test
+
+import (
+	"math/big"
+	"testing"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// evmTestContract is the creation bytecode of a contract whose constructor emits a log with the topic 0x01,
+// and whose runtime code returns 42 to any call.
+var evmTestContract = common.FromHex(
+	"600160006000a1" + // LOG1(offset 0, size 0, topic 1)
+		"600a6013600039600a6000f3" + // CODECOPY(0, 0x13, 10); RETURN(0, 10), i.e. return the runtime code.
+		"602a60005260206000f3", // Runtime: MSTORE(0, 42); RETURN(0, 32)
+)
+
+// TestEVMContract deploys a contract to the EVM of an Ethermint-style chain, calls it,
+// and finds the log emitted by its constructor both in the receipt and by filtering.
+func TestEVMContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals := 1
+	numFullNodes := 0
+
+	// Ethermint requires chain IDs of the form {identifier}_{EIP155 chain ID}-{version}.
+	cfg := ibc.ChainConfig{
+		ChainID: "evmos_9000-1",
+	}
+
+	chains := interchaintest.CreateChainWithConfig(t, numVals, numFullNodes, "evmos", "v16.0.3", cfg)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	const userFunds = int64(10_000_000_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
+	user := users[0]
+
+	receipt, err := chain.DeployEVMContract(ctx, user.KeyName(), evmTestContract)
+	require.NoError(t, err)
+	require.NotEqual(t, common.Address{}, receipt.ContractAddress)
+
+	require.Len(t, receipt.Logs, 1)
+	topic := common.BigToHash(big.NewInt(1))
+	require.Equal(t, []common.Hash{topic}, receipt.Logs[0].Topics)
+
+	res, err := chain.CallEVMContract(ctx, receipt.ContractAddress.Hex(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), new(big.Int).SetBytes(res).Int64())
+
+	// The receipt can also be retrieved by hash, e.g. for transactions sent by other tools.
+	fetched, err := chain.EVMTransactionReceipt(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, receipt.ContractAddress, fetched.ContractAddress)
+
+	logs, err := chain.EVMLogs(ctx, goethereum.FilterQuery{
+		FromBlock: receipt.BlockNumber,
+		ToBlock:   receipt.BlockNumber,
+		Addresses: []common.Address{receipt.ContractAddress},
+		Topics:    [][]common.Hash{{topic}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, receipt.TxHash, logs[0].TxHash)
+}
@@ -0,0 +1,74 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestJunoHardFork restarts a chain from its exported state, with a modified genesis,
+// and verifies that the counterparty light client follows it across the restart.
+func TestJunoHardFork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	chains := interchaintest.CreateChainsWithChainSpecs(t, []*interchaintest.ChainSpec{
+		{Name: "juno", ChainName: "juno", Version: "v17.0.0"},
+		{Name: "gaia", ChainName: "gaia", Version: "v9.1.0"},
+	})
+	chain, counterpartyChain := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	const path = "hard-fork-test-path"
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.StartupFlags("-b", "100"),
+	).Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain).
+		AddChain(counterpartyChain).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain,
+			Chain2:  counterpartyChain,
+			Relayer: r,
+			Path:    path,
+		})
+
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	result, err := chain.HardFork(ctx, cosmos.HardForkOptions{
+		ModifyGenesis: cosmos.ModifyGenesis([]cosmos.GenesisKV{
+			cosmos.NewGenesisKV("app_state.gov.params.voting_period", "15s"),
+		}),
+		Relayer:             r,
+		RelayerExecReporter: eRep,
+		RelayerPaths:        []string{path},
+	})
+	require.NoError(t, err)
+	require.Greater(t, int64(result.RestartHeight), result.ExportHeight)
+}
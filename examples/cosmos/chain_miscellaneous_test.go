@@ -10,6 +10,7 @@ import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
+	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	testutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/strangelove-ventures/interchaintest/v7"
@@ -87,6 +88,7 @@ func CosmosChainTestMiscellaneous(t *testing.T, name, version string) {
 	testPollForBalance(ctx, t, chain, users)
 	testRangeBlockMessages(ctx, t, chain, users)
 	testBroadcaster(ctx, t, chain, users)
+	testOfflineSigning(ctx, t, chain)
 	testQueryCmd(ctx, t, chain)
 	testHasCommand(ctx, t, chain)
 	testTokenFactory(ctx, t, chain, users)
@@ -315,6 +317,67 @@ func testBroadcaster(ctx context.Context, t *testing.T, chain *cosmos.CosmosChai
 	updatedBal2, err := chain.GetBalance(ctx, addr2, chain.Config().Denom)
 	require.NoError(t, err)
 	require.Equal(t, math.NewInt(2), updatedBal2)
+
+	// Simulate the gas of the transaction instead of using the default gas limit.
+	b.ConfigureFactoryOptions(cosmos.SimulateGas())
+	txResp, err = cosmos.BroadcastTx(
+		ctx,
+		b,
+		users[0],
+		banktypes.NewMsgSend(sdk.MustAccAddressFromBech32(from), sdk.MustAccAddressFromBech32(addr1), c1),
+	)
+	require.NoError(t, err)
+	require.Less(t, txResp.GasWanted, int64(flags.DefaultGasLimit))
+	require.LessOrEqual(t, txResp.GasUsed, txResp.GasWanted)
+
+	fee, err := cosmos.TxFee(txResp)
+	require.NoError(t, err)
+	require.True(t, fee.AmountOf(chain.Config().Denom).GTE(math.NewInt(chain.GetGasFeesInNativeDenom(txResp.GasWanted))))
+}
+
+func testOfflineSigning(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain) {
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "signer", int64(10_000_000_000), chain, chain, chain)
+	denom := chain.Config().Denom
+	recipient := users[2].FormattedAddress()
+
+	// 2-of-3 multisig wallet.
+	multisig, err := chain.CreateMultisigWallet(ctx, "multisig", 2, users)
+	require.NoError(t, err)
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: multisig.FormattedAddress(),
+		Denom:   denom,
+		Amount:  math.NewInt(1_000_000),
+	}))
+
+	resp, err := chain.MultisigTx(ctx, multisig, users[:2], "bank", "send", multisig.FormattedAddress(), recipient, "7"+denom)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code)
+
+	// users[1] sends on behalf of users[0] through authz.
+	resp, err = chain.AuthzGrant(ctx, users[0], users[1].FormattedAddress(), "send", "--spend-limit", "100"+denom)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code)
+
+	resp, err = chain.AuthzExec(ctx, users[1], users[0].FormattedAddress(), "bank", "send", users[0].FormattedAddress(), recipient, "3"+denom)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code)
+
+	// users[0] pays the fees of users[1] through a fee grant.
+	resp, err = chain.FeeGrant(ctx, users[0], users[1].FormattedAddress(), "--spend-limit", "1000000"+denom)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code)
+
+	before, err := chain.GetBalance(ctx, users[1].FormattedAddress(), denom)
+	require.NoError(t, err)
+	_, err = chain.GetNode().ExecTx(ctx, users[1].KeyName(), "bank", "send", users[1].KeyName(), recipient, "1"+denom, "--fee-granter", users[0].FormattedAddress())
+	require.NoError(t, err)
+	after, err := chain.GetBalance(ctx, users[1].FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, before.SubRaw(1), after, "fees should have been paid by the granter")
+
+	resp, err = chain.FeeGrantRevoke(ctx, users[0], users[1].FormattedAddress())
+	require.NoError(t, err)
+	require.Zero(t, resp.Code)
 }
 
 func testQueryCmd(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain) {
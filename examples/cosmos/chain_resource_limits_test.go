@@ -0,0 +1,116 @@
+package cosmos_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestResourceLimits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const memoryLimit = 1 << 30 // 1 GiB
+
+	numVals := 1
+	numFullNodes := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			Version:       "v9.1.0",
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+			ChainConfig: ibc.ChainConfig{
+				GasPrices: "0.0uatom",
+				ResourceLimits: &ibc.ResourceLimits{
+					CPUs:      1,
+					Memory:    memoryLimit,
+					PidsLimit: 512,
+				},
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0]
+
+	client, network := interchaintest.DockerSetup(t)
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	f, err := os.Create(reportPath)
+	require.NoError(t, err)
+	rep := testreporter.NewReporter(f)
+
+	// Registered before SampleContainerStats, so that it runs once sampling has stopped.
+	t.Cleanup(func() {
+		require.NoError(t, rep.Close())
+
+		// Only the node containers are limited; one-shot helper containers are not.
+		nodeNames := make(map[string]bool)
+		for _, n := range gaia.(*cosmos.CosmosChain).Nodes() {
+			nodeNames[n.Name()] = true
+		}
+		requireStatsWithinLimits(t, reportPath, nodeNames, memoryLimit)
+	})
+
+	// Sample every container of the test until it completes.
+	interchaintest.SampleContainerStats(ctx, t, client, rep, time.Second)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+	require.NoError(t, ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, gaia))
+}
+
+// requireStatsWithinLimits asserts that the report at reportPath contains container stats samples
+// for the containers in nodeNames, all of which carry the configured memory limit.
+func requireStatsWithinLimits(t *testing.T, reportPath string, nodeNames map[string]bool, memoryLimit uint64) {
+	t.Helper()
+
+	report, err := os.Open(reportPath)
+	require.NoError(t, err)
+	defer report.Close()
+
+	var samples []testreporter.ContainerStatsMessage
+	scanner := bufio.NewScanner(report)
+	for scanner.Scan() {
+		var m testreporter.WrappedMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &m))
+		if s, ok := m.Message.(testreporter.ContainerStatsMessage); ok && nodeNames[s.ContainerName] {
+			samples = append(samples, s)
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	require.NotEmpty(t, samples, "no node container stats were sampled")
+	for _, s := range samples {
+		require.Equal(t, memoryLimit, s.MemoryLimit, "container %s", s.ContainerName)
+		require.LessOrEqual(t, s.MemoryUsage, s.MemoryLimit, "container %s", s.ContainerName)
+	}
+}
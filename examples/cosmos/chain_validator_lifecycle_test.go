@@ -0,0 +1,91 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidatorLifecycle adds validators after genesis, jails and unjails one for downtime,
+// and tombstones another for double signing.
+func TestValidatorLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// Two genesis validators keep the chain live while the added, low power, validators misbehave.
+	// The fullnode observes the chain while a validator is stopped.
+	numVals := 2
+	numFullNodes := 1
+
+	const downtimeJailDuration = 10 * time.Second
+
+	// SDK v45 params for Gaia genesis, so that downtime is detected within a few blocks.
+	shortSlashingGenesis := []cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.slashing.params.signed_blocks_window", "10"),
+		cosmos.NewGenesisKV("app_state.slashing.params.min_signed_per_window", "0.500000000000000000"),
+		cosmos.NewGenesisKV("app_state.slashing.params.downtime_jail_duration", downtimeJailDuration.String()),
+	}
+
+	cfg := ibc.ChainConfig{
+		Denom:         "uatom",
+		ModifyGenesis: cosmos.ModifyGenesis(shortSlashingGenesis),
+	}
+
+	chains := interchaintest.CreateChainWithConfig(t, numVals, numFullNodes, "gaia", "v9.1.0", cfg)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	selfDelegation := sdk.NewCoin(chain.Config().Denom, math.NewInt(10_000_000))
+	vals, err := chain.AddValidators(ctx, nil, 2, interchaintest.FaucetAccountKeyName, selfDelegation)
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+
+	jailed, doubleSigner := vals[0], vals[1]
+
+	t.Run("jail and unjail", func(t *testing.T) {
+		require.NoError(t, chain.JailValidator(ctx, jailed, 2*time.Minute))
+		requireJailed(ctx, t, chain, jailed, true)
+
+		// The validator may only unjail once downtime_jail_duration has passed.
+		time.Sleep(downtimeJailDuration)
+
+		require.NoError(t, chain.UnjailValidator(ctx, jailed))
+		requireJailed(ctx, t, chain, jailed, false)
+	})
+
+	t.Run("double sign", func(t *testing.T) {
+		fn, err := chain.DoubleSign(ctx, doubleSigner)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = fn.StopContainer(ctx)
+			_ = fn.RemoveContainer(ctx)
+		})
+
+		require.NoError(t, chain.WaitForTombstone(ctx, doubleSigner, 2*time.Minute))
+		requireJailed(ctx, t, chain, doubleSigner, true)
+	})
+}
+
+// requireJailed asserts whether the validator val is jailed, as seen by the chain's first validator.
+func requireJailed(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, val *cosmos.ChainNode, jailed bool) {
+	t.Helper()
+
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+
+	v, err := chain.Validators[0].StakingQueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.Equal(t, jailed, v.Jailed, "validator %s", val.Name())
+}
@@ -8,17 +8,22 @@ import (
 	"log"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
 	"gopkg.in/yaml.v2"
+
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 )
 
 // Function to force stop a container
 func StopContainer(containerID string) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err := interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -30,7 +35,7 @@ func StopContainer(containerID string) error {
 
 // Function to stop all containers from an image
 func StopContainerByImage(imageName string) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err := interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -52,7 +57,7 @@ func StopContainerByImage(imageName string) error {
 // Utility for pulling and using an image of mulberry
 func PullMulberryImage(image string) error {
 	// Create a Docker client
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err := interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -79,7 +84,7 @@ func PullMulberryImage(image string) error {
 // RunContainer creates and starts a container from the given image.
 func RunContainerWithConfig(image string, containerName string, localConfigPath string) (string, error) {
 	// Create a Docker client
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err := interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return "", fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -119,10 +124,22 @@ func RunContainerWithConfig(image string, containerName string, localConfigPath
 	return resp.ID, nil
 }
 
-// ExecCommandInContainer executes a command inside a running container
-func ExecCommandInContainer(containerID string, command []string) error {
+// ExecCommandInContainer executes a command inside a running container.
+// It returns once the command has started, without waiting for it to finish,
+// so long-running commands such as "mulberry start" are reported to rep, if not nil,
+// with the exit code they have when ExecCommandInContainer returns.
+func ExecCommandInContainer(rep *testreporter.ExecReporter, containerID string, command []string) (err error) {
+	startedAt := time.Now()
+	var (
+		cli    *client.Client
+		execID string
+	)
+	defer func() {
+		trackExec(cli, rep, containerID, execID, command, nil, startedAt, err)
+	}()
+
 	// Create a Docker client
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err = interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -140,6 +157,7 @@ func ExecCommandInContainer(containerID string, command []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create exec instance: %w", err)
 	}
+	execID = execIDResp.ID
 
 	// Start the exec process
 	resp, err := cli.ContainerExecAttach(ctx, execIDResp.ID, types.ExecStartCheck{})
@@ -156,24 +174,44 @@ func ExecCommandInContainer(containerID string, command []string) error {
 	return nil
 }
 
-func StreamContainerLogsToFile(containerID string, logFile *os.File) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-	if err != nil {
-		return fmt.Errorf("failed to create Docker client: %w", err)
+// trackExec reports command, executed in containerID as execID, to rep, if not nil.
+// output is the multiplexed stdout and stderr of the command.
+// A command that has not exited yet, or whose exec cannot be inspected, is reported with exit code -1.
+func trackExec(cli *client.Client, rep *testreporter.ExecReporter, containerID, execID string, command []string, output []byte, startedAt time.Time, err error) {
+	if rep == nil {
+		return
 	}
 
 	ctx := context.Background()
-	options := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Follow: true}
+	containerName, image := containerID, ""
+	exitCode := -1
+	if cli != nil {
+		if c, inspectErr := cli.ContainerInspect(ctx, containerID); inspectErr == nil {
+			containerName = strings.TrimPrefix(c.Name, "/")
+			image = c.Config.Image
+		}
+		if execID != "" {
+			// The exit code of a command that is still running, e.g. a relayer daemon, is 0 until it exits.
+			if e, inspectErr := cli.ContainerExecInspect(ctx, execID); inspectErr == nil && !e.Running {
+				exitCode = e.ExitCode
+			}
+		}
+	}
 
-	out, err := cli.ContainerLogs(ctx, containerID, options)
-	if err != nil {
-		return fmt.Errorf("failed to fetch container logs: %w", err)
+	var stdout, stderr bytes.Buffer
+	if _, copyErr := stdcopy.StdCopy(&stdout, &stderr, bytes.NewReader(output)); copyErr != nil {
+		stdout.Reset()
+		stdout.Write(output)
 	}
-	defer out.Close()
 
-	// Redirect logs to the provided file
-	_, err = stdcopy.StdCopy(logFile, logFile, out)
-	return err
+	rep.TrackExec(
+		containerName, image,
+		command,
+		stdout.String(), stderr.String(),
+		exitCode,
+		startedAt, time.Now(),
+		err,
+	)
 }
 
 func decodeConfigYAML(configPath string) (config MulberryConfig, err error) {
@@ -306,9 +344,22 @@ func UpdateMulberryJackalRPC(configPath, newRPC string, newGRPC string) error {
 	return nil
 }
 
-func RetrieveFileFromContainer(containerID, filePath string) (string, error) {
+// RetrieveFileFromContainer returns the contents of filePath in the container,
+// reporting the command that reads it to rep, if not nil.
+func RetrieveFileFromContainer(rep *testreporter.ExecReporter, containerID, filePath string) (contents string, err error) {
+	command := []string{"cat", filePath}
+	startedAt := time.Now()
+	var (
+		cli    *client.Client
+		execID string
+		output bytes.Buffer
+	)
+	defer func() {
+		trackExec(cli, rep, containerID, execID, command, output.Bytes(), startedAt, err)
+	}()
+
 	// Create a Docker client
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err = interchaintest.NewContainerRuntimeClient()
 	if err != nil {
 		return "", fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -318,7 +369,7 @@ func RetrieveFileFromContainer(containerID, filePath string) (string, error) {
 
 	// Execute a command to cat the file contents
 	execConfig := types.ExecConfig{
-		Cmd:          []string{"cat", filePath},
+		Cmd:          command,
 		AttachStdout: true,
 		AttachStderr: true,
 	}
@@ -326,6 +377,7 @@ func RetrieveFileFromContainer(containerID, filePath string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to create exec instance: %w", err)
 	}
+	execID = execIDResp.ID
 
 	// Start the exec process
 	resp, err := cli.ContainerExecAttach(ctx, execIDResp.ID, types.ExecStartCheck{})
@@ -335,7 +387,6 @@ func RetrieveFileFromContainer(containerID, filePath string) (string, error) {
 	defer resp.Close()
 
 	// Read the output from the command
-	var output bytes.Buffer
 	if _, err := io.Copy(&output, resp.Reader); err != nil {
 		return "", fmt.Errorf("failed to read file contents: %w", err)
 	}
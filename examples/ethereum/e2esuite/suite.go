@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"testing"
 	"time"
 
 	dockerclient "github.com/docker/docker/client"
@@ -29,6 +30,34 @@ import (
 // Is this a new one or the one that already exists in eigenlayer-deployed-anvil-state.json
 const anvilFaucetPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
 
+// ReportPathEnv is the environment variable naming the file the test report is written to.
+// When unset, the report is written to the test's temporary directory, which is removed after the test.
+const ReportPathEnv = "E2E_REPORT_PATH"
+
+// NewReporter returns a reporter writing to the file named by ReportPathEnv, or to report.json in t.TempDir().
+// The reporter is closed when t completes, so it must be created before anything reporting to it,
+// such as CaptureContainerLogs, registers its own cleanup.
+func NewReporter(t *testing.T) *testreporter.Reporter {
+	path := os.Getenv(ReportPathEnv)
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "report.json")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create report file: %v", err)
+	}
+	t.Logf("Writing test report to %s", path)
+
+	rep := testreporter.NewReporter(f)
+	t.Cleanup(func() {
+		if err := rep.Close(); err != nil {
+			t.Logf("Failed to close test report: %v", err)
+		}
+	})
+	return rep
+}
+
 type TestSuite struct {
 	suite.Suite
 
@@ -40,6 +69,10 @@ type TestSuite struct {
 	Network        string
 	Logger         *zap.Logger
 	ExecRep        *testreporter.RelayerExecReporter
+	// ContainerExecRep reports the commands executed in the Mulberry container.
+	ContainerExecRep *testreporter.ExecReporter
+	// Logs collects the output of the chain and Mulberry containers.
+	Logs *interchaintest.ContainerLogs
 
 	// Don't need light clients for now. Only concerned about deploying outpost and
 	// emitting events
@@ -56,7 +89,12 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 	// We might need to do this in the future.
 
 	s.Logger = zaptest.NewLogger(s.T())
+
+	// Created first, so that it is closed once everything reporting to it has stopped.
+	rep := NewReporter(s.T())
+
 	s.DockerClient, s.Network = interchaintest.DockerSetup(s.T())
+	s.Logs = interchaintest.CaptureContainerLogs(ctx, s.T(), s.DockerClient, rep)
 
 	cf := interchaintest.NewBuiltinChainFactory(s.Logger, icChainSpecs)
 
@@ -71,7 +109,8 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 		ic = ic.AddChain(chain)
 	}
 
-	s.ExecRep = testreporter.NewNopReporter().RelayerExecReporter(s.T())
+	s.ExecRep = rep.RelayerExecReporter(s.T())
+	s.ContainerExecRep = rep.ExecReporter(s.T())
 
 	// Disabling both chains for now
 	// TODO: Run this in a goroutine and wait for it to be ready
@@ -81,6 +120,10 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 		NetworkID:        s.Network,
 		SkipPathCreation: true,
 	}))
+	s.T().Cleanup(func() {
+		_ = ic.Close()
+	})
+
 	// fails on x86 because we use biphan4/foundry docker image
 	// based on https://github.com/foundry-rs/foundry/blob/master/Dockerfile#L13
 
@@ -99,7 +142,18 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 
 	// using local image for now
 	image := "biphan4/mulberry:0.0.9"
-	if err := PullMulberryImage(image); err != nil {
+	if src := os.Getenv("MULBERRY_SRC"); src != "" {
+		// Test a local Mulberry checkout instead of the published image.
+		mulberry := ibc.DockerImage{
+			Repository: "mulberry",
+			Version:    "local",
+			Build:      &ibc.DockerBuild{Context: src},
+		}
+		if err := interchaintest.BuildImage(ctx, s.Logger, s.DockerClient, mulberry); err != nil {
+			log.Fatalf("Error building Mulberry image from %s: %v", src, err)
+		}
+		image = mulberry.Ref()
+	} else if err := PullMulberryImage(image); err != nil {
 		log.Fatalf("Error pulling Docker image: %v", err)
 	}
 
@@ -119,22 +173,15 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 
 	log.Printf("Container is running with ID: %s\n", containerID)
 
-	logFile, err := os.Create("mulberry_logs.txt")
-	if err != nil {
-		log.Fatalf("Failed to create log file: %v", err)
+	// Mulberry is not created by interchaintest, so its logs are collected explicitly.
+	if err := s.Logs.Follow(ctx, containerID); err != nil {
+		log.Fatalf("Failed to collect Mulberry logs: %v", err)
 	}
-	defer logFile.Close()
-
-	go func() {
-		err := StreamContainerLogsToFile(containerID, logFile)
-		if err != nil {
-			log.Printf("Failed to stream Mulberry logs to file: %v", err)
-		}
-	}()
+	log.Printf("Mulberry logs are written to %s\n", s.Logs.Dir())
 
 	// Execute a command inside the container
 	addressCommand := []string{"sh", "-c", "mulberry wallet address >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := ExecCommandInContainer(containerID, addressCommand); err != nil {
+	if err := ExecCommandInContainer(s.ContainerExecRep, containerID, addressCommand); err != nil {
 		log.Fatalf("Error creating wallet address in container: %v", err)
 	}
 
@@ -161,7 +208,7 @@ func (s *TestSuite) SetupSuite(ctx context.Context) {
 
 	// Start Mulberry
 	startCommand := []string{"sh", "-c", "export NO_COLOR=true; mulberry start >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := ExecCommandInContainer(containerID, startCommand); err != nil {
+	if err := ExecCommandInContainer(s.ContainerExecRep, containerID, startCommand); err != nil {
 		log.Fatalf("Error starting mulberry in container: %v", err)
 	}
 
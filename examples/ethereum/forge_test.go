@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/examples/ethereum/e2esuite"
 	"github.com/strangelove-ventures/interchaintest/v7/examples/ethereum/eth"
 
@@ -77,21 +78,21 @@ func (s *OutpostTestSuite) SetupForgeSuite(ctx context.Context) {
 		log.Fatalf("Error running container: %v", err)
 	}
 
-	logFile, err = os.Create("mulberry_logs.txt")
+	// Anvil runs on the host, so only Mulberry's logs are collected.
+	cli, err := interchaintest.NewContainerRuntimeClient()
 	if err != nil {
-		log.Fatalf("Failed to create log file: %v", err)
+		log.Fatalf("Failed to create container client: %v", err)
 	}
-
-	go func() {
-		err := e2esuite.StreamContainerLogsToFile(containerID, logFile)
-		if err != nil {
-			log.Printf("Failed to stream Mulberry logs to file: %v", err)
-		}
-	}()
+	s.Logs = interchaintest.CaptureContainerLogs(ctx, s.T(), cli, nil)
+	// Mulberry is not created by interchaintest, so its logs are collected explicitly.
+	if err := s.Logs.Follow(ctx, containerID); err != nil {
+		log.Fatalf("Failed to collect Mulberry logs: %v", err)
+	}
+	log.Printf("Mulberry logs are written to %s\n", s.Logs.Dir())
 
 	// Give mulberry a wallet
 	addressCommand := []string{"sh", "-c", "mulberry wallet address >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := e2esuite.ExecCommandInContainer(containerID, addressCommand); err != nil {
+	if err := e2esuite.ExecCommandInContainer(s.ContainerExecRep, containerID, addressCommand); err != nil {
 		log.Fatalf("Error creating wallet address in container: %v", err)
 	}
 
@@ -107,7 +108,7 @@ func (s *OutpostTestSuite) SetupForgeSuite(ctx context.Context) {
 	// Start Mulberry
 	// NOTE: get logs some other way, streaming the output of 'start' is blocking the rest of the code
 	startCommand := []string{"sh", "-c", "export NO_COLOR=true; mulberry start >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := e2esuite.ExecCommandInContainer(containerID, startCommand); err != nil {
+	if err := e2esuite.ExecCommandInContainer(s.ContainerExecRep, containerID, startCommand); err != nil {
 		log.Fatalf("Error starting mulberry in container: %v", err)
 	}
 }
@@ -267,7 +268,6 @@ func (s *OutpostTestSuite) TestForge() {
 	}))
 
 	time.Sleep(10 * time.Hour) // if this is active vscode thinks test fails
-	logFile.Close()
 }
 
 func cleanForgeSuite() {
@@ -25,8 +25,7 @@ var EvmUserA string
 
 func (s *OutpostTestSuite) TestJackalEVMBridge() {
 	ctx := context.Background()
-	s.SetupMulberrySuite(ctx, "jackal_evm_log.txt")
-	defer logFile.Close()
+	s.SetupMulberrySuite(ctx)
 
 	// Fund jackal account
 
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -20,7 +19,6 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/examples/ethereum/eth"
 	factorytypes "github.com/strangelove-ventures/interchaintest/v7/examples/ethereum/types/bindingsfactory"
 	logger "github.com/strangelove-ventures/interchaintest/v7/examples/logger"
-	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 
 	"go.uber.org/zap/zaptest"
 )
@@ -29,11 +27,10 @@ var (
 	canineRPCAddress string
 	localConfigPath  string
 	factoryAddress   string
-	logFile          *os.File
 	image            string
 )
 
-func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName string) {
+func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context) {
 	// Start Anvil node
 	anvilArgs := []string{"--port", "8545", "--block-time", "1", "--host", "0.0.0.0", "-vvvvv"}
 	// easiest way to install anvil is foundryup --install stable
@@ -58,7 +55,12 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 	icChainSpecs := chainconfig.ChainSpecs
 
 	s.TestSuite.Logger = zaptest.NewLogger(s.T())
+
+	// Created first, so that it is closed once everything reporting to it has stopped.
+	rep := e2esuite.NewReporter(s.T())
+
 	s.TestSuite.DockerClient, s.Network = interchaintest.DockerSetup(s.T())
+	s.Logs = interchaintest.CaptureContainerLogs(ctx, s.T(), s.DockerClient, rep)
 
 	cf := interchaintest.NewBuiltinChainFactory(s.Logger, icChainSpecs)
 
@@ -72,7 +74,8 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 		ic = ic.AddChain(chain)
 	}
 
-	s.TestSuite.ExecRep = testreporter.NewNopReporter().RelayerExecReporter(s.T())
+	s.TestSuite.ExecRep = rep.RelayerExecReporter(s.T())
+	s.TestSuite.ContainerExecRep = rep.ExecReporter(s.T())
 
 	// TODO: Run this in a goroutine and wait for it to be ready
 	s.Require().NoError(ic.Build(ctx, s.ExecRep, interchaintest.InterchainBuildOptions{
@@ -81,6 +84,9 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 		NetworkID:        s.Network,
 		SkipPathCreation: true,
 	}))
+	s.T().Cleanup(func() {
+		_ = ic.Close()
+	})
 
 	canine := chains[0].(*cosmos.CosmosChain)
 	canineRPC := canine.GetRPCAddress()
@@ -141,21 +147,15 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 		log.Fatalf("Error running container: %v", err)
 	}
 
-	logFile, err = os.Create(logName)
-	if err != nil {
-		log.Fatalf("Failed to create log file: %v", err)
+	// Mulberry is not created by interchaintest, so its logs are collected explicitly.
+	if err := s.Logs.Follow(ctx, containerID); err != nil {
+		log.Fatalf("Failed to collect Mulberry logs: %v", err)
 	}
-
-	go func() {
-		err := e2esuite.StreamContainerLogsToFile(containerID, logFile)
-		if err != nil {
-			log.Printf("Failed to stream Mulberry logs to file: %v", err)
-		}
-	}()
+	log.Printf("Mulberry logs are written to %s\n", s.Logs.Dir())
 
 	// Give mulberry a wallet
 	addressCommand := []string{"sh", "-c", "mulberry wallet address >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := e2esuite.ExecCommandInContainer(containerID, addressCommand); err != nil {
+	if err := e2esuite.ExecCommandInContainer(s.ContainerExecRep, containerID, addressCommand); err != nil {
 		log.Fatalf("Error creating wallet address in container: %v", err)
 	}
 
@@ -175,7 +175,7 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 	// Start Mulberry
 	// NOTE: get logs some other way, streaming the output of 'start' is blocking the rest of the code
 	startCommand := []string{"sh", "-c", "export NO_COLOR=true; mulberry start >> /proc/1/fd/1 2>> /proc/1/fd/2"}
-	if err := e2esuite.ExecCommandInContainer(containerID, startCommand); err != nil {
+	if err := e2esuite.ExecCommandInContainer(s.ContainerExecRep, containerID, startCommand); err != nil {
 		log.Fatalf("Error starting mulberry in container: %v", err)
 	}
 
@@ -185,7 +185,7 @@ func (s *OutpostTestSuite) SetupMulberrySuite(ctx context.Context, logName strin
 
 	filePath := "/root/.mulberry/seed.json"
 
-	contents, err := e2esuite.RetrieveFileFromContainer(containerID, filePath)
+	contents, err := e2esuite.RetrieveFileFromContainer(s.ContainerExecRep, containerID, filePath)
 	if err != nil {
 		log.Fatalf("Failed to retrieve file: %v", err)
 	}
@@ -22,8 +22,7 @@ import (
 
 func (s *OutpostTestSuite) TestStress() {
 	ctx := context.Background()
-	s.SetupMulberrySuite(ctx, "stress_log.txt")
-	defer logFile.Close()
+	s.SetupMulberrySuite(ctx)
 
 	// Connect to Anvil RPC
 	rpcURL := "http://127.0.0.1:8545"
@@ -1,8 +1,10 @@
 package ibc
 
 import (
+	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -52,19 +54,45 @@ type ChainConfig struct {
 	UsingChainIDFlagCLI bool `yaml:"using-chain-id-flag-cli"`
 	// Configuration describing additional sidecar processes.
 	SidecarConfigs []SidecarConfig
+	// CPU, memory, and process limits applied to every node container of the chain.
+	ResourceLimits *ResourceLimits `yaml:"resource-limits"`
+	// Parachains run on the relay chain, used for polkadot chains only.
+	Parachains []ParachainConfig `yaml:"parachains"`
+	// EVM configuration of Ethermint-style cosmos chains, which also serve the Ethereum JSON-RPC API.
+	// Nil for chains without an EVM.
+	EVM *EVMConfig `yaml:"evm"`
 }
 
 func (c ChainConfig) Clone() ChainConfig {
 	x := c
 
 	images := make([]DockerImage, len(c.Images))
-	copy(images, c.Images)
+	for i, image := range c.Images {
+		images[i] = image.clone()
+	}
 	x.Images = images
 
 	sidecars := make([]SidecarConfig, len(c.SidecarConfigs))
 	copy(sidecars, c.SidecarConfigs)
 	x.SidecarConfigs = sidecars
 
+	if c.ResourceLimits != nil {
+		limits := *c.ResourceLimits
+		x.ResourceLimits = &limits
+	}
+
+	if c.Parachains != nil {
+		x.Parachains = make([]ParachainConfig, len(c.Parachains))
+		for i, p := range c.Parachains {
+			x.Parachains[i] = p.Clone()
+		}
+	}
+
+	if c.EVM != nil {
+		evm := c.EVM.Clone()
+		x.EVM = &evm
+	}
+
 	return x
 }
 
@@ -72,6 +100,10 @@ func (c ChainConfig) VerifyCoinType() (string, error) {
 	// If coin-type is left blank in the ChainConfig,
 	// the Cosmos SDK default of 118 is used.
 	if c.CoinType == "" {
+		// Ethermint-style chains derive keys as Ethereum does.
+		if c.EVM != nil {
+			return EVMCoinType, nil
+		}
 		typ := reflect.TypeOf(c)
 		f, _ := typ.FieldByName("CoinType")
 		coinType := f.Tag.Get("default")
@@ -162,6 +194,23 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.SidecarConfigs = append([]SidecarConfig(nil), other.SidecarConfigs...)
 	}
 
+	if other.ResourceLimits != nil {
+		limits := *other.ResourceLimits
+		c.ResourceLimits = &limits
+	}
+
+	if len(other.Parachains) > 0 {
+		c.Parachains = make([]ParachainConfig, len(other.Parachains))
+		for i, p := range other.Parachains {
+			c.Parachains[i] = p.Clone()
+		}
+	}
+
+	if other.EVM != nil {
+		evm := other.EVM.Clone()
+		c.EVM = &evm
+	}
+
 	return c
 }
 
@@ -189,21 +238,145 @@ type SidecarConfig struct {
 	StartCmd         []string
 	PreStart         bool
 	ValidatorProcess bool
+	ResourceLimits   *ResourceLimits
+}
+
+// ParachainConfig describes a parachain run on a polkadot relay chain.
+type ParachainConfig struct {
+	// Binary to execute for the parachain node daemon, e.g. parachain-node.
+	Bin string `yaml:"bin"`
+	// Chain ID passed to the parachain node's --chain flag, e.g. dev-2000.
+	ChainID string `yaml:"chain-id"`
+	// Docker image of the parachain node.
+	Image DockerImage `yaml:"image"`
+	// Number of parachain nodes. If zero, the chain spec's number of full nodes is used.
+	NumNodes int `yaml:"num-nodes"`
+	// Additional flags for the parachain node.
+	Flags []string `yaml:"flags"`
+	// Additional flags for the relay chain node embedded in the parachain node.
+	RelayChainFlags []string `yaml:"relay-chain-flags"`
+	// Values set in the parachain's chain spec before genesis, keyed by dot-separated path,
+	// e.g. "genesis.runtime.parachainInfo.parachainId".
+	GenesisOverrides map[string]any `yaml:"genesis-overrides"`
+}
+
+// Clone returns a deep copy of p, except for the values of GenesisOverrides.
+func (p ParachainConfig) Clone() ParachainConfig {
+	x := p
+	x.Image = p.Image.clone()
+	x.Flags = append([]string(nil), p.Flags...)
+	x.RelayChainFlags = append([]string(nil), p.RelayChainFlags...)
+	if p.GenesisOverrides != nil {
+		x.GenesisOverrides = make(map[string]any, len(p.GenesisOverrides))
+		for k, v := range p.GenesisOverrides {
+			x.GenesisOverrides[k] = v
+		}
+	}
+	return x
+}
+
+// EVMCoinType is the BIP-44 coin type of Ethereum, used by Ethermint-style chains.
+const EVMCoinType = "60"
+
+// EVMConfig describes the EVM of an Ethermint-style cosmos chain.
+type EVMConfig struct {
+	// Key algorithm passed to the --algo flag of the keys commands. Defaults to eth_secp256k1.
+	KeyAlgorithm string `yaml:"key-algorithm"`
+	// JSON-RPC namespaces to enable. Defaults to eth, net, web3, txpool, and debug.
+	APINamespaces []string `yaml:"api-namespaces"`
+}
+
+// Clone returns a deep copy of c.
+func (c EVMConfig) Clone() EVMConfig {
+	c.APINamespaces = append([]string(nil), c.APINamespaces...)
+	return c
+}
+
+// Algo returns the key algorithm, defaulting to eth_secp256k1.
+func (c EVMConfig) Algo() string {
+	if c.KeyAlgorithm == "" {
+		return "eth_secp256k1"
+	}
+	return c.KeyAlgorithm
+}
+
+// Namespaces returns the JSON-RPC namespaces to enable, with defaults applied.
+func (c EVMConfig) Namespaces() []string {
+	if len(c.APINamespaces) == 0 {
+		return []string{"eth", "net", "web3", "txpool", "debug"}
+	}
+	return c.APINamespaces
+}
+
+// ResourceLimits describes the resources a container is allowed to consume.
+// Zero values leave the corresponding limit unset.
+type ResourceLimits struct {
+	// Number of CPUs the container may use, e.g. 1.5.
+	CPUs float64 `yaml:"cpus"`
+	// Memory limit in bytes.
+	Memory int64 `yaml:"memory"`
+	// Maximum number of processes inside the container.
+	PidsLimit int64 `yaml:"pids-limit"`
 }
 
 type DockerImage struct {
 	Repository string `yaml:"repository"`
 	Version    string `yaml:"version"`
 	UidGid     string `yaml:"uid-gid"`
+
+	// If set, the image is built from local sources and tagged as Ref(), instead of being pulled.
+	Build *DockerBuild `yaml:"build"`
+}
+
+// DockerBuild describes how to build an image from a local source tree.
+type DockerBuild struct {
+	// Context is the directory sent to the docker daemon as the build context.
+	Context string `yaml:"context"`
+
+	// Dockerfile is the path of the Dockerfile, relative to Context. Defaults to "Dockerfile".
+	Dockerfile string `yaml:"dockerfile"`
+
+	// Target is the build stage to build, for multi-stage Dockerfiles. Defaults to the final stage.
+	Target string `yaml:"target"`
+
+	// Args are the build-time variables, i.e. ARG values.
+	Args map[string]string `yaml:"args"`
+}
+
+func (i DockerImage) clone() DockerImage {
+	if i.Build != nil {
+		b := *i.Build
+		b.Args = make(map[string]string, len(i.Build.Args))
+		for k, v := range i.Build.Args {
+			b.Args[k] = v
+		}
+		i.Build = &b
+	}
+	return i
 }
 
 // Ref returns the reference to use when e.g. creating a container.
+//
+// An image built from source without a Repository is named after its build context directory.
 func (i DockerImage) Ref() string {
+	repo := i.Repository
+	if repo == "" && i.Build != nil {
+		repo = "interchaintest-local/" + strings.ToLower(filepath.Base(filepath.Clean(i.Build.Context)))
+	}
+
 	if i.Version == "" {
-		return i.Repository + ":latest"
+		return repo + ":latest"
 	}
 
-	return i.Repository + ":" + i.Version
+	return repo + ":" + i.Version
+}
+
+// RepositoryAndTag returns the repository and tag of Ref(), for APIs that take them separately.
+// Unlike the Repository field, the repository is never empty for an image built from source.
+func (i DockerImage) RepositoryAndTag() (repository, tag string) {
+	ref := i.Ref()
+	sep := strings.LastIndex(ref, ":")
+	return ref[:sep], ref[sep+1:]
 }
 
 type WalletAmount struct {
@@ -8,6 +8,7 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -42,6 +43,9 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Set during Build to stop reporting the commands run by the chains; called in the Close method.
+	unregisterExecs func()
 }
 
 type interchainLink struct {
@@ -98,8 +102,19 @@ type relayerPath struct {
 // If the given chain already exists,
 // or if another chain with the same configured chain ID exists, AddChain panics.
 func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.WalletAmount) *Interchain {
+	if err := ic.checkNewChain(chain); err != nil {
+		panic(err)
+	}
+
+	ic.addChain(chain, additionalGenesisWallets)
+	return ic
+}
+
+// checkNewChain returns an error if chain is nil, was already added,
+// or shares its chain ID or name with a chain already added.
+func (ic *Interchain) checkNewChain(chain ibc.Chain) error {
 	if chain == nil {
-		panic(fmt.Errorf("cannot add nil chain"))
+		return fmt.Errorf("cannot add nil chain")
 	}
 
 	newID := chain.Config().ChainID
@@ -107,28 +122,30 @@ func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.
 
 	for c, id := range ic.chains {
 		if c == chain {
-			panic(fmt.Errorf("chain %v was already added", c))
+			return fmt.Errorf("chain %v was already added", c)
 		}
 		if id == newID {
-			panic(fmt.Errorf("a chain with ID %s already exists", id))
+			return fmt.Errorf("a chain with ID %s already exists", id)
 		}
 		if c.Config().Name == newName {
-			panic(fmt.Errorf("a chain with name %s already exists", newName))
+			return fmt.Errorf("a chain with name %s already exists", newName)
 		}
 	}
 
-	ic.chains[chain] = newID
+	return nil
+}
+
+func (ic *Interchain) addChain(chain ibc.Chain, additionalGenesisWallets []ibc.WalletAmount) {
+	ic.chains[chain] = chain.Config().ChainID
 
 	if len(additionalGenesisWallets) == 0 {
-		return ic
+		return
 	}
 
 	if ic.AdditionalGenesisWallets == nil {
 		ic.AdditionalGenesisWallets = make(map[ibc.Chain][]ibc.WalletAmount)
 	}
 	ic.AdditionalGenesisWallets[chain] = additionalGenesisWallets
-
-	return ic
 }
 
 // AddRelayer adds the given relayer with the given name to the Interchain.
@@ -285,6 +302,37 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// If set, images without a manifest for the docker host's platform do not fail the build.
+	// They are pulled for another platform and run under emulation, if the host supports it.
+	AllowEmulatedImages bool
+}
+
+// imageLister is implemented by chains that run images beyond those in their ChainConfig.
+type imageLister interface {
+	Images() []ibc.DockerImage
+}
+
+// images returns the images of every chain, sidecar, and relayer in the Interchain.
+func (ic *Interchain) images() []ibc.DockerImage {
+	var images []ibc.DockerImage
+	for c := range ic.chains {
+		cfg := c.Config()
+		if l, ok := c.(imageLister); ok {
+			images = append(images, l.Images()...)
+		} else {
+			images = append(images, cfg.Images...)
+		}
+		for _, sc := range cfg.SidecarConfigs {
+			images = append(images, sc.Image)
+		}
+	}
+	for r := range ic.relayers {
+		if dr, ok := r.(interface{ ContainerImage() ibc.DockerImage }); ok {
+			images = append(images, dr.ContainerImage())
+		}
+	}
+	return images
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
@@ -297,6 +345,12 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.built = true
 
+	// Report the commands that the chains run in containers, e.g. CLI transactions and queries,
+	// next to the relayer commands. They are associated with the name the chains are built with.
+	if rep != nil {
+		ic.unregisterExecs = dockerutil.RegisterExecReporter(opts.TestName, rep.ExecReporter())
+	}
+
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
 		chains = append(chains, chain)
@@ -312,6 +366,13 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		provider.Consumers = append(provider.Consumers, consumer)
 	}
 
+	// Fail fast, with every problem at once, rather than on the first container that cannot be created.
+	if err := dockerutil.EnsureImages(ctx, ic.log, opts.Client, ic.images(), dockerutil.ImagePreflightOptions{
+		AllowEmulation: opts.AllowEmulatedImages,
+	}); err != nil {
+		return fmt.Errorf("failed to prepare images: %w", err)
+	}
+
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
@@ -543,6 +604,9 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
+	if ic.unregisterExecs != nil {
+		ic.unregisterExecs()
+	}
 	return ic.cs.Close()
 }
 
@@ -616,28 +680,38 @@ func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporte
 
 	for r, chains := range ic.relayerChains() {
 		for _, c := range chains {
-			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
-			if !r.UseDockerNetwork() {
-				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+			if err := ic.configureRelayerKey(ctx, rep, r, c); err != nil {
+				return err
 			}
+		}
+	}
 
-			chainName := ic.chains[c]
-			if err := r.AddChainConfiguration(ctx,
-				rep,
-				c.Config(), chainName,
-				rpcAddr, grpcAddr,
-			); err != nil {
-				return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+	return nil
+}
 
-			if err := r.RestoreKey(ctx,
-				rep,
-				c.Config(), chainName,
-				ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
-			); err != nil {
-				return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
-		}
+// configureRelayerKey adds the chain configuration of c to r
+// and restores the relayer wallet of the relayer-chain.
+func (ic *Interchain) configureRelayerKey(ctx context.Context, rep ibc.RelayerExecReporter, r ibc.Relayer, c ibc.Chain) error {
+	rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+	if !r.UseDockerNetwork() {
+		rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+	}
+
+	chainName := ic.chains[c]
+	if err := r.AddChainConfiguration(ctx,
+		rep,
+		c.Config(), chainName,
+		rpcAddr, grpcAddr,
+	); err != nil {
+		return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+	}
+
+	if err := r.RestoreKey(ctx,
+		rep,
+		c.Config(), chainName,
+		ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
+	); err != nil {
+		return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
 	}
 
 	return nil
@@ -0,0 +1,168 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// AddChainAfterBuild adds the given chain to an Interchain that was already built,
+// and starts it as Build would have: the chain is initialized,
+// and starts with a faucet account and the additional genesis wallets.
+//
+// Unlike chains added before Build, the chain's blocks are not saved to the block database.
+// Consumer chains are not supported.
+func (ic *Interchain) AddChainAfterBuild(
+	ctx context.Context,
+	opts InterchainBuildOptions,
+	chain ibc.Chain,
+	additionalGenesisWallets ...ibc.WalletAmount,
+) error {
+	if !ic.built {
+		return fmt.Errorf("Interchain.AddChainAfterBuild called before Build")
+	}
+	if err := ic.checkNewChain(chain); err != nil {
+		return err
+	}
+
+	cfg := chain.Config()
+	var images []ibc.DockerImage
+	if l, ok := chain.(imageLister); ok {
+		images = append(images, l.Images()...)
+	} else {
+		images = append(images, cfg.Images...)
+	}
+	for _, sc := range cfg.SidecarConfigs {
+		images = append(images, sc.Image)
+	}
+	if err := dockerutil.EnsureImages(ctx, ic.log, opts.Client, images, dockerutil.ImagePreflightOptions{
+		AllowEmulation: opts.AllowEmulatedImages,
+	}); err != nil {
+		return fmt.Errorf("failed to prepare images: %w", err)
+	}
+
+	if err := chain.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+		return fmt.Errorf("failed to initialize chain %s: %w", cfg.Name, err)
+	}
+
+	faucet, err := chain.BuildWallet(ctx, FaucetAccountKeyName, "")
+	if err != nil {
+		return fmt.Errorf("failed to create faucet account on chain %s: %w", cfg.Name, err)
+	}
+
+	walletAmounts := append([]ibc.WalletAmount{
+		{
+			Address: faucet.FormattedAddress(),
+			Denom:   cfg.Denom,
+			Amount:  math.NewInt(100_000_000_000_000), // Faucet wallet gets 100T units of denom.
+		},
+	}, additionalGenesisWallets...)
+
+	if err := chain.Start(opts.TestName, ctx, walletAmounts...); err != nil {
+		return fmt.Errorf("failed to start chain %s: %w", cfg.Name, err)
+	}
+
+	ic.addChain(chain, additionalGenesisWallets)
+	ic.cs.chains[chain] = struct{}{}
+	return nil
+}
+
+// AddLinkAfterBuild creates the given link on an Interchain that was already built.
+// The link's relayer must have been added before Build.
+//
+// Chains that the relayer was not configured for get a new relayer wallet,
+// funded by the chain's faucet, and are added to the relayer's configuration.
+// The path is then generated and linked; it is the caller's responsibility
+// to (re)start the relayer on the new path.
+func (ic *Interchain) AddLinkAfterBuild(ctx context.Context, rep ibc.RelayerExecReporter, link InterchainLink) error {
+	if !ic.built {
+		return fmt.Errorf("Interchain.AddLinkAfterBuild called before Build")
+	}
+	for _, c := range []ibc.Chain{link.Chain1, link.Chain2} {
+		if _, exists := ic.chains[c]; !exists {
+			cfg := c.Config()
+			return fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID)
+		}
+	}
+	if _, exists := ic.relayers[link.Relayer]; !exists {
+		return fmt.Errorf("relayer %v was never added to Interchain", link.Relayer)
+	}
+	if link.Chain1 == link.Chain2 {
+		return fmt.Errorf("chains must be different (both were %v)", link.Chain1)
+	}
+
+	key := relayerPath{
+		Relayer: link.Relayer,
+		Path:    link.Path,
+	}
+	if _, exists := ic.links[key]; exists {
+		return fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path)
+	}
+
+	// If the user specifies zero value options then we fall back to the defaults, as in Build.
+	if link.CreateClientOpts == (ibc.CreateClientOptions{}) {
+		link.CreateClientOpts = ibc.DefaultClientOpts()
+	}
+	if err := link.CreateClientOpts.Validate(); err != nil {
+		return err
+	}
+	if link.CreateChannelOpts == (ibc.CreateChannelOptions{}) {
+		link.CreateChannelOpts = ibc.DefaultChannelOpts()
+	}
+	if err := link.CreateChannelOpts.Validate(); err != nil {
+		return err
+	}
+
+	for _, c := range []ibc.Chain{link.Chain1, link.Chain2} {
+		rc := relayerChain{R: link.Relayer, C: c}
+		if _, ok := ic.relayerWallets[rc]; ok {
+			continue
+		}
+
+		// Just an ephemeral unique name, only for the local use of the keyring.
+		accountName := ic.relayers[link.Relayer] + "-" + ic.chains[c]
+		wallet, err := c.BuildRelayerWallet(ctx, accountName)
+		if err != nil {
+			return err
+		}
+
+		if err := c.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+			Address: wallet.FormattedAddress(),
+			Denom:   c.Config().Denom,
+			Amount:  math.NewInt(1_000_000_000_000), // Every wallet gets 1t units of denom.
+		}); err != nil {
+			return fmt.Errorf("failed to fund relayer wallet on chain %s: %w", ic.chains[c], err)
+		}
+
+		ic.relayerWallets[rc] = wallet
+		if err := ic.configureRelayerKey(ctx, rep, link.Relayer, c); err != nil {
+			delete(ic.relayerWallets, rc)
+			return err
+		}
+	}
+
+	c0, c1 := link.Chain1, link.Chain2
+	if err := link.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, link.Path); err != nil {
+		return fmt.Errorf(
+			"failed to generate path %s on relayer %s between chains %s and %s: %w",
+			link.Path, link.Relayer, ic.chains[c0], ic.chains[c1], err,
+		)
+	}
+
+	if err := link.Relayer.LinkPath(ctx, rep, link.Path, link.CreateChannelOpts, link.CreateClientOpts); err != nil {
+		return fmt.Errorf(
+			"failed to link path %s on relayer %s between chains %s and %s: %w",
+			link.Path, link.Relayer, ic.chains[c0], ic.chains[c1], err,
+		)
+	}
+
+	ic.links[key] = interchainLink{
+		chains:            [2]ibc.Chain{c0, c1},
+		createChannelOpts: link.CreateChannelOpts,
+		createClientOpts:  link.CreateClientOpts,
+	}
+	return nil
+}
@@ -452,6 +452,31 @@ func TestInterchain_AddNil(t *testing.T) {
 	})
 }
 
+func TestInterchain_AfterBuildRequiresBuild(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	var r rly.CosmosRelayer
+	ic := interchaintest.NewInterchain().AddChain(chains[0]).AddRelayer(&r, "r")
+	ctx := context.Background()
+
+	err = ic.AddChainAfterBuild(ctx, interchaintest.InterchainBuildOptions{}, chains[1])
+	require.EqualError(t, err, "Interchain.AddChainAfterBuild called before Build")
+
+	err = ic.AddLinkAfterBuild(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainLink{
+		Chain1:  chains[0],
+		Chain2:  chains[1],
+		Relayer: &r,
+		Path:    "p",
+	})
+	require.EqualError(t, err, "Interchain.AddLinkAfterBuild called before Build")
+}
+
 func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotNil(t, resp)
 	require.NotEqual(t, 0, resp.GasUsed)
@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -30,21 +33,32 @@ func (txs transactions) Hash() []byte {
 // This method is idempotent and can be safely called multiple times with the same arguments.
 // The txs should be human-readable.
 func (chain *Chain) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
-	k := fmt.Sprintf("%d-%x", height, transactions(txs).Hash())
+	return chain.SaveBlockAt(ctx, height, time.Time{}, txs)
+}
+
+// SaveBlockAt is like SaveBlock, additionally saving the time in the block header, if not zero.
+// Packet latencies are computed from block times, or from the times blocks were saved if unknown.
+func (chain *Chain) SaveBlockAt(ctx context.Context, height uint64, blockTime time.Time, txs []Tx) error {
+	k := fmt.Sprintf("%d-%d-%x", height, blockTime.UnixNano(), transactions(txs).Hash())
 	_, err, _ := chain.single.Do(k, func() (any, error) {
-		return nil, chain.saveBlock(ctx, height, txs)
+		return nil, chain.saveBlock(ctx, height, blockTime, txs)
 	})
 	return err
 }
 
-func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactions) error {
+func (chain *Chain) saveBlock(ctx context.Context, height uint64, blockTime time.Time, txs transactions) error {
 	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dbTx.Rollback() }()
 
-	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at) VALUES (?, ?, ?)`, height, chain.id, nowRFC3339())
+	var bt sql.NullString
+	if !blockTime.IsZero() {
+		bt = nullString(blockTime.UTC().Format(blockTimeFormat))
+	}
+	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at, block_time) VALUES (?, ?, ?, ?)`,
+		height, chain.id, nowRFC3339(), bt)
 	if err != nil {
 		return fmt.Errorf("insert into block: %w", err)
 	}
@@ -54,7 +68,7 @@ func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactio
 		return err
 	}
 	for _, tx := range txs {
-		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id) VALUES (?, ?)`, string(tx.Data), blockID)
+		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, code, fk_block_id) VALUES (?, ?, ?)`, string(tx.Data), tx.Code, blockID)
 		if err != nil {
 			return fmt.Errorf("insert into tx: %w", err)
 		}
@@ -80,8 +94,130 @@ func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactio
 					return fmt.Errorf("insert into tendermint_event_attr: %w", err)
 				}
 			}
+
+			if err := savePacketEvent(ctx, dbTx, txID, e); err != nil {
+				return err
+			}
+		}
+
+		if tx.EVM != nil {
+			if err := chain.saveEVMTx(ctx, dbTx, txID, tx.EVM); err != nil {
+				return err
+			}
+		}
+	}
+
+	return dbTx.Commit()
+}
+
+func (chain *Chain) saveEVMTx(ctx context.Context, dbTx *sql.Tx, txID int64, tx *EVMTx) error {
+	res, err := dbTx.ExecContext(ctx, `INSERT INTO evm_tx(
+    hash, from_address, to_address, contract_address, value, gas_price, gas, gas_used, status, input, fk_tx_id
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		tx.Hash, tx.From, nullString(tx.To), nullString(tx.ContractAddress), tx.Value, tx.GasPrice,
+		tx.Gas, tx.GasUsed, tx.Status, tx.Input, txID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert into evm_tx: %w", err)
+	}
+	evmTxID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, l := range tx.Logs {
+		if len(l.Topics) > 4 {
+			return fmt.Errorf("log %d of evm tx %s has %d topics, at most 4 are allowed", l.Index, tx.Hash, len(l.Topics))
+		}
+		var topics [4]sql.NullString
+		for i, topic := range l.Topics {
+			topics[i] = nullString(topic)
+		}
+		_, err := dbTx.ExecContext(ctx, `INSERT INTO evm_log(
+    log_index, address, topic0, topic1, topic2, topic3, data, fk_evm_tx_id
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			l.Index, l.Address, topics[0], topics[1], topics[2], topics[3], l.Data, evmTxID,
+		)
+		if err != nil {
+			return fmt.Errorf("insert into evm_log: %w", err)
 		}
 	}
 
+	return chain.upsertEVMABIs(ctx, dbTx, tx.ABIs)
+}
+
+// SaveEVMABIs saves the JSON ABIs of contracts, keyed by address, replacing any previously saved ABI.
+func (chain *Chain) SaveEVMABIs(ctx context.Context, abis map[string]string) error {
+	dbTx, err := chain.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	if err := chain.upsertEVMABIs(ctx, dbTx, abis); err != nil {
+		return err
+	}
 	return dbTx.Commit()
 }
+
+func (chain *Chain) upsertEVMABIs(ctx context.Context, dbTx *sql.Tx, abis map[string]string) error {
+	for addr, abi := range abis {
+		_, err := dbTx.ExecContext(ctx, `INSERT INTO evm_abi(address, abi, fk_chain_id) VALUES (?, ?, ?)
+ON CONFLICT(address, fk_chain_id) DO UPDATE SET abi=excluded.abi`, strings.ToLower(addr), abi, chain.id)
+		if err != nil {
+			return fmt.Errorf("upsert evm_abi for %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// packetEventTypes are the IBC core events saved to ibc_packet_event.
+// TimeoutOnClose also emits timeout_packet.
+var packetEventTypes = map[string]bool{
+	"send_packet":           true,
+	"recv_packet":           true,
+	"write_acknowledgement": true,
+	"acknowledge_packet":    true,
+	"timeout_packet":        true,
+}
+
+// savePacketEvent indexes e if it is an IBC packet event, ignoring other events.
+func savePacketEvent(ctx context.Context, dbTx *sql.Tx, txID int64, e Event) error {
+	if !packetEventTypes[e.Type] {
+		return nil
+	}
+	attrs := make(map[string]string, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+	if err != nil {
+		// Not emitted by IBC core.
+		return nil
+	}
+
+	// Newer versions of ibc-go only emit the hex encoded data and ack.
+	data := attrs["packet_data"]
+	if data == "" {
+		data = attrs["packet_data_hex"]
+	}
+	ack := attrs["packet_ack"]
+	if ack == "" {
+		ack = attrs["packet_ack_hex"]
+	}
+
+	_, err = dbTx.ExecContext(ctx, `INSERT INTO ibc_packet_event(
+    type, sequence, src_port, src_channel, dst_port, dst_channel, data, ack, timeout_height, timeout_timestamp, fk_tx_id
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.Type, seq, attrs["packet_src_port"], attrs["packet_src_channel"], attrs["packet_dst_port"], attrs["packet_dst_channel"],
+		nullString(data), nullString(ack), nullString(attrs["packet_timeout_height"]), nullString(attrs["packet_timeout_timestamp"]), txID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert into ibc_packet_event: %w", err)
+	}
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
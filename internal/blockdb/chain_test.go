@@ -183,3 +183,20 @@ ORDER BY tendermint_event_attr.id`)
 		require.Zero(t, count)
 	})
 }
+
+func TestChain_SaveEVMABIs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+
+	require.NoError(t, chain.SaveEVMABIs(ctx, map[string]string{"0xABC": `[{"type":"fallback"}]`}))
+	require.NoError(t, chain.SaveEVMABIs(ctx, map[string]string{"0xabc": `[]`, "0xdef": `[]`}))
+
+	got, err := NewQuery(db).EVMABIs(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, []EVMABIResult{{Address: "0xabc", ABI: `[]`}, {Address: "0xdef", ABI: `[]`}}, got)
+}
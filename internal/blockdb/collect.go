@@ -14,8 +14,51 @@ type Tx struct {
 	// Otherwise, this should be a human-readable format if possible.
 	Data []byte
 
+	// Code is non-zero if the transaction failed: the ABCI result code for Tendermint transactions,
+	// 1 for reverted EVM transactions.
+	Code uint32
+
 	// Events associated with the transaction, if applicable.
 	Events []Event
+
+	// EVM is the transaction and its receipt, for EVM chains.
+	EVM *EVMTx
+}
+
+// EVMTx is an EVM transaction with its receipt,
+// so that the blockdb package does not depend directly on go-ethereum.
+// Addresses, hashes and byte fields are 0x prefixed hex strings.
+type EVMTx struct {
+	Hash string
+	From string
+	// To is empty for a contract creation.
+	To string
+	// ContractAddress is the address of the contract created by the transaction, if any.
+	ContractAddress string
+	// Value and GasPrice are decimal amounts of wei, which may not fit in an int64.
+	Value    string
+	GasPrice string
+	Gas      uint64
+	GasUsed  uint64
+	// Status is 1 for success, 0 if the transaction reverted.
+	Status uint64
+	// Input is the calldata, or the contract creation code.
+	Input string
+
+	Logs []EVMLog
+
+	// ABIs of the contracts the transaction or its logs refer to, if registered with the chain.
+	// Keyed by contract address, the values are JSON ABIs.
+	ABIs map[string]string
+}
+
+// EVMLog is a log emitted by an EVM transaction.
+type EVMLog struct {
+	Index   uint64
+	Address string
+	// Topics has at most 4 topics, the first being the event signature hash for non-anonymous events.
+	Topics []string
+	Data   string
 }
 
 // Event is an alternative representation of tendermint/abci/types.Event,
@@ -44,6 +87,35 @@ type BlockSaver interface {
 	SaveBlock(ctx context.Context, height uint64, txs []Tx) error
 }
 
+// Block is a block's transactions with the time in its header.
+type Block struct {
+	Time time.Time
+	Txs  []Tx
+}
+
+// BlockFinder is optionally implemented by a TxFinder that also finds the time in the block header,
+// from which packet latencies are computed.
+type BlockFinder interface {
+	FindBlock(ctx context.Context, height uint64) (Block, error)
+}
+
+// TimedBlockSaver is optionally implemented by a BlockSaver to save the block times of a BlockFinder.
+type TimedBlockSaver interface {
+	SaveBlockAt(ctx context.Context, height uint64, blockTime time.Time, txs []Tx) error
+}
+
+// EVMABIFinder is optionally implemented by the TxFinder of an EVM chain,
+// whose contract ABIs are registered independently of its transactions.
+type EVMABIFinder interface {
+	// EVMABIs returns the registered JSON ABIs, keyed by contract address.
+	EVMABIs() map[string]string
+}
+
+// EVMABISaver is optionally implemented by a BlockSaver to save the ABIs of an EVMABIFinder.
+type EVMABISaver interface {
+	SaveEVMABIs(ctx context.Context, abis map[string]string) error
+}
+
 // Collector saves block transactions at regular intervals.
 type Collector struct {
 	finder TxFinder
@@ -51,6 +123,9 @@ type Collector struct {
 	rate   time.Duration
 	saver  BlockSaver
 	cancel context.CancelFunc
+
+	// ABIs already saved, so that only newly registered or changed ABIs are saved with each block.
+	savedABIs map[string]string
 }
 
 // NewCollector creates a valid Collector that polls every duration at rate.
@@ -103,13 +178,65 @@ func (p *Collector) Stop() {
 }
 
 func (p *Collector) saveTxsForHeight(ctx context.Context, height uint64) error {
-	txs, err := p.finder.FindTxs(ctx, height)
-	if err != nil {
-		return fmt.Errorf("find txs: %w", err)
+	var block Block
+	if finder, ok := p.finder.(BlockFinder); ok {
+		var err error
+		if block, err = finder.FindBlock(ctx, height); err != nil {
+			return fmt.Errorf("find block: %w", err)
+		}
+	} else {
+		txs, err := p.finder.FindTxs(ctx, height)
+		if err != nil {
+			return fmt.Errorf("find txs: %w", err)
+		}
+		block.Txs = txs
+	}
+
+	var err error
+	if saver, ok := p.saver.(TimedBlockSaver); ok && !block.Time.IsZero() {
+		err = saver.SaveBlockAt(ctx, height, block.Time, block.Txs)
+	} else {
+		err = p.saver.SaveBlock(ctx, height, block.Txs)
 	}
-	err = p.saver.SaveBlock(ctx, height, txs)
 	if err != nil {
 		return fmt.Errorf("save block: %w", err)
 	}
+	// Failing to save the ABIs does not fail the block, which is already saved; they are retried with the next block.
+	if err := p.saveABIs(ctx); err != nil {
+		p.log.Info("Failed to save EVM ABIs", zap.Error(err), zap.Uint64("height", height))
+	}
+	return nil
+}
+
+// saveABIs saves the ABIs registered with the finder since the last call, if both the finder and the saver support it.
+func (p *Collector) saveABIs(ctx context.Context) error {
+	finder, ok := p.finder.(EVMABIFinder)
+	if !ok {
+		return nil
+	}
+	saver, ok := p.saver.(EVMABISaver)
+	if !ok {
+		return nil
+	}
+
+	pending := make(map[string]string)
+	for addr, abi := range finder.EVMABIs() {
+		if p.savedABIs[addr] != abi {
+			pending[addr] = abi
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if err := saver.SaveEVMABIs(ctx, pending); err != nil {
+		return err
+	}
+
+	if p.savedABIs == nil {
+		p.savedABIs = make(map[string]string, len(pending))
+	}
+	for addr, abi := range pending {
+		p.savedABIs[addr] = abi
+	}
 	return nil
 }
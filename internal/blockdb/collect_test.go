@@ -130,6 +130,97 @@ func TestCollector_Collect(t *testing.T) {
 	})
 }
 
+type mockBlockFinder struct {
+	mockTxFinder
+	time time.Time
+}
+
+func (f mockBlockFinder) FindBlock(ctx context.Context, height uint64) (Block, error) {
+	txs, err := f.FindTxs(ctx, height)
+	return Block{Time: f.time, Txs: txs}, err
+}
+
+type mockTimedBlockSaver struct {
+	mockBlockSaver
+	times []time.Time
+}
+
+func (s *mockTimedBlockSaver) SaveBlockAt(ctx context.Context, height uint64, blockTime time.Time, txs []Tx) error {
+	s.times = append(s.times, blockTime)
+	return s.SaveBlock(ctx, height, txs)
+}
+
+func TestCollector_SaveBlockTime(t *testing.T) {
+	ctx := context.Background()
+	blockTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	var saved [][]Tx
+	finder := mockBlockFinder{
+		mockTxFinder: func(ctx context.Context, height uint64) ([]Tx, error) {
+			return []Tx{{Data: []byte(strconv.FormatUint(height, 10))}}, nil
+		},
+		time: blockTime,
+	}
+	saver := &mockTimedBlockSaver{mockBlockSaver: func(ctx context.Context, height uint64, txs []Tx) error {
+		saved = append(saved, txs)
+		return nil
+	}}
+
+	require.NoError(t, NewCollector(zap.NewNop(), finder, saver, time.Millisecond).saveTxsForHeight(ctx, 1))
+	require.Equal(t, []time.Time{blockTime}, saver.times)
+	require.Equal(t, [][]Tx{{{Data: []byte("1")}}}, saved)
+
+	// Without a block time, the block is saved as by a plain TxFinder.
+	finder.time = time.Time{}
+	require.NoError(t, NewCollector(zap.NewNop(), finder, saver, time.Millisecond).saveTxsForHeight(ctx, 2))
+	require.Len(t, saver.times, 1)
+	require.Len(t, saved, 2)
+}
+
+type mockABIFinder struct {
+	mockTxFinder
+	abis map[string]string
+}
+
+func (f mockABIFinder) EVMABIs() map[string]string {
+	abis := make(map[string]string, len(f.abis))
+	for addr, abi := range f.abis {
+		abis[addr] = abi
+	}
+	return abis
+}
+
+type mockABISaver struct {
+	mockBlockSaver
+	saved []map[string]string
+}
+
+func (s *mockABISaver) SaveEVMABIs(ctx context.Context, abis map[string]string) error {
+	s.saved = append(s.saved, abis)
+	return nil
+}
+
+func TestCollector_SaveABIs(t *testing.T) {
+	ctx := context.Background()
+
+	finder := mockABIFinder{
+		mockTxFinder: func(ctx context.Context, height uint64) ([]Tx, error) { return nil, nil },
+		abis:         map[string]string{"0xa": `[]`},
+	}
+	saver := &mockABISaver{mockBlockSaver: func(ctx context.Context, height uint64, txs []Tx) error { return nil }}
+	c := NewCollector(zap.NewNop(), finder, saver, time.Millisecond)
+
+	// Registered ABIs are saved with the next block, even without transactions referring to them.
+	require.NoError(t, c.saveTxsForHeight(ctx, 1))
+	require.Equal(t, []map[string]string{{"0xa": `[]`}}, saver.saved)
+
+	// Only new or changed ABIs are saved with later blocks.
+	finder.abis["0xb"] = `[]`
+	require.NoError(t, c.saveTxsForHeight(ctx, 2))
+	require.NoError(t, c.saveTxsForHeight(ctx, 3))
+	require.Equal(t, []map[string]string{{"0xa": `[]`}, {"0xb": `[]`}}, saver.saved)
+}
+
 func TestCollector_Stop(t *testing.T) {
 	// Synchronization control to allow test to progress without a data race.
 	// Begins locked, unlocks from the finder, and the test blocks trying to re-lock it.
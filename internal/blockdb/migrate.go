@@ -21,6 +21,9 @@ import (
 //	│                    │          │                    │         │                    │          │                    │
 //	└────────────────────┘          └────────────────────┘         └────────────────────┘          └────────────────────┘
 //
+// EVM chains also save each tx's receipt to evm_tx, its logs to evm_log, and the registered contract ABIs to evm_abi.
+// IBC packet events of a tx are indexed in ibc_packet_event, linked across chains by the v_ibc_packets view.
+//
 // The gitSha ensures we can trace back to the version of the codebase that produced the schema.
 // Warning: Typical best practice wraps each migration step into its own transaction. For simplicity given
 // this is an embedded database, we omit transactions.
@@ -127,6 +130,18 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("alter table chain add chain_type: %w", err)
 	}
 
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN code INTEGER NOT NULL DEFAULT 0`)
+	if errIgnoreDuplicateColumn(err, "code") != nil {
+		return fmt.Errorf("alter table tx add code: %w", err)
+	}
+
+	// The time in the block header, unlike created_at, which is the time the block was saved.
+	// Null if the chain does not report it.
+	_, err = tx.Exec(`ALTER TABLE block ADD COLUMN block_time TEXT`)
+	if errIgnoreDuplicateColumn(err, "block_time") != nil {
+		return fmt.Errorf("alter table block add block_time: %w", err)
+	}
+
 	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS tendermint_event (
     id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
     type TEXT NOT NULL CHECK (length(type) > 0),
@@ -148,6 +163,76 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS evm_tx (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    hash TEXT NOT NULL CHECK (length(hash) > 0),
+    from_address TEXT NOT NULL CHECK (length(from_address) > 0),
+    to_address TEXT, -- NULL for contract creation
+    contract_address TEXT,
+    value TEXT NOT NULL, -- wei as a decimal string, since it may not fit in an INTEGER
+    gas_price TEXT NOT NULL,
+    gas INTEGER NOT NULL,
+    gas_used INTEGER NOT NULL,
+    status INTEGER NOT NULL, -- 1 success, 0 reverted
+    input TEXT NOT NULL,
+    fk_tx_id INTEGER UNIQUE,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table evm_tx: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS evm_log (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    log_index INTEGER NOT NULL,
+    address TEXT NOT NULL CHECK (length(address) > 0),
+    topic0 TEXT,
+    topic1 TEXT,
+    topic2 TEXT,
+    topic3 TEXT,
+    data TEXT NOT NULL,
+    fk_evm_tx_id INTEGER,
+    FOREIGN KEY(fk_evm_tx_id) REFERENCES evm_tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table evm_log: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS evm_abi (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    address TEXT NOT NULL CHECK (length(address) > 0), -- lowercase
+    abi TEXT NOT NULL CHECK (json_valid(abi)),
+    fk_chain_id INTEGER,
+    FOREIGN KEY(fk_chain_id) REFERENCES chain(id) ON DELETE CASCADE,
+    UNIQUE(address,fk_chain_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table evm_abi: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS ibc_packet_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (type IN ('send_packet', 'recv_packet', 'write_acknowledgement', 'acknowledge_packet', 'timeout_packet')),
+    sequence INTEGER NOT NULL,
+    src_port TEXT NOT NULL,
+    src_channel TEXT NOT NULL,
+    dst_port TEXT NOT NULL,
+    dst_channel TEXT NOT NULL,
+    data TEXT,
+    ack TEXT,
+    timeout_height TEXT,
+    timeout_timestamp TEXT,
+    fk_tx_id INTEGER,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table ibc_packet_event: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS ibc_packet_event_packet ON ibc_packet_event(src_port, src_channel, sequence)`)
+	if err != nil {
+		return fmt.Errorf("create index ibc_packet_event_packet: %w", err)
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
@@ -182,9 +267,11 @@ SELECT
   , chain.chain_type as chain_type
   , block.id as block_id
   , block.created_at as block_created_at
+  , block.block_time as block_time
   , block.height as block_height
   , tx.id as tx_id
   , tx.data as tx
+  , tx.code as tx_code -- non-zero if the tx failed
 FROM tx
 LEFT JOIN block ON tx.fk_block_id = block.id
 LEFT JOIN chain ON block.fk_chain_id = chain.id
@@ -209,6 +296,12 @@ SELECT
   , tx_id
   , key as msg_n -- message position within the tx
   , json_extract(value, "$.@type") as type
+  , COALESCE(
+      json_extract(value, "$.sender"),            -- MsgTransfer, MsgExecuteContract, ...
+      json_extract(value, "$.from_address"),      -- MsgSend
+      json_extract(value, "$.delegator_address"), -- staking and distribution messages
+      json_extract(value, "$.signer")             -- IBC core messages
+    ) as sender
   , json_extract(value, "$.client_state.chain_id") as client_chain_id
   , json_extract(value, "$.client_id") as client_id
   , json_extract(value, "$.counterparty.client_id") as counterparty_client_id
@@ -236,6 +329,7 @@ SELECT
       json_extract(value, "$.channel.counterparty.channel_id"), -- ChannelOpenTry
       json_extract(value, "$.packet.destination_channel")       -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
     ) as counterparty_channel_id
+  , tx_code
   , value as raw
 FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 `)
@@ -243,6 +337,177 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_cosmos_messages view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_evm_txs`)
+	if err != nil {
+		return fmt.Errorf("drop old v_evm_txs view: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIEW v_evm_txs AS
+SELECT
+  v_tx_flattened.test_case_id
+  , v_tx_flattened.test_case_name
+  , v_tx_flattened.chain_kid
+  , v_tx_flattened.chain_id
+  , v_tx_flattened.block_id
+  , v_tx_flattened.block_height
+  , v_tx_flattened.tx_id
+  , evm_tx.id as evm_tx_id
+  , evm_tx.hash
+  , evm_tx.from_address
+  , evm_tx.to_address
+  , evm_tx.contract_address
+  , evm_tx.value
+  , evm_tx.gas_price
+  , evm_tx.gas
+  , evm_tx.gas_used
+  , evm_tx.status
+  , evm_tx.input
+  , substr(evm_tx.input, 1, 10) as selector -- 0x and the 4 bytes identifying the called method
+  , (SELECT COUNT(*) FROM evm_log WHERE evm_log.fk_evm_tx_id = evm_tx.id) as log_count
+FROM evm_tx
+INNER JOIN v_tx_flattened ON evm_tx.fk_tx_id = v_tx_flattened.tx_id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_evm_txs view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_evm_logs`)
+	if err != nil {
+		return fmt.Errorf("drop old v_evm_logs view: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIEW v_evm_logs AS
+SELECT
+  v_evm_txs.test_case_id
+  , v_evm_txs.test_case_name
+  , v_evm_txs.chain_kid
+  , v_evm_txs.chain_id
+  , v_evm_txs.block_height
+  , v_evm_txs.evm_tx_id
+  , v_evm_txs.hash as tx_hash
+  , evm_log.id as evm_log_id
+  , evm_log.log_index
+  , evm_log.address
+  , evm_log.topic0 -- event signature hash, unless the event is anonymous
+  , evm_log.topic1
+  , evm_log.topic2
+  , evm_log.topic3
+  , evm_log.data
+FROM evm_log
+INNER JOIN v_evm_txs ON evm_log.fk_evm_tx_id = v_evm_txs.evm_tx_id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_evm_logs view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packet_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packet_events view: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packet_events AS
+SELECT
+  v_tx_flattened.test_case_id
+  , v_tx_flattened.test_case_name
+  , v_tx_flattened.chain_kid
+  , v_tx_flattened.chain_id
+  , v_tx_flattened.block_height
+  , v_tx_flattened.block_created_at
+  , v_tx_flattened.block_time
+  , v_tx_flattened.tx_id
+  , ibc_packet_event.id as packet_event_id
+  , ibc_packet_event.type
+  , ibc_packet_event.sequence
+  , ibc_packet_event.src_port
+  , ibc_packet_event.src_channel
+  , ibc_packet_event.dst_port
+  , ibc_packet_event.dst_channel
+  , ibc_packet_event.data
+  , ibc_packet_event.ack
+  , ibc_packet_event.timeout_height
+  , ibc_packet_event.timeout_timestamp
+FROM ibc_packet_event
+INNER JOIN v_tx_flattened ON ibc_packet_event.fk_tx_id = v_tx_flattened.tx_id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packet_events view: %w", err)
+	}
+
+	// A packet is identified by its source port, channel and sequence. Channel identifiers are only unique per chain,
+	// so the receiving events must be on another chain than the send_packet, and the acknowledging events on the same.
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packets`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packets view: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packets AS
+WITH lifecycle AS (
+  SELECT
+    send.*
+    , (SELECT MIN(packet_event_id) FROM v_ibc_packet_events e
+       WHERE e.type = 'recv_packet' AND e.test_case_id = send.test_case_id AND e.chain_kid != send.chain_kid
+         AND e.src_port = send.src_port AND e.src_channel = send.src_channel
+         AND e.dst_port = send.dst_port AND e.dst_channel = send.dst_channel AND e.sequence = send.sequence
+      ) as recv_event_id
+    , (SELECT MIN(packet_event_id) FROM v_ibc_packet_events e
+       WHERE e.type = 'write_acknowledgement' AND e.test_case_id = send.test_case_id AND e.chain_kid != send.chain_kid
+         AND e.src_port = send.src_port AND e.src_channel = send.src_channel
+         AND e.dst_port = send.dst_port AND e.dst_channel = send.dst_channel AND e.sequence = send.sequence
+      ) as write_ack_event_id
+    , (SELECT MIN(packet_event_id) FROM v_ibc_packet_events e
+       WHERE e.type = 'acknowledge_packet' AND e.chain_kid = send.chain_kid
+         AND e.src_port = send.src_port AND e.src_channel = send.src_channel AND e.sequence = send.sequence
+      ) as ack_event_id
+    , (SELECT MIN(packet_event_id) FROM v_ibc_packet_events e
+       WHERE e.type = 'timeout_packet' AND e.chain_kid = send.chain_kid
+         AND e.src_port = send.src_port AND e.src_channel = send.src_channel AND e.sequence = send.sequence
+      ) as timeout_event_id
+  FROM v_ibc_packet_events send
+  WHERE send.type = 'send_packet'
+)
+SELECT
+  lifecycle.test_case_id
+  , lifecycle.test_case_name
+  , lifecycle.sequence
+  , lifecycle.src_port
+  , lifecycle.src_channel
+  , lifecycle.dst_port
+  , lifecycle.dst_channel
+  , lifecycle.chain_kid as src_chain_kid
+  , lifecycle.chain_id as src_chain_id
+  , recv.chain_kid as dst_chain_kid
+  , recv.chain_id as dst_chain_id
+  , CASE
+      WHEN ack.packet_event_id IS NOT NULL THEN 'acknowledged'
+      WHEN timeout.packet_event_id IS NOT NULL THEN 'timed out'
+      WHEN recv.packet_event_id IS NOT NULL THEN 'received'
+      ELSE 'sent'
+    END as status
+  , lifecycle.block_height as send_height
+  , lifecycle.block_created_at as send_created_at
+  , recv.block_height as recv_height
+  , recv.block_created_at as recv_created_at
+  , write_ack.block_height as write_ack_height
+  , ack.block_height as ack_height
+  , ack.block_created_at as ack_created_at
+  , timeout.block_height as timeout_height
+  , timeout.block_created_at as timeout_created_at
+  -- Latencies are in milliseconds, between the times in the block headers.
+  -- The times the blocks were saved are only used for chains that do not report block times.
+  , CAST(round((julianday(COALESCE(recv.block_time, recv.block_created_at))
+      - julianday(COALESCE(lifecycle.block_time, lifecycle.block_created_at))) * 86400000) AS INTEGER) as recv_latency_ms
+  , CAST(round((julianday(COALESCE(ack.block_time, ack.block_created_at, timeout.block_time, timeout.block_created_at))
+      - julianday(COALESCE(lifecycle.block_time, lifecycle.block_created_at))) * 86400000) AS INTEGER) as complete_latency_ms
+  , lifecycle.data
+  , write_ack.ack
+  , lifecycle.timeout_height as packet_timeout_height
+  , lifecycle.timeout_timestamp as packet_timeout_timestamp
+FROM lifecycle
+LEFT JOIN v_ibc_packet_events recv ON recv.packet_event_id = lifecycle.recv_event_id
+LEFT JOIN v_ibc_packet_events write_ack ON write_ack.packet_event_id = lifecycle.write_ack_event_id
+LEFT JOIN v_ibc_packet_events ack ON ack.packet_event_id = lifecycle.ack_event_id
+LEFT JOIN v_ibc_packet_events timeout ON timeout.packet_event_id = lifecycle.timeout_event_id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packets view: %w", err)
+	}
+
 	_, err = tx.Exec(`DROP VIEW IF EXISTS v_tx_agg`)
 	if err != nil {
 		return fmt.Errorf("drop old v_tx_agg view: %w", err)
@@ -3,8 +3,11 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Query is a service that queries the database.
@@ -106,6 +109,11 @@ type CosmosMessageResult struct {
 	Index  int
 	Type   string // URI for proto definition, e.g. /ibc.core.client.v1.MsgCreateClient
 
+	// Sender is the sender, from_address, delegator_address or signer of the message.
+	Sender sql.NullString
+	TxCode int64  // Non-zero if the message's tx failed.
+	Raw    string // The message as JSON.
+
 	ClientChainID sql.NullString
 
 	ClientID             sql.NullString
@@ -138,6 +146,9 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
         , counterparty_port_id
         , channel_id
         , counterparty_channel_id
+        , sender
+        , tx_code
+        , raw
     FROM v_cosmos_messages
     WHERE chain_kid = ?
     ORDER BY block_height ASC , msg_n ASC`, chainPkey)
@@ -161,6 +172,9 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 			&res.CounterpartyPortID,
 			&res.ChannelID,
 			&res.CounterpartyChannelID,
+			&res.Sender,
+			&res.TxCode,
+			&res.Raw,
 		); err != nil {
 			return nil, err
 		}
@@ -170,14 +184,16 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 }
 
 type TxResult struct {
+	ID     int64 // tx primary key
 	Height int64
 	Tx     []byte
+	Code   int64 // Non-zero if the tx failed.
 }
 
 // Transactions returns TxResults only for blocks with transactions present.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
+	rows, err := q.db.QueryContext(ctx, `SELECT tx.id, block.height, tx.data, tx.code FROM tx 
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
     WHERE chain.id = ?
@@ -190,7 +206,7 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 	var results []TxResult
 	for rows.Next() {
 		var res TxResult
-		if err := rows.Scan(&res.Height, &res.Tx); err != nil {
+		if err := rows.Scan(&res.ID, &res.Height, &res.Tx, &res.Code); err != nil {
 			return nil, err
 		}
 		results = append(results, res)
@@ -198,3 +214,401 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 
 	return results, nil
 }
+
+// TestCaseID returns the primary key of the most recent test case named name.
+func (q *Query) TestCaseID(ctx context.Context, name string) (int64, error) {
+	var id int64
+	err := q.db.QueryRowContext(ctx, `SELECT id FROM test_case WHERE name = ? ORDER BY id DESC LIMIT 1`, name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("test case %q not found", name)
+	}
+	return id, err
+}
+
+// ChainPkey returns the chain primary key "chain.id" of the chainID in the test case.
+func (q *Query) ChainPkey(ctx context.Context, testCaseID int64, chainID string) (int64, error) {
+	var id int64
+	err := q.db.QueryRowContext(ctx, `SELECT id FROM chain WHERE fk_test_id = ? AND chain_id = ?`, testCaseID, chainID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("chain %q not found in test case", chainID)
+	}
+	return id, err
+}
+
+type EventResult struct {
+	TxID       int64 // tx primary key
+	Type       string
+	Attributes []EventAttribute
+}
+
+// TxEvents returns the events of the transactions of the chain, ordered by tx.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) TxEvents(ctx context.Context, chainPkey int64) ([]EventResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        tendermint_event.id, tx.id, tendermint_event.type, tendermint_event_attr.key, tendermint_event_attr.value
+    FROM tendermint_event
+    INNER JOIN tx ON tendermint_event.fk_tx_id = tx.id
+    INNER JOIN block ON tx.fk_block_id = block.id
+    LEFT JOIN tendermint_event_attr ON tendermint_event_attr.fk_event_id = tendermint_event.id
+    WHERE block.fk_chain_id = ?
+    ORDER BY block.height ASC, tx.id ASC, tendermint_event.id ASC, tendermint_event_attr.id ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		results []EventResult
+		lastID  int64 = -1
+	)
+	for rows.Next() {
+		var (
+			eventID, txID int64
+			eventType     string
+			key, value    sql.NullString
+		)
+		if err := rows.Scan(&eventID, &txID, &eventType, &key, &value); err != nil {
+			return nil, err
+		}
+		if eventID != lastID {
+			results = append(results, EventResult{TxID: txID, Type: eventType})
+			lastID = eventID
+		}
+		if key.Valid {
+			last := &results[len(results)-1]
+			last.Attributes = append(last.Attributes, EventAttribute{Key: key.String, Value: value.String})
+		}
+	}
+	return results, rows.Err()
+}
+
+// SQLResult is the result of a free-form query.
+type SQLResult struct {
+	Columns []string
+	// Rows have a value per column: nil, int64, float64, string or []byte.
+	Rows [][]any
+}
+
+// SQL runs a free-form read-only query, such as a SELECT on the v_tx_flattened or v_cosmos_messages views.
+// Only a single SELECT, WITH, EXPLAIN or VALUES statement is accepted,
+// and it runs with the query_only pragma set, so it cannot change the database.
+func (q *Query) SQL(ctx context.Context, query string, args ...any) (SQLResult, error) {
+	var res SQLResult
+
+	if err := checkReadOnlyStatement(query); err != nil {
+		return res, err
+	}
+
+	conn, err := q.db.Conn(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA query_only = ON`); err != nil {
+		return res, fmt.Errorf("set query_only: %w", err)
+	}
+	// The connection returns to the pool, where it may be used for writes.
+	defer func() { _, _ = conn.ExecContext(context.Background(), `PRAGMA query_only = OFF`) }()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	res.Columns, err = rows.Columns()
+	if err != nil {
+		return res, err
+	}
+	for rows.Next() {
+		row := make([]any, len(res.Columns))
+		ptrs := make([]any, len(row))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return res, err
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	return res, rows.Err()
+}
+
+// readOnlyKeywords are the keywords that read-only statements start with.
+var readOnlyKeywords = map[string]bool{
+	"SELECT":  true,
+	"WITH":    true,
+	"EXPLAIN": true,
+	"VALUES":  true,
+}
+
+// checkReadOnlyStatement returns an error unless query is a single statement
+// starting with one of the readOnlyKeywords.
+// The driver runs every statement of a query, so "SELECT 1; DELETE FROM tx" must be rejected.
+func checkReadOnlyStatement(query string) error {
+	i, err := skipSQLSpace(query, 0)
+	if err != nil {
+		return err
+	}
+	start := i
+	for i < len(query) && (query[i] == '_' || unicode.IsLetter(rune(query[i]))) {
+		i++
+	}
+	if keyword := strings.ToUpper(query[start:i]); !readOnlyKeywords[keyword] {
+		return fmt.Errorf("only SELECT, WITH, EXPLAIN and VALUES statements are allowed, got %q", keyword)
+	}
+
+	for i < len(query) {
+		if i, err = skipSQLSpace(query, i); err != nil {
+			return err
+		}
+		if i == len(query) {
+			break
+		}
+
+		switch ch := query[i]; ch {
+		case ';':
+			// Only empty statements may follow.
+			for i < len(query) && query[i] == ';' {
+				if i, err = skipSQLSpace(query, i+1); err != nil {
+					return err
+				}
+			}
+			if i < len(query) {
+				return errors.New("only a single statement is allowed")
+			}
+		case '\'', '"', '`', '[':
+			closing := ch
+			if ch == '[' {
+				closing = ']'
+			}
+			// A doubled quote escapes the quote; it is scanned as two adjacent strings.
+			end := strings.IndexByte(query[i+1:], closing)
+			if end < 0 {
+				return errors.New("unterminated quoted string or identifier")
+			}
+			i += end + 2
+		default:
+			i++
+		}
+	}
+	return nil
+}
+
+// skipSQLSpace returns the index of the first character of query at or after i
+// that is neither whitespace nor part of a comment.
+func skipSQLSpace(query string, i int) (int, error) {
+	for i < len(query) {
+		switch {
+		case unicode.IsSpace(rune(query[i])):
+			i++
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return len(query), nil
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return 0, errors.New("unterminated comment")
+			}
+			i += end + 4
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+type EVMLogResult struct {
+	Index   int64
+	Address string
+	Topics  []string
+	Data    string
+}
+
+type EVMTxResult struct {
+	Height          int64
+	Hash            string
+	From            string
+	To              sql.NullString // Null for contract creation.
+	ContractAddress sql.NullString
+	Value           string // Wei as a decimal string.
+	GasPrice        string
+	Gas             int64
+	GasUsed         int64
+	Status          int64 // 1 for success, 0 if reverted.
+	Input           string
+	Logs            []EVMLogResult
+}
+
+// EVMTransactions returns the EVM transactions of the chain, with their logs.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) EVMTransactions(ctx context.Context, chainPkey int64) ([]EVMTxResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        evm_tx_id, block_height, hash, from_address, to_address, contract_address,
+        value, gas_price, gas, gas_used, status, input
+    FROM v_evm_txs
+    WHERE chain_kid = ?
+    ORDER BY block_height ASC, tx_id ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []EVMTxResult
+	byID := make(map[int64]int)
+	for rows.Next() {
+		var (
+			id  int64
+			res EVMTxResult
+		)
+		if err := rows.Scan(
+			&id,
+			&res.Height,
+			&res.Hash,
+			&res.From,
+			&res.To,
+			&res.ContractAddress,
+			&res.Value,
+			&res.GasPrice,
+			&res.Gas,
+			&res.GasUsed,
+			&res.Status,
+			&res.Input,
+		); err != nil {
+			return nil, err
+		}
+		byID[id] = len(results)
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	logs, err := q.db.QueryContext(ctx, `SELECT
+        evm_tx_id, log_index, address, topic0, topic1, topic2, topic3, data
+    FROM v_evm_logs
+    WHERE chain_kid = ?
+    ORDER BY evm_tx_id ASC, log_index ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer logs.Close()
+	for logs.Next() {
+		var (
+			id     int64
+			res    EVMLogResult
+			topics [4]sql.NullString
+		)
+		if err := logs.Scan(&id, &res.Index, &res.Address, &topics[0], &topics[1], &topics[2], &topics[3], &res.Data); err != nil {
+			return nil, err
+		}
+		for _, topic := range topics {
+			if topic.Valid {
+				res.Topics = append(res.Topics, topic.String)
+			}
+		}
+		i, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("evm log %d of unknown evm tx %d", res.Index, id)
+		}
+		results[i].Logs = append(results[i].Logs, res)
+	}
+
+	return results, logs.Err()
+}
+
+type EVMABIResult struct {
+	Address string // Lowercase.
+	ABI     string // JSON ABI.
+}
+
+// EVMABIs returns the contract ABIs registered for the chain.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) EVMABIs(ctx context.Context, chainPkey int64) ([]EVMABIResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT address, abi FROM evm_abi WHERE fk_chain_id = ? ORDER BY address ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []EVMABIResult
+	for rows.Next() {
+		var res EVMABIResult
+		if err := rows.Scan(&res.Address, &res.ABI); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+type PacketResult struct {
+	Sequence   int64
+	SrcChainID string
+	SrcPort    string
+	SrcChannel string
+	DstChainID sql.NullString // Null until the packet is received.
+	DstPort    string
+	DstChannel string
+	Status     string // One of sent, received, acknowledged or timed out.
+
+	SendHeight     int64
+	RecvHeight     sql.NullInt64
+	WriteAckHeight sql.NullInt64
+	AckHeight      sql.NullInt64
+	TimeoutHeight  sql.NullInt64
+
+	// Latencies from the send_packet block, in milliseconds.
+	RecvLatency     sql.NullInt64
+	CompleteLatency sql.NullInt64 // Until acknowledged or timed out.
+
+	Data sql.NullString
+	Ack  sql.NullString
+}
+
+// Packets returns the lifecycle of the IBC packets sent by the chains of the test case, ordered by send height.
+func (q *Query) Packets(ctx context.Context, testCaseID int64) ([]PacketResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        sequence, src_chain_id, src_port, src_channel, dst_chain_id, dst_port, dst_channel, status,
+        send_height, recv_height, write_ack_height, ack_height, timeout_height,
+        recv_latency_ms, complete_latency_ms, data, ack
+    FROM v_ibc_packets
+    WHERE test_case_id = ?
+    ORDER BY send_height ASC, src_chain_id ASC, sequence ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []PacketResult
+	for rows.Next() {
+		var res PacketResult
+		if err := rows.Scan(
+			&res.Sequence,
+			&res.SrcChainID,
+			&res.SrcPort,
+			&res.SrcChannel,
+			&res.DstChainID,
+			&res.DstPort,
+			&res.DstChannel,
+			&res.Status,
+			&res.SendHeight,
+			&res.RecvHeight,
+			&res.WriteAckHeight,
+			&res.AckHeight,
+			&res.TimeoutHeight,
+			&res.RecvLatency,
+			&res.CompleteLatency,
+			&res.Data,
+			&res.Ack,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
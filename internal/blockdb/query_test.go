@@ -2,6 +2,7 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
 	"strings"
@@ -216,3 +217,270 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_EVMTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "31337", "ethereum")
+	require.NoError(t, err)
+
+	deploy := Tx{Data: []byte(`{"hash":"0x01"}`), EVM: &EVMTx{
+		Hash:            "0x01",
+		From:            "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		ContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		Value:           "0",
+		GasPrice:        "1000000000",
+		Gas:             500000,
+		GasUsed:         400000,
+		Status:          1,
+		Input:           "0x6080",
+		ABIs:            map[string]string{"0x5FbDB2315678afecb367f032d93F642f64180aa3": `[]`},
+	}}
+	call := Tx{Data: []byte(`{"hash":"0x02"}`), EVM: &EVMTx{
+		Hash:     "0x02",
+		From:     "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		To:       "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		Value:    "10000000000000000000000", // Larger than an INTEGER.
+		GasPrice: "1000000000",
+		Gas:      60000,
+		GasUsed:  51000,
+		Status:   0,
+		Input:    "0xa9059cbb",
+		Logs: []EVMLog{
+			{Index: 0, Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Topics: []string{"0xaa", "0xbb"}, Data: "0x"},
+			{Index: 1, Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Data: "0x01"},
+		},
+		ABIs: map[string]string{"0x5FbDB2315678afecb367f032d93F642f64180aa3": `[{"type":"fallback"}]`},
+	}}
+
+	require.NoError(t, chain.SaveBlock(ctx, 3, []Tx{deploy}))
+	require.NoError(t, chain.SaveBlock(ctx, 4, []Tx{{Data: []byte(`not evm`)}, call}))
+
+	results, err := NewQuery(db).EVMTransactions(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	first := results[0]
+	require.EqualValues(t, 3, first.Height)
+	require.Equal(t, "0x01", first.Hash)
+	require.False(t, first.To.Valid)
+	require.Equal(t, "0x5FbDB2315678afecb367f032d93F642f64180aa3", first.ContractAddress.String)
+	require.EqualValues(t, 400000, first.GasUsed)
+	require.EqualValues(t, 1, first.Status)
+	require.Empty(t, first.Logs)
+
+	second := results[1]
+	require.EqualValues(t, 4, second.Height)
+	require.Equal(t, "0x5FbDB2315678afecb367f032d93F642f64180aa3", second.To.String)
+	require.False(t, second.ContractAddress.Valid)
+	require.Equal(t, "10000000000000000000000", second.Value)
+	require.EqualValues(t, 0, second.Status)
+	require.Equal(t, []EVMLogResult{
+		{Index: 0, Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Topics: []string{"0xaa", "0xbb"}, Data: "0x"},
+		{Index: 1, Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Data: "0x01"},
+	}, second.Logs)
+
+	var selector string
+	var logCount int
+	require.NoError(t, db.QueryRow(`SELECT selector, log_count FROM v_evm_txs WHERE hash = '0x02'`).Scan(&selector, &logCount))
+	require.Equal(t, "0xa9059cbb", selector)
+	require.Equal(t, 2, logCount)
+
+	// The latest ABI of an address wins.
+	abis, err := NewQuery(db).EVMABIs(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, []EVMABIResult{
+		{Address: "0x5fbdb2315678afecb367f032d93f642f64180aa3", ABI: `[{"type":"fallback"}]`},
+	}, abis)
+
+	t.Run("too many topics", func(t *testing.T) {
+		tx := Tx{Data: []byte(`{}`), EVM: &EVMTx{
+			Hash:  "0x03",
+			From:  "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			Value: "0",
+			Logs:  []EVMLog{{Address: "0x01", Topics: []string{"0x1", "0x2", "0x3", "0x4", "0x5"}}},
+		}}
+		require.Error(t, chain.SaveBlock(ctx, 5, []Tx{tx}))
+	})
+}
+
+func TestQuery_Packets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	packetEvent := func(typ string, seq string, extra ...EventAttribute) Event {
+		// Both chains use channel-0, so packets are told apart by the chain that sent them.
+		attrs := []EventAttribute{
+			{Key: "packet_sequence", Value: seq},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_dst_channel", Value: "channel-0"},
+		}
+		return Event{Type: typ, Attributes: append(attrs, extra...)}
+	}
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	gaia, err := tc.AddChain(ctx, "gaia-1", "cosmos")
+	require.NoError(t, err)
+	osmo, err := tc.AddChain(ctx, "osmosis-1", "cosmos")
+	require.NoError(t, err)
+
+	// Latencies are between the times in the block headers, not the times the blocks are saved.
+	sentAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, gaia.SaveBlockAt(ctx, 10, sentAt, []Tx{{Data: []byte(`send`), Events: []Event{
+		packetEvent("send_packet", "1", EventAttribute{Key: "packet_data", Value: `{"amount":"1"}`}, EventAttribute{Key: "packet_timeout_height", Value: "0-100"}),
+		packetEvent("send_packet", "2"),
+		{Type: "transfer", Attributes: []EventAttribute{{Key: "recipient", Value: "cosmos1"}}},
+		{Type: "recv_packet", Attributes: []EventAttribute{{Key: "packet_sequence", Value: "not ibc core"}}},
+	}}}))
+	require.NoError(t, osmo.SaveBlockAt(ctx, 20, sentAt.Add(1500*time.Millisecond), []Tx{{Data: []byte(`recv`), Events: []Event{
+		packetEvent("recv_packet", "1"),
+		packetEvent("write_acknowledgement", "1", EventAttribute{Key: "packet_ack_hex", Value: "7b22726573756c74223a2241513d3d227d"}),
+		packetEvent("send_packet", "1"),
+	}}}))
+	require.NoError(t, gaia.SaveBlockAt(ctx, 11, sentAt.Add(3250*time.Millisecond), []Tx{{Data: []byte(`ack`), Events: []Event{
+		packetEvent("acknowledge_packet", "1"),
+		packetEvent("timeout_packet", "2"),
+	}}}))
+
+	// Another test case must not match.
+	other, err := CreateTestCase(ctx, db, "other", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "juno-1", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, []Tx{{Data: []byte(`recv`), Events: []Event{
+		packetEvent("recv_packet", "2"),
+	}}}))
+
+	results, err := NewQuery(db).Packets(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	acked := results[0]
+	require.EqualValues(t, 1, acked.Sequence)
+	require.Equal(t, "gaia-1", acked.SrcChainID)
+	require.Equal(t, "osmosis-1", acked.DstChainID.String)
+	require.Equal(t, "transfer", acked.SrcPort)
+	require.Equal(t, "channel-0", acked.DstChannel)
+	require.Equal(t, "acknowledged", acked.Status)
+	require.EqualValues(t, 10, acked.SendHeight)
+	require.EqualValues(t, 20, acked.RecvHeight.Int64)
+	require.EqualValues(t, 20, acked.WriteAckHeight.Int64)
+	require.EqualValues(t, 11, acked.AckHeight.Int64)
+	require.False(t, acked.TimeoutHeight.Valid)
+	require.Equal(t, sql.NullInt64{Int64: 1500, Valid: true}, acked.RecvLatency)
+	require.Equal(t, sql.NullInt64{Int64: 3250, Valid: true}, acked.CompleteLatency)
+	require.Equal(t, `{"amount":"1"}`, acked.Data.String)
+	require.Equal(t, "7b22726573756c74223a2241513d3d227d", acked.Ack.String)
+
+	timedOut := results[1]
+	require.EqualValues(t, 2, timedOut.Sequence)
+	require.Equal(t, "timed out", timedOut.Status)
+	require.False(t, timedOut.DstChainID.Valid)
+	require.False(t, timedOut.RecvLatency.Valid)
+	require.EqualValues(t, 11, timedOut.TimeoutHeight.Int64)
+	require.Equal(t, sql.NullInt64{Int64: 3250, Valid: true}, timedOut.CompleteLatency)
+
+	// Sent by osmosis on its own channel-0, not to be confused with the packet sent by gaia.
+	sent := results[2]
+	require.EqualValues(t, 1, sent.Sequence)
+	require.Equal(t, "osmosis-1", sent.SrcChainID)
+	require.Equal(t, "sent", sent.Status)
+	require.False(t, sent.RecvHeight.Valid)
+	require.False(t, sent.AckHeight.Valid)
+
+	var timeoutHeight string
+	require.NoError(t, db.QueryRow(`SELECT packet_timeout_height FROM v_ibc_packets WHERE test_case_id = ? AND send_height = 10 AND sequence = 1`, tc.id).Scan(&timeoutHeight))
+	require.Equal(t, "0-100", timeoutHeight)
+
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM ibc_packet_event`).Scan(&n))
+	require.Equal(t, 8, n)
+}
+
+func TestQuery_SQL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	c, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, c.SaveBlock(ctx, 10, []Tx{{Data: []byte("tx1")}, {Data: []byte("tx2")}}))
+
+	q := NewQuery(db)
+
+	res, err := q.SQL(ctx, `SELECT block_height, tx FROM v_tx_flattened WHERE chain_kid = ? ORDER BY tx`, c.id)
+	require.NoError(t, err)
+	require.Equal(t, []string{"block_height", "tx"}, res.Columns)
+	require.Equal(t, [][]any{{int64(10), "tx1"}, {int64(10), "tx2"}}, res.Rows)
+
+	for _, query := range []string{
+		`DELETE FROM tx`,
+		`COMMIT; DELETE FROM tx; SELECT 1`,
+		`SELECT 1; DELETE FROM tx`,
+		`WITH x AS (SELECT 1) DELETE FROM tx`,
+		`PRAGMA query_only = OFF`,
+		`/* SELECT */ UPDATE tx SET data = 'x'`,
+	} {
+		_, err := q.SQL(ctx, query)
+		require.Error(t, err, query)
+	}
+
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tx`).Scan(&count))
+	require.Equal(t, 2, count)
+
+	// The connection is writable again after the query.
+	require.NoError(t, c.SaveBlock(ctx, 11, []Tx{{Data: []byte("tx3")}}))
+}
+
+func TestCheckReadOnlyStatement(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Query   string
+		WantErr string
+	}{
+		{Query: `SELECT 1`},
+		{Query: `  select 1;  `},
+		{Query: "-- comment\nSELECT 1;;\n-- trailing comment"},
+		{Query: `/* leading */ WITH x AS (SELECT 1) SELECT * FROM x`},
+		{Query: `EXPLAIN QUERY PLAN SELECT * FROM tx`},
+		{Query: `VALUES (1), (2)`},
+		{Query: `SELECT 'a;b', "c;d", [e;f], 'it''s; fine'`},
+		{Query: `SELECT 1 -- ; DELETE FROM tx`},
+
+		{Query: ``, WantErr: "only SELECT"},
+		{Query: `DELETE FROM tx`, WantErr: `got "DELETE"`},
+		{Query: `ATTACH DATABASE 'x.db' AS x`, WantErr: `got "ATTACH"`},
+		{Query: `SELECT 1; DELETE FROM tx`, WantErr: "single statement"},
+		{Query: `SELECT 1; /* */ SELECT 2`, WantErr: "single statement"},
+		{Query: `SELECT 'unterminated`, WantErr: "unterminated quoted"},
+		{Query: `SELECT 1 /* unterminated`, WantErr: "unterminated comment"},
+	} {
+		err := checkReadOnlyStatement(tt.Query)
+		if tt.WantErr == "" {
+			require.NoError(t, err, tt.Query)
+		} else {
+			require.ErrorContains(t, err, tt.WantErr, tt.Query)
+		}
+	}
+}
@@ -39,3 +39,6 @@ func ConnectDB(ctx context.Context, databasePath string) (*sql.DB, error) {
 func nowRFC3339() string {
 	return time.Now().UTC().Format(time.RFC3339)
 }
+
+// blockTimeFormat is RFC 3339 with milliseconds, the precision of SQLite's date and time functions.
+const blockTimeFormat = "2006-01-02T15:04:05.000Z07:00"
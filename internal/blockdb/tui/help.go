@@ -40,14 +40,28 @@ var (
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
+		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"e", "evm txs"}, {"p", "ibc packets"}, {"s", "sql"}, {"enter", "view txs"}}, tableNavKeys),
 		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		evmTxsMain:         bindingsWithBase([]keyBinding{{"enter", "view tx"}}, tableNavKeys),
+		evmTxDetailMain: bindingsWithBase([]keyBinding{
+			{"[", "previous tx"},
+			{"]", "next tx"},
+		}, textNavKeys),
+		packetsMain: bindingsWithBase([]keyBinding{{"enter", "view packet"}}, tableNavKeys),
+		packetDetailMain: bindingsWithBase([]keyBinding{
+			{"[", "previous packet"},
+			{"]", "next packet"},
+		}, textNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
 			{"/", "toggle search"},
 			{"c", "copy all txs"},
 		}, textNavKeys),
+		sqlMain: bindingsWithBase([]keyBinding{
+			{"enter", "run query"},
+			{"tab", "toggle query/results"},
+		}, tableNavKeys),
 		errorModalMain: bindingsWithBase(nil),
 	}
 )
@@ -11,12 +11,17 @@ func _() {
 	_ = x[testCasesMain-0]
 	_ = x[cosmosMessagesMain-1]
 	_ = x[txDetailMain-2]
-	_ = x[errorModalMain-3]
+	_ = x[evmTxsMain-3]
+	_ = x[evmTxDetailMain-4]
+	_ = x[packetsMain-5]
+	_ = x[packetDetailMain-6]
+	_ = x[sqlMain-7]
+	_ = x[errorModalMain-8]
 }
 
-const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMain"
+const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainevmTxsMainevmTxDetailMainpacketsMainpacketDetailMainsqlMainerrorModalMain"
 
-var _mainContent_index = [...]uint8{0, 13, 31, 43, 57}
+var _mainContent_index = [...]uint8{0, 13, 31, 43, 53, 68, 79, 95, 102, 116}
 
 func (i mainContent) String() string {
 	if i < 0 || i >= mainContent(len(_mainContent_index)-1) {
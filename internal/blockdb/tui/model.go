@@ -18,6 +18,11 @@ const (
 	testCasesMain mainContent = iota
 	cosmosMessagesMain
 	txDetailMain
+	evmTxsMain
+	evmTxDetailMain
+	packetsMain
+	packetDetailMain
+	sqlMain
 	errorModalMain
 )
 
@@ -31,6 +36,10 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 type QueryService interface {
 	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
 	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	EVMTransactions(ctx context.Context, chainPkey int64) ([]blockdb.EVMTxResult, error)
+	EVMABIs(ctx context.Context, chainPkey int64) ([]blockdb.EVMABIResult, error)
+	Packets(ctx context.Context, testCaseID int64) ([]blockdb.PacketResult, error)
+	SQL(ctx context.Context, query string, args ...any) (blockdb.SQLResult, error)
 }
 
 // Model encapsulates state that updates a view.
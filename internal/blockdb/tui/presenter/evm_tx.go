@@ -0,0 +1,244 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// EVMABIs are the contract ABIs of a chain keyed by lowercase address.
+type EVMABIs map[string]abi.ABI
+
+// NewEVMABIs parses the registered ABIs.
+func NewEVMABIs(results []blockdb.EVMABIResult) (EVMABIs, error) {
+	abis := make(EVMABIs, len(results))
+	for _, res := range results {
+		parsed, err := abi.JSON(strings.NewReader(res.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("parse abi of %s: %w", res.Address, err)
+		}
+		abis[strings.ToLower(res.Address)] = parsed
+	}
+	return abis, nil
+}
+
+func (abis EVMABIs) lookup(addr string) (abi.ABI, bool) {
+	a, ok := abis[strings.ToLower(addr)]
+	return a, ok
+}
+
+// EVMTx presents a blockdb.EVMTxResult, decoding its calldata and logs with the ABIs.
+type EVMTx struct {
+	Result blockdb.EVMTxResult
+	ABIs   EVMABIs
+}
+
+func (tx EVMTx) Height() string { return strconv.FormatInt(tx.Result.Height, 10) }
+
+// Hash is abbreviated, see Detail for the full hash.
+func (tx EVMTx) Hash() string { return shortHex(tx.Result.Hash) }
+
+func (tx EVMTx) From() string { return shortHex(tx.Result.From) }
+
+// To is the recipient, or the created contract for a contract creation.
+func (tx EVMTx) To() string {
+	if tx.Result.To.Valid {
+		return shortHex(tx.Result.To.String)
+	}
+	return "create " + shortHex(tx.Result.ContractAddress.String)
+}
+
+// Value is in wei.
+func (tx EVMTx) Value() string { return tx.Result.Value }
+
+func (tx EVMTx) Status() string {
+	if tx.Result.Status == 1 {
+		return "success"
+	}
+	return "reverted"
+}
+
+func (tx EVMTx) LogCount() string { return strconv.Itoa(len(tx.Result.Logs)) }
+
+// Method is the name of the called method if its contract's ABI is registered,
+// otherwise the 4 byte method selector. It is empty for transfers and contract creations.
+func (tx EVMTx) Method() string {
+	if !tx.Result.To.Valid {
+		return ""
+	}
+	input := decodeHex(tx.Result.Input)
+	if len(input) < 4 {
+		return ""
+	}
+	if a, ok := tx.ABIs.lookup(tx.Result.To.String); ok {
+		if method, err := a.MethodById(input[:4]); err == nil {
+			return method.Name
+		}
+	}
+	return hexutil.Encode(input[:4])
+}
+
+// Detail is the full transaction, with the decoded call and logs.
+func (tx EVMTx) Detail() string {
+	var sb strings.Builder
+	res := tx.Result
+
+	fmt.Fprintf(&sb, "Hash:     %s\n", res.Hash)
+	fmt.Fprintf(&sb, "Height:   %d\n", res.Height)
+	fmt.Fprintf(&sb, "Status:   %s\n", tx.Status())
+	fmt.Fprintf(&sb, "From:     %s\n", res.From)
+	if res.To.Valid {
+		fmt.Fprintf(&sb, "To:       %s\n", res.To.String)
+	} else {
+		fmt.Fprintf(&sb, "Contract: %s (created)\n", res.ContractAddress.String)
+	}
+	fmt.Fprintf(&sb, "Value:    %s wei\n", res.Value)
+	fmt.Fprintf(&sb, "Gas:      %d used of %d, at %s wei\n", res.GasUsed, res.Gas, res.GasPrice)
+
+	if call := tx.call(); call != "" {
+		sb.WriteString("\n" + call)
+	}
+	fmt.Fprintf(&sb, "\nInput:\n%s\n", res.Input)
+
+	if len(res.Logs) > 0 {
+		sb.WriteString("\nLogs:\n")
+	}
+	for _, l := range res.Logs {
+		sb.WriteString(tx.log(l))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (tx EVMTx) call() string {
+	if !tx.Result.To.Valid {
+		return ""
+	}
+	input := decodeHex(tx.Result.Input)
+	if len(input) < 4 {
+		return ""
+	}
+	a, ok := tx.ABIs.lookup(tx.Result.To.String)
+	if !ok {
+		return fmt.Sprintf("Call: %s (no ABI registered for %s)\n", hexutil.Encode(input[:4]), tx.Result.To.String)
+	}
+	method, err := a.MethodById(input[:4])
+	if err != nil {
+		return fmt.Sprintf("Call: %s (%v)\n", hexutil.Encode(input[:4]), err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Call: %s\n", method.Sig)
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		fmt.Fprintf(&sb, "  failed to decode arguments: %v\n", err)
+		return sb.String()
+	}
+	for i, arg := range method.Inputs {
+		fmt.Fprintf(&sb, "  %s: %s\n", argName(arg, i), formatABIValue(args[i]))
+	}
+	return sb.String()
+}
+
+func (tx EVMTx) log(l blockdb.EVMLogResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "  [%d] %s", l.Index, l.Address)
+
+	event, values, err := tx.decodeLog(l)
+	if err == nil {
+		fmt.Fprintf(&sb, " %s\n", event.Sig)
+		for i, arg := range event.Inputs {
+			fmt.Fprintf(&sb, "    %s: %s\n", argName(arg, i), formatABIValue(values[arg.Name]))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	if !errors.Is(err, errNoABI) {
+		fmt.Fprintf(&sb, "    failed to decode: %v\n", err)
+	}
+	for i, topic := range l.Topics {
+		fmt.Fprintf(&sb, "    topic%d: %s\n", i, topic)
+	}
+	fmt.Fprintf(&sb, "    data: %s\n", l.Data)
+	return sb.String()
+}
+
+var errNoABI = errors.New("no ABI registered")
+
+func (tx EVMTx) decodeLog(l blockdb.EVMLogResult) (*abi.Event, map[string]any, error) {
+	a, ok := tx.ABIs.lookup(l.Address)
+	if !ok || len(l.Topics) == 0 {
+		return nil, nil, errNoABI
+	}
+	event, err := a.EventByID(common.HexToHash(l.Topics[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]any)
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	topics := make([]common.Hash, 0, len(l.Topics)-1)
+	for _, topic := range l.Topics[1:] {
+		topics = append(topics, common.HexToHash(topic))
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics); err != nil {
+		return nil, nil, err
+	}
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(values, decodeHex(l.Data)); err != nil {
+		return nil, nil, err
+	}
+	return event, values, nil
+}
+
+func argName(arg abi.Argument, i int) string {
+	if arg.Name == "" {
+		return strconv.Itoa(i)
+	}
+	return arg.Name
+}
+
+func formatABIValue(v any) string {
+	switch v := v.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// shortHex abbreviates hashes and addresses, e.g. 0x1234…cdef.
+func shortHex(s string) string {
+	if len(s) <= 14 {
+		return s
+	}
+	return s[:6] + "…" + s[len(s)-4:]
+}
+
+// decodeHex returns the bytes of the 0x prefixed hex string, or nil if it is invalid.
+func decodeHex(s string) []byte {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil
+	}
+	return b
+}
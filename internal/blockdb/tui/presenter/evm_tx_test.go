@@ -0,0 +1,107 @@
+package presenter
+
+import (
+	"database/sql"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+const erc20ABI = `[
+  {"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+  {"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func TestEVMTx(t *testing.T) {
+	t.Parallel()
+
+	const (
+		token = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+		from  = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+		to    = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	)
+
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	require.NoError(t, err)
+	input, err := parsed.Pack("transfer", common.HexToAddress(to), big.NewInt(100))
+	require.NoError(t, err)
+	data, err := parsed.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(100))
+	require.NoError(t, err)
+
+	result := blockdb.EVMTxResult{
+		Height:   7,
+		Hash:     "0x2b1e6a6ab54d5b8e1d5e8f53bc0c4a7b1f0c8a5f8a8c2d6f3e1b9a4c7d0e2f31",
+		From:     from,
+		To:       sql.NullString{String: token, Valid: true},
+		Value:    "0",
+		GasPrice: "1000000000",
+		Gas:      60000,
+		GasUsed:  51000,
+		Status:   1,
+		Input:    hexutil.Encode(input),
+		Logs: []blockdb.EVMLogResult{{
+			Index:   0,
+			Address: token,
+			Topics: []string{
+				parsed.Events["Transfer"].ID.Hex(),
+				common.BytesToHash(common.HexToAddress(from).Bytes()).Hex(),
+				common.BytesToHash(common.HexToAddress(to).Bytes()).Hex(),
+			},
+			Data: hexutil.Encode(data),
+		}},
+	}
+
+	t.Run("with abi", func(t *testing.T) {
+		abis, err := NewEVMABIs([]blockdb.EVMABIResult{{Address: strings.ToLower(token), ABI: erc20ABI}})
+		require.NoError(t, err)
+
+		pres := EVMTx{Result: result, ABIs: abis}
+
+		require.Equal(t, "7", pres.Height())
+		require.Equal(t, "0x2b1e…2f31", pres.Hash())
+		require.Equal(t, "0x5FbD…0aa3", pres.To())
+		require.Equal(t, "transfer", pres.Method())
+		require.Equal(t, "success", pres.Status())
+		require.Equal(t, "1", pres.LogCount())
+
+		detail := pres.Detail()
+		require.Contains(t, detail, "Gas:      51000 used of 60000, at 1000000000 wei")
+		require.Contains(t, detail, "Call: transfer(address,uint256)\n  to: "+to+"\n  amount: 100\n")
+		require.Contains(t, detail, "[0] "+token+" Transfer(address,address,uint256)\n    from: "+from+"\n    to: "+to+"\n    value: 100")
+	})
+
+	t.Run("without abi", func(t *testing.T) {
+		pres := EVMTx{Result: result}
+
+		require.Equal(t, "0xa9059cbb", pres.Method())
+
+		detail := pres.Detail()
+		require.Contains(t, detail, "Call: 0xa9059cbb (no ABI registered for "+token+")")
+		require.Contains(t, detail, "    topic0: "+parsed.Events["Transfer"].ID.Hex())
+		require.Contains(t, detail, "    data: "+hexutil.Encode(data))
+	})
+
+	t.Run("contract creation", func(t *testing.T) {
+		creation := blockdb.EVMTxResult{
+			ContractAddress: sql.NullString{String: token, Valid: true},
+			Input:           "0x6080",
+		}
+		pres := EVMTx{Result: creation}
+
+		require.Equal(t, "create 0x5FbD…0aa3", pres.To())
+		require.Empty(t, pres.Method())
+		require.Equal(t, "reverted", pres.Status())
+		require.Contains(t, pres.Detail(), "Contract: "+token+" (created)")
+	})
+
+	t.Run("invalid abi", func(t *testing.T) {
+		_, err := NewEVMABIs([]blockdb.EVMABIResult{{Address: token, ABI: `{}`}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,141 @@
+package presenter
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Packet presents the lifecycle of an IBC packet from a blockdb.PacketResult.
+type Packet struct {
+	Result blockdb.PacketResult
+}
+
+func (p Packet) Sequence() string { return strconv.FormatInt(p.Result.Sequence, 10) }
+
+// Source is the sending chain with the packet's source port and channel.
+func (p Packet) Source() string {
+	return fmt.Sprintf("%s %s/%s", p.Result.SrcChainID, p.Result.SrcPort, p.Result.SrcChannel)
+}
+
+// Destination is the receiving chain with the packet's destination port and channel.
+// The chain is unknown until the packet is received.
+func (p Packet) Destination() string {
+	chainID := p.Result.DstChainID.String
+	if !p.Result.DstChainID.Valid {
+		chainID = "?"
+	}
+	return fmt.Sprintf("%s %s/%s", chainID, p.Result.DstPort, p.Result.DstChannel)
+}
+
+// Status notes error acknowledgements, which complete the packet lifecycle even though the packet failed.
+func (p Packet) Status() string {
+	if p.AckError() != "" {
+		return p.Result.Status + " (error)"
+	}
+	return p.Result.Status
+}
+
+func (p Packet) Sent() string     { return strconv.FormatInt(p.Result.SendHeight, 10) }
+func (p Packet) Received() string { return formatNullInt(p.Result.RecvHeight) }
+
+// Completed is the height at which the packet was acknowledged or timed out on the source chain.
+func (p Packet) Completed() string {
+	if p.Result.AckHeight.Valid {
+		return formatNullInt(p.Result.AckHeight)
+	}
+	return formatNullInt(p.Result.TimeoutHeight)
+}
+
+// Latency is the time from sending until the packet was acknowledged or timed out,
+// or until it was received if it is not yet complete.
+func (p Packet) Latency() string {
+	if p.Result.CompleteLatency.Valid {
+		return formatLatency(p.Result.CompleteLatency)
+	}
+	return formatLatency(p.Result.RecvLatency)
+}
+
+// Data is the packet data, decoded if saved as hex.
+func (p Packet) Data() string { return decodePacketBytes(p.Result.Data.String) }
+
+// Ack is the acknowledgement written by the destination chain, decoded if saved as hex.
+func (p Packet) Ack() string { return decodePacketBytes(p.Result.Ack.String) }
+
+// AckError is the error of an ICS-04 error acknowledgement, if any.
+func (p Packet) AckError() string {
+	var ack struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(p.Ack()), &ack); err != nil {
+		return ""
+	}
+	return ack.Error
+}
+
+// Detail is the timeline of the packet with its data and acknowledgement.
+func (p Packet) Detail() string {
+	var sb strings.Builder
+	res := p.Result
+
+	fmt.Fprintf(&sb, "Sequence:    %d\n", res.Sequence)
+	fmt.Fprintf(&sb, "Source:      %s\n", p.Source())
+	fmt.Fprintf(&sb, "Destination: %s\n", p.Destination())
+	fmt.Fprintf(&sb, "Status:      %s\n", p.Status())
+
+	sb.WriteString("\nTimeline:\n")
+	fmt.Fprintf(&sb, "  sent          %s @ %d\n", res.SrcChainID, res.SendHeight)
+	if res.RecvHeight.Valid {
+		fmt.Fprintf(&sb, "  received      %s @ %d, after %s\n", res.DstChainID.String, res.RecvHeight.Int64, formatLatency(res.RecvLatency))
+	}
+	if res.WriteAckHeight.Valid {
+		fmt.Fprintf(&sb, "  ack written   %s @ %d\n", res.DstChainID.String, res.WriteAckHeight.Int64)
+	}
+	if res.AckHeight.Valid {
+		fmt.Fprintf(&sb, "  acknowledged  %s @ %d, after %s\n", res.SrcChainID, res.AckHeight.Int64, formatLatency(res.CompleteLatency))
+	}
+	if res.TimeoutHeight.Valid {
+		fmt.Fprintf(&sb, "  timed out     %s @ %d, after %s\n", res.SrcChainID, res.TimeoutHeight.Int64, formatLatency(res.CompleteLatency))
+	}
+
+	if data := p.Data(); data != "" {
+		fmt.Fprintf(&sb, "\nData:\n%s\n", data)
+	}
+	if ack := p.Ack(); ack != "" {
+		fmt.Fprintf(&sb, "\nAck:\n%s\n", ack)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func formatNullInt(n sql.NullInt64) string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatInt(n.Int64, 10)
+}
+
+// formatLatency formats a latency in milliseconds, e.g. as 1.5s.
+func formatLatency(ms sql.NullInt64) string {
+	if !ms.Valid {
+		return ""
+	}
+	return (time.Duration(ms.Int64) * time.Millisecond).String()
+}
+
+// decodePacketBytes decodes the packet_data_hex and packet_ack_hex event attributes,
+// returning other values as is.
+func decodePacketBytes(s string) string {
+	if s == "" {
+		return ""
+	}
+	if b, err := hex.DecodeString(s); err == nil {
+		return string(b)
+	}
+	return s
+}
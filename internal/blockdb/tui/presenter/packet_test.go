@@ -0,0 +1,71 @@
+package presenter
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("acknowledged", func(t *testing.T) {
+		pres := Packet{Result: blockdb.PacketResult{
+			Sequence:        3,
+			SrcChainID:      "gaia-1",
+			SrcPort:         "transfer",
+			SrcChannel:      "channel-0",
+			DstChainID:      sql.NullString{String: "osmosis-1", Valid: true},
+			DstPort:         "transfer",
+			DstChannel:      "channel-4",
+			Status:          "acknowledged",
+			SendHeight:      10,
+			RecvHeight:      sql.NullInt64{Int64: 21, Valid: true},
+			WriteAckHeight:  sql.NullInt64{Int64: 21, Valid: true},
+			AckHeight:       sql.NullInt64{Int64: 14, Valid: true},
+			RecvLatency:     sql.NullInt64{Int64: 3000, Valid: true},
+			CompleteLatency: sql.NullInt64{Int64: 7250, Valid: true},
+			Data:            sql.NullString{String: `{"amount":"1"}`, Valid: true},
+			Ack:             sql.NullString{String: hex.EncodeToString([]byte(`{"error":"insufficient funds"}`)), Valid: true},
+		}}
+
+		require.Equal(t, "3", pres.Sequence())
+		require.Equal(t, "gaia-1 transfer/channel-0", pres.Source())
+		require.Equal(t, "osmosis-1 transfer/channel-4", pres.Destination())
+		require.Equal(t, "acknowledged (error)", pres.Status())
+		require.Equal(t, "10", pres.Sent())
+		require.Equal(t, "21", pres.Received())
+		require.Equal(t, "14", pres.Completed())
+		require.Equal(t, "7.25s", pres.Latency())
+		require.Equal(t, "insufficient funds", pres.AckError())
+
+		detail := pres.Detail()
+		require.Contains(t, detail, "  sent          gaia-1 @ 10\n")
+		require.Contains(t, detail, "  received      osmosis-1 @ 21, after 3s\n")
+		require.Contains(t, detail, "  ack written   osmosis-1 @ 21\n")
+		require.Contains(t, detail, "  acknowledged  gaia-1 @ 14, after 7.25s\n")
+		require.Contains(t, detail, "Data:\n{\"amount\":\"1\"}\n")
+		require.Contains(t, detail, "Ack:\n{\"error\":\"insufficient funds\"}")
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		pres := Packet{Result: blockdb.PacketResult{
+			SrcChainID: "gaia-1",
+			DstPort:    "transfer",
+			DstChannel: "channel-4",
+			Status:     "sent",
+			SendHeight: 10,
+		}}
+
+		require.Equal(t, "? transfer/channel-4", pres.Destination())
+		require.Equal(t, "sent", pres.Status())
+		require.Empty(t, pres.Received())
+		require.Empty(t, pres.Completed())
+		require.Empty(t, pres.Latency())
+		require.Empty(t, pres.AckError())
+		require.NotContains(t, pres.Detail(), "received")
+	})
+}
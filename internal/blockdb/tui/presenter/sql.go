@@ -0,0 +1,24 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SQLValue presents a value of a blockdb.SQLResult row.
+func SQLValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -49,6 +49,91 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
 			return nil
 
+		case event.Rune() == 'e' && m.stack.Current() == testCasesMain:
+			// Show evm txs.
+			tc := m.testCases[m.selectedRow()]
+			txs, err := m.evmTxs(ctx, tc.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(err)
+				return nil
+			}
+			m.pushMainView(evmTxsMain, newEVMTxsView(tc, txs))
+			return nil
+
+		case event.Rune() == 'p' && m.stack.Current() == testCasesMain:
+			// Show the ibc packets of all chains in the test case.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.Packets(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query packets: %w", err))
+				return nil
+			}
+			packets := make([]presenter.Packet, len(results))
+			for i, res := range results {
+				packets[i] = presenter.Packet{Result: res}
+			}
+			m.pushMainView(packetsMain, newPacketsView(tc, packets))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == packetsMain:
+			// Show packet detail.
+			view := m.packetsView()
+			if len(view.Packets) == 0 {
+				return nil
+			}
+			row, _ := view.GetSelection()
+			// Offset by 1 to account for header row.
+			m.pushMainView(packetDetailMain, packetDetailView(view.Packets, row-1))
+			return nil
+
+		case event.Rune() == '[' && m.stack.Current() == packetDetailMain:
+			goToPrevPage(m.packetDetailView())
+			return nil
+
+		case event.Rune() == ']' && m.stack.Current() == packetDetailMain:
+			gotToNextPage(m.packetDetailView())
+			return nil
+
+		case event.Rune() == 's' && m.stack.Current() == testCasesMain:
+			// Show sql, querying the selected chain by default.
+			tc := m.testCases[m.selectedRow()]
+			query := fmt.Sprintf("SELECT block_height, tx_code, tx FROM v_tx_flattened WHERE chain_kid = %d ORDER BY block_height DESC LIMIT 100", tc.ChainPKey)
+			m.pushMainView(sqlMain, newSQLView(query))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == sqlMain:
+			view := m.sqlView()
+			res, err := m.querySvc.SQL(ctx, view.Input.GetText())
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query: %w", err))
+				return nil
+			}
+			view.SetResult(res)
+			return nil
+
+		case event.Key() == tcell.KeyTab && m.stack.Current() == sqlMain:
+			m.sqlView().ToggleFocus()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == evmTxsMain:
+			// Show evm tx detail.
+			view := m.evmTxsView()
+			if len(view.Txs) == 0 {
+				return nil
+			}
+			row, _ := view.GetSelection()
+			// Offset by 1 to account for header row.
+			m.pushMainView(evmTxDetailMain, evmTxDetailView(view.chainID, view.Txs, row-1))
+			return nil
+
+		case event.Rune() == '[' && m.stack.Current() == evmTxDetailMain:
+			goToPrevPage(m.evmTxDetailView())
+			return nil
+
+		case event.Rune() == ']' && m.stack.Current() == evmTxDetailMain:
+			gotToNextPage(m.evmTxDetailView())
+			return nil
+
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
 			goToPrevPage(m.txDetailView().Pages)
 			return nil
@@ -111,6 +196,53 @@ func (m *Model) txDetailView() *txDetailView {
 	return primitive.(*txDetailView)
 }
 
+// evmTxs returns the evm txs of the chain, decoded with its registered ABIs.
+func (m *Model) evmTxs(ctx context.Context, chainPkey int64) ([]presenter.EVMTx, error) {
+	results, err := m.querySvc.EVMTransactions(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query evm transactions: %w", err)
+	}
+	abiResults, err := m.querySvc.EVMABIs(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query evm abis: %w", err)
+	}
+	abis, err := presenter.NewEVMABIs(abiResults)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]presenter.EVMTx, len(results))
+	for i, res := range results {
+		txs[i] = presenter.EVMTx{Result: res, ABIs: abis}
+	}
+	return txs, nil
+}
+
+func (m *Model) evmTxsView() *evmTxsView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*evmTxsView)
+}
+
+func (m *Model) packetsView() *packetsView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*packetsView)
+}
+
+func (m *Model) packetDetailView() *tview.Pages {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*tview.Pages)
+}
+
+func (m *Model) sqlView() *sqlView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*sqlView)
+}
+
+func (m *Model) evmTxDetailView() *tview.Pages {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*tview.Pages)
+}
+
 // gotToNextPage assumes a convention where the page name is equal to its index. e.g. "0", "1", "2", etc.
 func gotToNextPage(pages *tview.Pages) {
 	idxStr, _ := pages.GetFrontPage()
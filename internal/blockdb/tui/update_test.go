@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -32,9 +33,47 @@ type mockQueryService struct {
 	GotChainPkey int64
 	Messages     []blockdb.CosmosMessageResult
 	Txs          []blockdb.TxResult
+	EVMTxs       []blockdb.EVMTxResult
+	ABIs         []blockdb.EVMABIResult
+	IBCPackets   []blockdb.PacketResult
+	GotTestCase  int64
+	SQLResult    blockdb.SQLResult
+	GotQuery     string
 	Err          error
 }
 
+func (m *mockQueryService) Packets(ctx context.Context, testCaseID int64) ([]blockdb.PacketResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCase = testCaseID
+	return m.IBCPackets, m.Err
+}
+
+func (m *mockQueryService) SQL(ctx context.Context, query string, args ...any) (blockdb.SQLResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotQuery = query
+	return m.SQLResult, m.Err
+}
+
+func (m *mockQueryService) EVMTransactions(ctx context.Context, chainPkey int64) ([]blockdb.EVMTxResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.EVMTxs, m.Err
+}
+
+func (m *mockQueryService) EVMABIs(ctx context.Context, chainPkey int64) ([]blockdb.EVMABIResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.ABIs, m.Err
+}
+
 func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
 	if ctx == nil {
 		panic("nil context")
@@ -95,6 +134,158 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("evm txs", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			EVMTxs: []blockdb.EVMTxResult{
+				{Height: 10, Hash: "0x01", Status: 1, To: sql.NullString{String: "0xaa", Valid: true}},
+				{Height: 11, Hash: "0x02", Status: 0, ContractAddress: sql.NullString{String: "0xbb", Valid: true}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-evm"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('e'))
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+
+		view := model.evmTxsView()
+		// 3 rows: 1 header + 2 blockdb.EVMTxResult
+		require.Equal(t, 3, view.GetRowCount())
+		require.Contains(t, view.GetTitle(), "my-evm")
+
+		draw(model.RootView())
+		update(enterKey)
+
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+		pages := model.evmTxDetailView()
+		require.Equal(t, 2, pages.GetPageCount())
+
+		_, primitive := pages.GetFrontPage()
+		textView := primitive.(*tview.TextView)
+		require.Contains(t, textView.GetTitle(), "EVM Tx 1 of 2")
+		require.Contains(t, textView.GetText(true), "Hash:     0x01")
+
+		update(runeKey(']'))
+		_, primitive = pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-evm @ Height 11 [EVM Tx 2 of 2]")
+
+		update(escKey)
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+	})
+
+	t.Run("evm txs invalid abi", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			ABIs: []blockdb.EVMABIResult{{Address: "0xaa", ABI: `{}`}},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-evm"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('e'))
+
+		_, primitive := model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Flex{}, primitive)
+	})
+
+	t.Run("packets", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			IBCPackets: []blockdb.PacketResult{
+				{Sequence: 1, SrcChainID: "gaia-1", SrcPort: "transfer", SrcChannel: "channel-0", Status: "sent", SendHeight: 10},
+				{Sequence: 2, SrcChainID: "gaia-1", SrcPort: "transfer", SrcChannel: "channel-0", Status: "sent", SendHeight: 11},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, ChainPKey: 5, ChainID: "gaia-1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		require.EqualValues(t, 3, querySvc.GotTestCase)
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		view := model.packetsView()
+		require.Equal(t, 3, view.GetRowCount())
+		require.Equal(t, "gaia-1 transfer/channel-0", view.GetCell(1, 1).Text)
+
+		draw(model.RootView())
+		update(enterKey)
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+
+		pages := model.packetDetailView()
+		_, primitive := pages.GetFrontPage()
+		textView := primitive.(*tview.TextView)
+		require.Contains(t, textView.GetTitle(), "gaia-1 transfer/channel-0 #1 [Packet 1 of 2]")
+		require.Contains(t, textView.GetText(true), "sent          gaia-1 @ 10")
+
+		update(runeKey(']'))
+		_, primitive = pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "[Packet 2 of 2]")
+
+		update(escKey)
+		update(escKey)
+		require.Equal(t, 1, model.mainContentView().GetPageCount())
+
+		querySvc.Err = errors.New("boom")
+		update(runeKey('p'))
+		_, primitive = model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Flex{}, primitive)
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			SQLResult: blockdb.SQLResult{
+				Columns: []string{"block_height", "tx"},
+				Rows: [][]any{
+					{int64(12), []byte(`{"tx":1}`)},
+					{int64(13), nil},
+				},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('s'))
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		view := model.sqlView()
+		require.Contains(t, view.Input.GetText(), "WHERE chain_kid = 5")
+
+		update(enterKey)
+		require.Equal(t, view.Input.GetText(), querySvc.GotQuery)
+
+		require.Equal(t, "Results [2 rows]", view.Results.GetTitle())
+		require.Equal(t, 3, view.Results.GetRowCount())
+		require.Equal(t, "BLOCK_HEIGHT", view.Results.GetCell(0, 0).Text)
+		require.Equal(t, `{"tx":1}`, view.Results.GetCell(1, 1).Text)
+		require.Equal(t, "NULL", view.Results.GetCell(2, 1).Text)
+
+		update(tcell.NewEventKey(tcell.KeyTab, 0, 0))
+		require.True(t, view.Results.HasFocus())
+		require.False(t, view.Input.HasFocus())
+		update(tcell.NewEventKey(tcell.KeyTab, 0, 0))
+		require.True(t, view.Input.HasFocus())
+
+		querySvc.Err = errors.New("no such table")
+		update(enterKey)
+		_, primitive := model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Flex{}, primitive)
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
@@ -61,6 +61,13 @@ func detailTableView(title string, headers []string, rows [][]string) *tview.Tab
 		SetBorderAttributes(tcell.AttrDim)
 
 	tbl.SetTitle(title)
+	setTableContent(tbl, headers, rows)
+	return tbl
+}
+
+// setTableContent replaces the content of tbl with a header row and the rows.
+func setTableContent(tbl *tview.Table, headers []string, rows [][]string) {
+	tbl.Clear()
 
 	headerCell := func(s string) *tview.TableCell {
 		s = strings.ToUpper(s)
@@ -89,7 +96,6 @@ func detailTableView(title string, headers []string, rows [][]string) *tview.Tab
 			tbl.SetCell(rowPos, col, contentCell(content))
 		}
 	}
-	return tbl
 }
 
 // testCasesView is the initial main content.
@@ -150,6 +156,199 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 	return detailTableView(title, headers, rows)
 }
 
+// evmTxsView lists the EVM transactions of a chain, keeping them for evmTxDetailView.
+type evmTxsView struct {
+	*tview.Table
+
+	chainID string
+	Txs     []presenter.EVMTx
+}
+
+func newEVMTxsView(tc blockdb.TestCaseResult, txs []presenter.EVMTx) *evmTxsView {
+	headers := []string{
+		"Height",
+		"Hash",
+		"From",
+		"To",
+		"Method",
+		"Value",
+		"Status",
+		"Logs",
+	}
+
+	rows := make([][]string, len(txs))
+	for i, pres := range txs {
+		rows[i] = []string{
+			pres.Height(),
+			pres.Hash(),
+			pres.From(),
+			pres.To(),
+			pres.Method(),
+			pres.Value(),
+			pres.Status(),
+			pres.LogCount(),
+		}
+	}
+
+	title := fmt.Sprintf("%s EVM Txs [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
+	return &evmTxsView{
+		Table:   detailTableView(title, headers, rows),
+		chainID: tc.ChainID,
+		Txs:     txs,
+	}
+}
+
+// evmTxDetailView shows one decoded EVM transaction per page, starting at the selected one.
+func evmTxDetailView(chainID string, txs []presenter.EVMTx, selected int) *tview.Pages {
+	pages := tview.NewPages()
+	for i, pres := range txs {
+		textView := tview.NewTextView().
+			SetText(pres.Detail()).
+			SetTextColor(textColor).
+			SetWrap(true).
+			SetWordWrap(true).
+			SetTextAlign(tview.AlignLeft).
+			SetScrollable(true)
+
+		textView.SetBorder(true).
+			SetBorderPadding(0, 0, 1, 1).
+			SetBorderAttributes(tcell.AttrDim)
+
+		textView.SetTitle(fmt.Sprintf("%s @ Height %d [EVM Tx %d of %d]", chainID, pres.Result.Height, i+1, len(txs)))
+
+		pages.AddPage(strconv.Itoa(i), textView, true, false)
+	}
+	pages.SwitchToPage(strconv.Itoa(selected))
+	return pages
+}
+
+// packetsView lists the IBC packets sent by the chains of a test case, keeping them for packetDetailView.
+type packetsView struct {
+	*tview.Table
+
+	Packets []presenter.Packet
+}
+
+func newPacketsView(tc blockdb.TestCaseResult, packets []presenter.Packet) *packetsView {
+	headers := []string{
+		"Seq",
+		"Source",
+		"Destination",
+		"Status",
+		"Sent",
+		"Received",
+		"Completed",
+		"Latency",
+	}
+
+	rows := make([][]string, len(packets))
+	for i, pres := range packets {
+		rows[i] = []string{
+			pres.Sequence(),
+			pres.Source(),
+			pres.Destination(),
+			pres.Status(),
+			pres.Sent(),
+			pres.Received(),
+			pres.Completed(),
+			pres.Latency(),
+		}
+	}
+
+	title := fmt.Sprintf("%s IBC Packets [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return &packetsView{
+		Table:   detailTableView(title, headers, rows),
+		Packets: packets,
+	}
+}
+
+// packetDetailView shows the timeline of one packet per page, starting at the selected one.
+func packetDetailView(packets []presenter.Packet, selected int) *tview.Pages {
+	pages := tview.NewPages()
+	for i, pres := range packets {
+		textView := tview.NewTextView().
+			SetText(pres.Detail()).
+			SetTextColor(textColor).
+			SetWrap(true).
+			SetWordWrap(true).
+			SetTextAlign(tview.AlignLeft).
+			SetScrollable(true)
+
+		textView.SetBorder(true).
+			SetBorderPadding(0, 0, 1, 1).
+			SetBorderAttributes(tcell.AttrDim)
+
+		textView.SetTitle(fmt.Sprintf("%s #%d [Packet %d of %d]", pres.Source(), pres.Result.Sequence, i+1, len(packets)))
+
+		pages.AddPage(strconv.Itoa(i), textView, true, false)
+	}
+	pages.SwitchToPage(strconv.Itoa(selected))
+	return pages
+}
+
+// sqlView runs ad-hoc queries, showing their results in a table.
+type sqlView struct {
+	*tview.Flex
+
+	Input   *tview.InputField
+	Results *tview.Table
+}
+
+func newSQLView(query string) *sqlView {
+	view := &sqlView{
+		Input:   tview.NewInputField().SetText(query),
+		Results: detailTableView("Results", []string{"Result"}, nil),
+	}
+
+	view.Input.
+		SetFieldTextColor(searchActiveColor).
+		SetFieldBackgroundColor(backgroundColor)
+	view.Input.SetTitle("SQL").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderAttributes(tcell.AttrDim)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorder(false)
+	flex.AddItem(view.Input, 3, 1, true)
+	flex.AddItem(view.Results, 0, 9, false)
+
+	view.Flex = flex
+	return view
+}
+
+// SetResult shows the result of the query.
+func (view *sqlView) SetResult(res blockdb.SQLResult) {
+	headers := res.Columns
+	if len(headers) == 0 {
+		// E.g. a statement other than a query.
+		headers = []string{"Result"}
+	}
+
+	rows := make([][]string, len(res.Rows))
+	for i, row := range res.Rows {
+		rows[i] = make([]string, len(row))
+		for j, v := range row {
+			rows[i][j] = presenter.SQLValue(v)
+		}
+	}
+
+	setTableContent(view.Results, headers, rows)
+	view.Results.SetTitle(fmt.Sprintf("Results [%d rows]", len(rows)))
+	view.Results.ScrollToBeginning()
+}
+
+// ToggleFocus moves the focus between the query and its results.
+func (view *sqlView) ToggleFocus() {
+	if view.Results.HasFocus() {
+		view.Results.Blur()
+		view.Input.Focus(nil)
+		return
+	}
+	view.Input.Blur()
+	view.Results.Focus(nil)
+}
+
 func errorModalView(err error) *tview.Flex {
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("Error: %v", err)).
@@ -0,0 +1,352 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// BuildHashLabel is the label holding the hash of the sources and options an image was built from.
+// An existing image with a matching label is reused instead of being rebuilt.
+const BuildHashLabel = LabelPrefix + "build-hash"
+
+// Images are built at most once per process, even when requested concurrently by several chains.
+// Builds of different image references run concurrently.
+var (
+	builtImagesMu sync.Mutex
+	builtImages   = map[string]*builtImage{} // By image ref.
+)
+
+// builtImage serializes the builds of one image reference.
+type builtImage struct {
+	mu   sync.Mutex
+	hash string // Hash of the sources the image was last built from.
+}
+
+// builtImageFor returns the build state of the image reference, creating it if needed.
+func builtImageFor(ref string) *builtImage {
+	builtImagesMu.Lock()
+	defer builtImagesMu.Unlock()
+
+	bi, ok := builtImages[ref]
+	if !ok {
+		bi = &builtImage{}
+		builtImages[ref] = bi
+	}
+	return bi
+}
+
+// BuildImage builds image from its Build configuration and tags it as image.Ref(),
+// unless an image with that reference was already built from identical sources.
+// BuildImage returns an error if image has no Build configuration.
+func BuildImage(ctx context.Context, log *zap.Logger, cli *client.Client, image ibc.DockerImage) error {
+	if image.Build == nil {
+		return fmt.Errorf("image %s has no build configuration", image.Ref())
+	}
+	b := image.Build
+	ref := image.Ref()
+
+	contextDir, err := filepath.Abs(b.Context)
+	if err != nil {
+		return fmt.Errorf("build context %s: %w", b.Context, err)
+	}
+
+	excludes, err := readDockerignore(contextDir)
+	if err != nil {
+		return err
+	}
+
+	files, err := contextFiles(contextDir, excludes)
+	if err != nil {
+		return fmt.Errorf("build context %s: %w", contextDir, err)
+	}
+
+	hash, err := buildHash(contextDir, files, b)
+	if err != nil {
+		return fmt.Errorf("hash build context %s: %w", contextDir, err)
+	}
+
+	bi := builtImageFor(ref)
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	if bi.hash == hash {
+		return nil
+	}
+
+	if inspect, _, err := cli.ImageInspectWithRaw(ctx, ref); err == nil && inspect.Config != nil && inspect.Config.Labels[BuildHashLabel] == hash {
+		log.Info("Reusing image built from unchanged sources", zap.String("image", ref), zap.String("hash", hash))
+		bi.hash = hash
+		return nil
+	}
+
+	log.Info("Building image", zap.String("image", ref), zap.String("context", contextDir))
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeContextTar(pw, contextDir, files))
+	}()
+	defer pr.Close()
+
+	buildArgs := make(map[string]*string, len(b.Args))
+	for k, v := range b.Args {
+		v := v
+		buildArgs[k] = &v
+	}
+
+	res, err := cli.ImageBuild(ctx, pr, types.ImageBuildOptions{
+		Tags:        []string{ref},
+		Dockerfile:  b.Dockerfile,
+		Target:      b.Target,
+		BuildArgs:   buildArgs,
+		Labels:      map[string]string{BuildHashLabel: hash},
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return fmt.Errorf("build image %s: %w", ref, err)
+	}
+	defer res.Body.Close()
+
+	if err := readBuildOutput(res.Body, log.With(zap.String("image", ref))); err != nil {
+		return fmt.Errorf("build image %s: %w", ref, err)
+	}
+
+	bi.hash = hash
+	return nil
+}
+
+// readBuildOutput consumes the JSON message stream of an image build,
+// logging build output at debug level and returning the first reported error.
+func readBuildOutput(r io.Reader, log *zap.Logger) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Stream      string `json:"stream"`
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read build output: %w", err)
+		}
+
+		if msg.ErrorDetail.Message != "" {
+			return errors.New(msg.ErrorDetail.Message)
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+		if s := strings.TrimSpace(msg.Stream); s != "" {
+			log.Debug(s)
+		}
+	}
+}
+
+// readDockerignore returns the patterns in the .dockerignore file of contextDir, if any.
+func readDockerignore(contextDir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open .dockerignore: %w", err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, s.Err()
+}
+
+// isExcluded reports whether the slash-separated relative path matches the .dockerignore patterns.
+// As in docker, later patterns take precedence and a leading "!" re-includes paths.
+// A pattern also matches everything beneath a matching directory.
+// Unlike docker, the "**" wildcard is not supported.
+func isExcluded(rel string, patterns []string) bool {
+	excluded := false
+	for _, p := range patterns {
+		negate := strings.HasPrefix(p, "!")
+		p = strings.TrimPrefix(p, "!")
+		p = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(p)), "/")
+
+		if matchPathOrParent(p, rel) {
+			excluded = !negate
+		}
+	}
+	return excluded
+}
+
+func matchPathOrParent(pattern, rel string) bool {
+	for p := rel; p != "." && p != "/"; p = filepath.ToSlash(filepath.Dir(p)) {
+		if ok, _ := filepath.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// contextFiles returns the sorted, slash-separated relative paths of the files in contextDir
+// that are sent to the daemon.
+// The Dockerfile and .dockerignore are always included, as docker does.
+func contextFiles(contextDir string, excludes []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(contextDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(contextDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+
+		if isExcluded(rel, excludes) && rel != "Dockerfile" && rel != ".dockerignore" {
+			// Excluded directories may still contain re-included files, so only skip files.
+			if d.IsDir() && !hasNegation(excludes) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type().IsRegular() || d.Type()&os.ModeSymlink != 0 || d.IsDir() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func hasNegation(patterns []string) bool {
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHash hashes the build options and the names, modes, and contents of files.
+// Modification times are deliberately ignored so that a fresh checkout reuses a cached image.
+func buildHash(contextDir string, files []string, b *ibc.DockerBuild) (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "dockerfile=%s\ntarget=%s\n", b.Dockerfile, b.Target)
+	keys := make([]string, 0, len(b.Args))
+	for k := range b.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "arg %s=%s\n", k, b.Args[k])
+	}
+
+	for _, rel := range files {
+		path := filepath.Join(contextDir, filepath.FromSlash(rel))
+		fi, err := os.Lstat(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "file %s %o\n", rel, fi.Mode())
+
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "link %s\n", target)
+		case fi.Mode().IsRegular():
+			f, err := os.Open(path)
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(h, f)
+			_ = f.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeContextTar writes files, relative to contextDir, to w as an uncompressed tar archive.
+func writeContextTar(w io.Writer, contextDir string, files []string) error {
+	tw := tar.NewWriter(w)
+	for _, rel := range files {
+		path := filepath.Join(contextDir, filepath.FromSlash(rel))
+		fi, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		// Ownership on the host is meaningless inside the image.
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if fi.Mode().IsRegular() {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(tw, f)
+			_ = f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Close()
+}
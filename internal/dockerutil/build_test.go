@@ -0,0 +1,127 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+func TestIsExcluded(t *testing.T) {
+	patterns := []string{"*.log", "target", "docs/*.md", "!docs/README.md"}
+
+	for rel, want := range map[string]bool{
+		"main.go":          false,
+		"debug.log":        true,
+		"target":           true,
+		"target/release/x": true,
+		"docs/guide.md":    true,
+		"docs/README.md":   false,
+		"src/debug.log":    false,
+	} {
+		require.Equal(t, want, isExcluded(rel, patterns), rel)
+	}
+}
+
+func TestBuildContext(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(rel, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	writeFile("Dockerfile", "FROM busybox\nCOPY . /src\n")
+	writeFile(".dockerignore", "# comment\n\nDockerfile\nbuild\n")
+	writeFile("main.go", "package main\n")
+	writeFile("build/out.bin", "binary")
+
+	excludes, err := readDockerignore(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Dockerfile", "build"}, excludes)
+
+	files, err := contextFiles(dir, excludes)
+	require.NoError(t, err)
+	require.Equal(t, []string{".dockerignore", "Dockerfile", "main.go"}, files)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeContextTar(&buf, dir, files))
+	tr := tar.NewReader(&buf)
+	contents := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[hdr.Name] = string(b)
+	}
+	require.Equal(t, "package main\n", contents["main.go"])
+	require.Len(t, contents, 3)
+
+	b := &ibc.DockerBuild{Context: dir, Args: map[string]string{"VERSION": "1"}}
+	hash, err := buildHash(dir, files, b)
+	require.NoError(t, err)
+
+	// Modification times do not affect the hash.
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), past, past))
+	same, err := buildHash(dir, files, b)
+	require.NoError(t, err)
+	require.Equal(t, hash, same)
+
+	b.Args["VERSION"] = "2"
+	changed, err := buildHash(dir, files, b)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changed)
+
+	b.Args["VERSION"] = "1"
+	writeFile("main.go", "package main\n\nfunc main() {}\n")
+	changed, err = buildHash(dir, files, b)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changed)
+}
+
+func TestDockerImageRef_Build(t *testing.T) {
+	image := ibc.DockerImage{Build: &ibc.DockerBuild{Context: "../Mulberry/"}}
+	require.Equal(t, "interchaintest-local/mulberry:latest", image.Ref())
+
+	image.Repository, image.Version = "mulberry", "dev"
+	require.Equal(t, "mulberry:dev", image.Ref())
+}
+
+func TestDockerImageRepositoryAndTag_Build(t *testing.T) {
+	// NewImage requires a repository, which images built from source may leave empty.
+	image := ibc.DockerImage{Build: &ibc.DockerBuild{Context: "../Mulberry/"}}
+	repo, tag := image.RepositoryAndTag()
+	require.Equal(t, "interchaintest-local/mulberry", repo)
+	require.Equal(t, "latest", tag)
+
+	image.Repository, image.Version = "localhost:5000/mulberry", "dev"
+	repo, tag = image.RepositoryAndTag()
+	require.Equal(t, "localhost:5000/mulberry", repo)
+	require.Equal(t, "dev", tag)
+}
+
+func TestBuiltImageFor(t *testing.T) {
+	a := builtImageFor("interchaintest-local/a:latest")
+	require.Same(t, a, builtImageFor("interchaintest-local/a:latest"))
+
+	// A build in progress for one image must not block the build of another.
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b := builtImageFor("interchaintest-local/b:latest")
+	require.NotSame(t, a, b)
+	require.True(t, b.mu.TryLock())
+	b.mu.Unlock()
+}
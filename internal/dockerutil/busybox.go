@@ -16,41 +16,49 @@ import (
 //
 // A mutex allows for retries upon error, if we ever need that;
 // whereas a sync.Once would not be simple to retry.
+//
+// Presence is tracked per reference, as the helper image depends on the ContainerRuntime.
 var (
 	ensureBusyboxMu sync.Mutex
-	hasBusybox      bool
+	hasBusybox      = map[string]bool{}
 )
 
-const busyboxRef = "busybox:stable"
+// ensureBusybox pulls the ContainerRuntime's helper image if necessary,
+// and returns its reference.
+func ensureBusybox(ctx context.Context, cli *client.Client) (string, error) {
+	rt, err := ContainerRuntime()
+	if err != nil {
+		return "", err
+	}
+	busyboxRef := rt.HelperImage()
 
-func ensureBusybox(ctx context.Context, cli *client.Client) error {
 	ensureBusyboxMu.Lock()
 	defer ensureBusyboxMu.Unlock()
 
-	if hasBusybox {
-		return nil
+	if hasBusybox[busyboxRef] {
+		return busyboxRef, nil
 	}
 
 	images, err := cli.ImageList(ctx, types.ImageListOptions{
 		Filters: filters.NewArgs(filters.Arg("reference", busyboxRef)),
 	})
 	if err != nil {
-		return fmt.Errorf("listing images to check busybox presence: %w", err)
+		return "", fmt.Errorf("listing images to check busybox presence: %w", err)
 	}
 
 	if len(images) > 0 {
-		hasBusybox = true
-		return nil
+		hasBusybox[busyboxRef] = true
+		return busyboxRef, nil
 	}
 
 	rc, err := cli.ImagePull(ctx, busyboxRef, types.ImagePullOptions{})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, _ = io.Copy(io.Discard, rc)
 	_ = rc.Close()
 
-	hasBusybox = true
-	return nil
+	hasBusybox[busyboxRef] = true
+	return busyboxRef, nil
 }
@@ -24,6 +24,7 @@ type ContainerLifecycle struct {
 	containerName     string
 	id                string
 	preStartListeners Listeners
+	resources         container.Resources
 }
 
 func NewContainerLifecycle(log *zap.Logger, client *dockerclient.Client, containerName string) *ContainerLifecycle {
@@ -34,6 +35,12 @@ func NewContainerLifecycle(log *zap.Logger, client *dockerclient.Client, contain
 	}
 }
 
+// SetResourceLimits configures the CPU, memory, and process limits
+// applied to the container on the next call to CreateContainer.
+func (c *ContainerLifecycle) SetResourceLimits(limits *ibc.ResourceLimits) {
+	c.resources = ContainerResources(limits)
+}
+
 func (c *ContainerLifecycle) CreateContainerWithMounts(
 	ctx context.Context,
 	testName string,
@@ -82,6 +89,7 @@ func (c *ContainerLifecycle) CreateContainerWithMounts(
 			AutoRemove:      false,
 			DNS:             []string{},
 			Mounts:          mounts,
+			Resources:       c.resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -145,6 +153,7 @@ func (c *ContainerLifecycle) CreateContainer(
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       c.resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -212,6 +221,11 @@ func (c *ContainerLifecycle) ContainerID() string {
 	return c.id
 }
 
+// Stats returns a single sample of the container's current resource usage.
+func (c *ContainerLifecycle) Stats(ctx context.Context) (ContainerStats, error) {
+	return GetContainerStats(ctx, c.client, c.id)
+}
+
 func (c *ContainerLifecycle) GetHostPorts(ctx context.Context, portIDs ...string) ([]string, error) {
 	cjson, err := c.client.ContainerInspect(ctx, c.id)
 	if err != nil {
@@ -0,0 +1,99 @@
+package dockerutil
+
+import (
+	"sync"
+	"time"
+)
+
+// ExecReporter receives every command run to completion by an Image of a test,
+// such as the CLI transactions and queries of chain nodes.
+// It is satisfied by *testreporter.ExecReporter.
+type ExecReporter interface {
+	TrackExec(
+		containerName, image string,
+		command []string,
+		stdout, stderr string,
+		exitCode int,
+		startedAt, finishedAt time.Time,
+		err error,
+	)
+}
+
+// execReporterEntry gives each registration a distinct identity,
+// so that the same reporter may be registered more than once.
+type execReporterEntry struct {
+	rep ExecReporter
+}
+
+var execReporters = struct {
+	mu sync.Mutex
+	m  map[string][]*execReporterEntry
+}{m: make(map[string][]*execReporterEntry)}
+
+// RegisterExecReporter reports the commands run by every Image created for testName to rep,
+// until the returned function is called.
+//
+// Images are created deep within chain and relayer implementations,
+// so the reporter is looked up by the test name rather than passed to each Image.
+func RegisterExecReporter(testName string, rep ExecReporter) (unregister func()) {
+	execReporters.mu.Lock()
+	defer execReporters.mu.Unlock()
+	e := &execReporterEntry{rep: rep}
+	execReporters.m[testName] = append(execReporters.m[testName], e)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			execReporters.mu.Lock()
+			defer execReporters.mu.Unlock()
+
+			entries := execReporters.m[testName]
+			for i, other := range entries {
+				if other == e {
+					// Copy rather than shift in place, as trackExec may be ranging over the old slice.
+					entries = append(entries[:i:i], entries[i+1:]...)
+					break
+				}
+			}
+			if len(entries) == 0 {
+				delete(execReporters.m, testName)
+			} else {
+				execReporters.m[testName] = entries
+			}
+		})
+	}
+}
+
+// TrackExec reports a command that does not run in an Image, such as a transaction submitted over RPC,
+// to every ExecReporter registered for testName.
+func TrackExec(
+	testName, containerName, image string,
+	cmd []string,
+	res ContainerExecResult,
+	startedAt, finishedAt time.Time,
+) {
+	trackExec(testName, containerName, image, cmd, res, startedAt, finishedAt)
+}
+
+// trackExec reports the command to every ExecReporter registered for testName.
+func trackExec(
+	testName, containerName, image string,
+	cmd []string,
+	res ContainerExecResult,
+	startedAt, finishedAt time.Time,
+) {
+	execReporters.mu.Lock()
+	entries := execReporters.m[testName]
+	execReporters.mu.Unlock()
+
+	for _, e := range entries {
+		e.rep.TrackExec(
+			containerName, image,
+			cmd,
+			string(res.Stdout), string(res.Stderr),
+			res.ExitCode,
+			startedAt, finishedAt,
+			res.Err,
+		)
+	}
+}
@@ -0,0 +1,88 @@
+package dockerutil
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type trackedExec struct {
+	ContainerName, Image string
+	Command              []string
+	Stdout, Stderr       string
+	ExitCode             int
+	Err                  error
+}
+
+type mockExecReporter struct {
+	mu    sync.Mutex
+	execs []trackedExec
+}
+
+func (r *mockExecReporter) TrackExec(
+	containerName, image string,
+	command []string,
+	stdout, stderr string,
+	exitCode int,
+	_, _ time.Time,
+	err error,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.execs = append(r.execs, trackedExec{
+		ContainerName: containerName,
+		Image:         image,
+		Command:       command,
+		Stdout:        stdout,
+		Stderr:        stderr,
+		ExitCode:      exitCode,
+		Err:           err,
+	})
+}
+
+func (r *mockExecReporter) Execs() []trackedExec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]trackedExec(nil), r.execs...)
+}
+
+func TestRegisterExecReporter(t *testing.T) {
+	t.Parallel()
+
+	testName := t.Name()
+	res := ContainerExecResult{Stdout: []byte("out"), Stderr: []byte("err"), ExitCode: 1, Err: errors.New("boom")}
+	now := time.Now()
+
+	var first, second mockExecReporter
+	unregisterFirst := RegisterExecReporter(testName, &first)
+	unregisterSecond := RegisterExecReporter(testName, &second)
+
+	trackExec(testName, "c1", "busybox:stable", []string{"echo", "1"}, res, now, now)
+	trackExec(testName+"/other", "c2", "busybox:stable", []string{"echo", "2"}, res, now, now)
+
+	want := trackedExec{
+		ContainerName: "c1",
+		Image:         "busybox:stable",
+		Command:       []string{"echo", "1"},
+		Stdout:        "out",
+		Stderr:        "err",
+		ExitCode:      1,
+		Err:           res.Err,
+	}
+	require.Equal(t, []trackedExec{want}, first.Execs())
+	require.Equal(t, []trackedExec{want}, second.Execs())
+
+	unregisterFirst()
+	unregisterFirst() // Unregistering twice must not remove the other reporter.
+
+	trackExec(testName, "c3", "busybox:stable", []string{"echo", "3"}, res, now, now)
+	require.Len(t, first.Execs(), 1)
+	require.Len(t, second.Execs(), 2)
+
+	unregisterSecond()
+	trackExec(testName, "c4", "busybox:stable", []string{"echo", "4"}, res, now, now)
+	require.Len(t, second.Execs(), 2)
+}
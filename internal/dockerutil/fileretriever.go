@@ -32,9 +32,43 @@ func NewFileRetriever(log *zap.Logger, cli *client.Client, testName string) *Fil
 // SingleFileContent returns the content of the file named at relPath,
 // inside the volume specified by volumeName.
 func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPath string) ([]byte, error) {
-	const mountPath = "/mnt/dockervolume"
+	rc, err := r.copyFromVolume(ctx, volumeName, relPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	wantPath := path.Base(relPath)
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tar from container: %w", err)
+		}
+		if hdr.Name != wantPath {
+			r.log.Debug("Unexpected path", zap.String("want", relPath), zap.String("got", hdr.Name))
+			continue
+		}
+
+		return io.ReadAll(tr)
+	}
+
+	return nil, fmt.Errorf("path %q not found in tar from container", relPath)
+}
 
-	if err := ensureBusybox(ctx, r.cli); err != nil {
+const fileRetrieverMountPath = "/mnt/dockervolume"
+
+// copyFromVolume returns the tar stream of relPath in the volume,
+// named after the base of relPath as with docker cp.
+// The container used to read the volume is removed when the stream is closed.
+func (r *FileRetriever) copyFromVolume(ctx context.Context, volumeName, relPath string) (io.ReadCloser, error) {
+	busyboxRef, err := ensureBusybox(ctx, r.cli)
+	if err != nil {
 		return nil, err
 	}
 
@@ -51,7 +85,7 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 			Labels: map[string]string{CleanupLabel: r.testName},
 		},
 		&container.HostConfig{
-			Binds:      []string{volumeName + ":" + mountPath},
+			Binds:      []string{volumeName + ":" + fileRetrieverMountPath},
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
@@ -62,39 +96,31 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 		return nil, fmt.Errorf("creating container: %w", err)
 	}
 
-	defer func() {
+	remove := func() {
 		if err := r.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
 			Force: true,
 		}); err != nil {
 			r.log.Warn("Failed to remove file content container", zap.String("container_id", cc.ID), zap.Error(err))
 		}
-	}()
+	}
 
-	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, path.Join(mountPath, relPath))
+	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, path.Join(fileRetrieverMountPath, relPath))
 	if err != nil {
+		remove()
 		return nil, fmt.Errorf("copying from container: %w", err)
 	}
-	defer func() {
-		_ = rc.Close()
-	}()
 
-	wantPath := path.Base(relPath)
-	tr := tar.NewReader(rc)
-	for {
-		hdr, err := tr.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("reading tar from container: %w", err)
-		}
-		if hdr.Name != wantPath {
-			r.log.Debug("Unexpected path", zap.String("want", relPath), zap.String("got", hdr.Name))
-			continue
-		}
+	return &removingReadCloser{ReadCloser: rc, remove: remove}, nil
+}
 
-		return io.ReadAll(tr)
-	}
+// removingReadCloser removes the container it reads from when closed.
+type removingReadCloser struct {
+	io.ReadCloser
+	remove func()
+}
 
-	return nil, fmt.Errorf("path %q not found in tar from container", relPath)
+func (rc *removingReadCloser) Close() error {
+	err := rc.ReadCloser.Close()
+	rc.remove()
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -30,9 +31,42 @@ func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWr
 
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
-	const mountPath = "/mnt/dockervolume"
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: relPath,
+
+		Size: int64(len(content)),
+		Mode: 0600,
+		// Not setting uname because the container will chown it anyway.
+
+		ModTime: time.Now(),
+
+		Format: tar.FormatPAX,
+	}); err != nil {
+		return fmt.Errorf("writing tar header: %w", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("writing content to tar: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+
+	return w.copyToVolume(ctx, volumeName, fileWriterMountPath, &buf,
+		// Take the uid and gid of the mount path,
+		// and set that as the owner of the new relative path.
+		`chown -R "$(stat -c '%u:%g' "$1")" "$1"`,
+	)
+}
 
-	if err := ensureBusybox(ctx, w.cli); err != nil {
+const fileWriterMountPath = "/mnt/dockervolume"
+
+// copyToVolume copies the tar stream to dstPath, in a container with the volume mounted at fileWriterMountPath,
+// then runs the script in the container, with the mount path as $1.
+func (w *FileWriter) copyToVolume(ctx context.Context, volumeName, dstPath string, tarStream io.Reader, script string) error {
+	busyboxRef, err := ensureBusybox(ctx, w.cli)
+	if err != nil {
 		return err
 	}
 
@@ -45,12 +79,9 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 
 			Entrypoint: []string{"sh", "-c"},
 			Cmd: []string{
-				// Take the uid and gid of the mount path,
-				// and set that as the owner of the new relative path.
-				`chown -R "$(stat -c '%u:%g' "$1")" "$2"`,
+				script,
 				"_", // Meaningless arg0 for sh -c with positional args.
-				mountPath,
-				mountPath,
+				fileWriterMountPath,
 			},
 
 			// Use root user to avoid permission issues when reading files from the volume.
@@ -59,7 +90,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			Labels: map[string]string{CleanupLabel: w.testName},
 		},
 		&container.HostConfig{
-			Binds:      []string{volumeName + ":" + mountPath},
+			Binds:      []string{volumeName + ":" + fileWriterMountPath},
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
@@ -84,33 +115,11 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 	}()
 
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	if err := tw.WriteHeader(&tar.Header{
-		Name: relPath,
-
-		Size: int64(len(content)),
-		Mode: 0600,
-		// Not setting uname because the container will chown it anyway.
-
-		ModTime: time.Now(),
-
-		Format: tar.FormatPAX,
-	}); err != nil {
-		return fmt.Errorf("writing tar header: %w", err)
-	}
-	if _, err := tw.Write(content); err != nil {
-		return fmt.Errorf("writing content to tar: %w", err)
-	}
-	if err := tw.Close(); err != nil {
-		return fmt.Errorf("closing tar writer: %w", err)
-	}
-
 	if err := w.cli.CopyToContainer(
 		ctx,
 		cc.ID,
-		mountPath,
-		&buf,
+		dstPath,
+		tarStream,
 		types.CopyToContainerOptions{},
 	); err != nil {
 		return fmt.Errorf("copying tar to container: %w", err)
@@ -134,7 +143,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 
 		if res.StatusCode != 0 {
-			return fmt.Errorf("chown on new file exited %d", res.StatusCode)
+			return fmt.Errorf("write-file script exited %d", res.StatusCode)
 		}
 	}
 
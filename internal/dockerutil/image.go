@@ -89,6 +89,14 @@ type ContainerOptions struct {
 
 	// If non-zero, will limit the amount of log lines returned.
 	LogTail uint64
+
+	// CPU, memory, and process limits for the container.
+	// The zero value leaves the container unrestricted.
+	Resources container.Resources
+
+	// If true, Run does not report the command to the ExecReporters registered for the test,
+	// e.g. because the caller reports it itself.
+	SkipExecReport bool
 }
 
 // ContainerExecResult is a wrapper type that wraps an exit code and associated output from stderr & stdout, along with
@@ -103,7 +111,18 @@ type ContainerExecResult struct {
 //
 // Run blocks until the command completes. Thus, Run is not suitable for daemons or servers. Use Start instead.
 // A non-zero status code returns an error.
-func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions) ContainerExecResult {
+//
+// Unless opts.SkipExecReport is set, the command and its result are reported
+// to the ExecReporters registered for the image's test name.
+func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions) (res ContainerExecResult) {
+	var containerName string
+	if !opts.SkipExecReport {
+		startedAt := time.Now()
+		defer func() {
+			trackExec(image.testName, containerName, image.imageRef(), cmd, res, startedAt, time.Now())
+		}()
+	}
+
 	c, err := image.Start(ctx, cmd, opts)
 	if err != nil {
 		return ContainerExecResult{
@@ -113,6 +132,7 @@ func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions
 			Stderr:   nil,
 		}
 	}
+	containerName = c.Name
 	return c.Wait(ctx, opts.LogTail)
 }
 
@@ -175,6 +195,7 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 			Binds:           opts.Binds,
 			PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
 			AutoRemove:      false,
+			Resources:       opts.Resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -125,6 +125,25 @@ echo -n hi from stderr >> /dev/stderr
 			_ = image.Run(ctx, nil, ContainerOptions{})
 		})
 	})
+
+	t.Run("exec reporter", func(t *testing.T) {
+		// The image belongs to the parent test, so register the reporter under its name.
+		var rep mockExecReporter
+		unregister := RegisterExecReporter(image.testName, &rep)
+		defer unregister()
+
+		res := image.Run(ctx, []string{"echo", "-n", "reported"}, ContainerOptions{})
+		require.NoError(t, res.Err)
+		res = image.Run(ctx, []string{"echo", "-n", "skipped"}, ContainerOptions{SkipExecReport: true})
+		require.NoError(t, res.Err)
+
+		execs := rep.Execs()
+		require.Len(t, execs, 1)
+		require.Equal(t, []string{"echo", "-n", "reported"}, execs[0].Command)
+		require.Equal(t, "reported", execs[0].Stdout)
+		require.Equal(t, testDockerImage+":"+testDockerTag, execs[0].Image)
+		require.NotEmpty(t, execs[0].ContainerName)
+	})
 }
 
 func TestContainer(t *testing.T) {
@@ -0,0 +1,408 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"go.uber.org/zap"
+)
+
+// LogLine is a single line written by a container to stdout or stderr.
+type LogLine struct {
+	ContainerName string
+	Stream        string // "stdout" or "stderr".
+	When          time.Time
+	Text          string
+}
+
+// LogViolation records a log line that matched a pattern passed to (*LogCollector).AssertNoLog.
+type LogViolation struct {
+	Pattern string
+	Line    LogLine
+}
+
+func (v LogViolation) String() string {
+	return fmt.Sprintf("container %s logged %q, matching forbidden pattern %q", v.Line.ContainerName, v.Line.Text, v.Pattern)
+}
+
+// ContainerLogReporter receives every line collected by a LogCollector.
+// It is satisfied by *testreporter.ContainerLogReporter.
+type ContainerLogReporter interface {
+	TrackContainerLog(containerName, stream string, when time.Time, line string)
+}
+
+// DefaultMaxLogLines is the number of most recent lines a LogCollector keeps in memory by default.
+const DefaultMaxLogLines = 100_000
+
+// LogCollector streams the stdout and stderr of every container created for a test,
+// i.e. every container labeled with CleanupLabel for the test name,
+// into one file per container.
+// The most recent lines are also kept in memory so that tests can wait for, or forbid, particular output.
+type LogCollector struct {
+	log *zap.Logger
+	cli *client.Client
+
+	testName string
+	dir      string
+	rep      ContainerLogReporter
+
+	mu         sync.Mutex
+	lines      []LogLine
+	maxLines   int
+	dropped    int           // Number of lines discarded from the front of lines.
+	newLine    chan struct{} // Closed and replaced whenever a line is added.
+	following  map[string]bool
+	lastSeen   map[string]time.Time
+	files      map[string]*os.File
+	forbidden  []*regexp.Regexp
+	violations []LogViolation
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewLogCollector returns a LogCollector that writes one file per container into dir,
+// creating dir if necessary. Files left in dir by a previous run are truncated.
+// If rep is not nil, every collected line is also passed to rep.
+func NewLogCollector(log *zap.Logger, cli *client.Client, testName, dir string, rep ContainerLogReporter) (*LogCollector, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create log directory %s: %w", dir, err)
+	}
+
+	return &LogCollector{
+		log:      log,
+		cli:      cli,
+		testName: testName,
+		dir:      dir,
+		rep:      rep,
+
+		maxLines:  DefaultMaxLogLines,
+		newLine:   make(chan struct{}),
+		following: make(map[string]bool),
+		lastSeen:  make(map[string]time.Time),
+		files:     make(map[string]*os.File),
+	}, nil
+}
+
+// Dir returns the directory containing the per-container log files.
+func (c *LogCollector) Dir() string {
+	return c.dir
+}
+
+// SetMaxLines sets the number of most recent lines kept in memory, which must be positive.
+// Older lines are still written to the log files and checked against AssertNoLog patterns,
+// but are no longer returned by Lines or matched by WaitForLog.
+func (c *LogCollector) SetMaxLines(n int) {
+	if n <= 0 {
+		panic(fmt.Errorf("max log lines must be positive, got %d", n))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxLines = n
+	c.trimLines()
+}
+
+// trimLines discards the oldest lines beyond maxLines. c.mu must be held.
+func (c *LogCollector) trimLines() {
+	if n := len(c.lines) - c.maxLines; n > 0 {
+		// Reslicing is amortized by append, which copies only the retained lines when it grows.
+		c.lines = c.lines[n:]
+		c.dropped += n
+	}
+}
+
+// Start begins discovering and following the test's containers in the background.
+// Call Stop to end collection.
+func (c *LogCollector) Start(ctx context.Context) {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	ctx = c.ctx
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			c.discover(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends collection, waits for all log streams to finish, and closes the log files.
+func (c *LogCollector) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, f := range c.files {
+		if err := f.Close(); err != nil {
+			c.log.Info("Failed to close container log file", zap.String("container", name), zap.Error(err))
+		}
+	}
+	c.files = map[string]*os.File{}
+}
+
+// Follow streams the logs of the container with the given ID,
+// which is useful for containers that were not created by interchaintest.
+// Following stops when the container exits or when the collector is stopped.
+func (c *LogCollector) Follow(ctx context.Context, containerID string) error {
+	if c.ctx == nil || c.ctx.Err() != nil {
+		return fmt.Errorf("log collector is not running")
+	}
+
+	cjson, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspect container %s: %w", containerID, err)
+	}
+
+	c.follow(c.ctx, cjson.ID, strings.TrimPrefix(cjson.Name, "/"))
+	return nil
+}
+
+// discover follows any running container of the test that is not yet being followed.
+func (c *LogCollector) discover(ctx context.Context) {
+	cs, err := c.cli.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", CleanupLabel+"="+c.testName),
+			filters.Arg("status", "running"),
+		),
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.Info("Failed to list containers for log collection", zap.Error(err))
+		}
+		return
+	}
+
+	for _, ct := range cs {
+		name := ct.ID
+		if len(ct.Names) > 0 {
+			name = strings.TrimPrefix(ct.Names[0], "/")
+		}
+		c.follow(ctx, ct.ID, name)
+	}
+}
+
+func (c *LogCollector) follow(ctx context.Context, containerID, name string) {
+	c.mu.Lock()
+	if c.following[containerID] {
+		c.mu.Unlock()
+		return
+	}
+	c.following[containerID] = true
+	since := c.lastSeen[containerID]
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			// Allow the container to be followed again if it is restarted.
+			c.mu.Lock()
+			delete(c.following, containerID)
+			c.mu.Unlock()
+		}()
+
+		opts := types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+			Timestamps: true,
+		}
+		if !since.IsZero() {
+			// Since has second granularity on older daemons, so lines may repeat after a restart.
+			opts.Since = since.Add(time.Nanosecond).Format(time.RFC3339Nano)
+		}
+
+		rc, err := c.cli.ContainerLogs(ctx, containerID, opts)
+		if err != nil {
+			if ctx.Err() == nil {
+				c.log.Info("Failed to follow container logs", zap.String("container", name), zap.Error(err))
+			}
+			return
+		}
+		defer func() { _ = rc.Close() }()
+
+		stdout := &lineWriter{emit: func(s string) { c.addLine(containerID, name, "stdout", s) }}
+		stderr := &lineWriter{emit: func(s string) { c.addLine(containerID, name, "stderr", s) }}
+
+		// Logs are multiplexed into one stream; see docs for ContainerLogs.
+		if _, err := stdcopy.StdCopy(stdout, stderr, rc); err != nil && ctx.Err() == nil {
+			c.log.Debug("Container log stream ended", zap.String("container", name), zap.Error(err))
+		}
+		stdout.Flush()
+		stderr.Flush()
+	}()
+}
+
+// addLine records a raw line, which is prefixed with a timestamp because logs are requested with Timestamps.
+func (c *LogCollector) addLine(containerID, name, stream, raw string) {
+	when := time.Now()
+	text := raw
+	if ts, rest, ok := strings.Cut(raw, " "); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			when, text = t, rest
+		}
+	}
+
+	line := LogLine{
+		ContainerName: name,
+		Stream:        stream,
+		When:          when,
+		Text:          text,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastSeen[containerID] = when
+	c.lines = append(c.lines, line)
+	c.trimLines()
+	for _, re := range c.forbidden {
+		if re.MatchString(text) {
+			c.violations = append(c.violations, LogViolation{Pattern: re.String(), Line: line})
+		}
+	}
+
+	if f, err := c.file(name); err == nil {
+		_, _ = fmt.Fprintf(f, "%s %s %s\n", when.Format(time.RFC3339Nano), stream, text)
+	} else {
+		c.log.Info("Failed to open container log file", zap.String("container", name), zap.Error(err))
+	}
+
+	if c.rep != nil {
+		c.rep.TrackContainerLog(name, stream, when, text)
+	}
+
+	close(c.newLine)
+	c.newLine = make(chan struct{})
+}
+
+// file returns the log file for the named container. c.mu must be held.
+func (c *LogCollector) file(name string) (*os.File, error) {
+	if f, ok := c.files[name]; ok {
+		return f, nil
+	}
+
+	f, err := os.OpenFile(filepath.Join(c.dir, SanitizeContainerName(name)+".log"), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	c.files[name] = f
+	return f, nil
+}
+
+// WaitForLog blocks until a container whose name contains containerName
+// logs a line matching re, and returns that line.
+// An empty containerName matches every container.
+// Lines logged before WaitForLog was called are also considered, if they are still kept in memory.
+func (c *LogCollector) WaitForLog(ctx context.Context, containerName string, re *regexp.Regexp) (LogLine, error) {
+	seen := 0 // Number of lines considered, including those dropped since.
+	for {
+		c.mu.Lock()
+		start := seen - c.dropped
+		if start < 0 {
+			start = 0
+		}
+		for _, line := range c.lines[start:] {
+			if strings.Contains(line.ContainerName, containerName) && re.MatchString(line.Text) {
+				c.mu.Unlock()
+				return line, nil
+			}
+		}
+		seen = c.dropped + len(c.lines)
+		wait := c.newLine
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return LogLine{}, fmt.Errorf("waiting for log matching %q from container %q: %w", re.String(), containerName, ctx.Err())
+		case <-wait:
+		}
+	}
+}
+
+// AssertNoLog records a violation for every line, already logged or logged in the future,
+// that matches re. Use Violations to retrieve them.
+func (c *LogCollector) AssertNoLog(re *regexp.Regexp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.forbidden = append(c.forbidden, re)
+	for _, line := range c.lines {
+		if re.MatchString(line.Text) {
+			c.violations = append(c.violations, LogViolation{Pattern: re.String(), Line: line})
+		}
+	}
+}
+
+// Violations returns the lines that matched a pattern passed to AssertNoLog.
+func (c *LogCollector) Violations() []LogViolation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]LogViolation(nil), c.violations...)
+}
+
+// Lines returns the lines kept in memory from containers whose name contains containerName.
+func (c *LogCollector) Lines(containerName string) []LogLine {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var out []LogLine
+	for _, line := range c.lines {
+		if strings.Contains(line.ContainerName, containerName) {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// lineWriter is an io.Writer that calls emit for every complete line written to it.
+type lineWriter struct {
+	buf  bytes.Buffer
+	emit func(string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf.Next(i + 1))
+		w.emit(strings.TrimRight(line, "\r\n"))
+	}
+}
+
+// Flush emits any trailing partial line.
+func (w *lineWriter) Flush() {
+	if w.buf.Len() > 0 {
+		w.emit(w.buf.String())
+		w.buf.Reset()
+	}
+}
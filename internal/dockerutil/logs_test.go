@@ -0,0 +1,139 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLineWriter(t *testing.T) {
+	var got []string
+	w := &lineWriter{emit: func(s string) { got = append(got, s) }}
+
+	_, err := w.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"first"}, got)
+
+	_, err = w.Write([]byte("ond\r\nthird"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, got)
+
+	w.Flush()
+	require.Equal(t, []string{"first", "second", "third"}, got)
+}
+
+func TestLogCollector(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewLogCollector(zap.NewNop(), nil, t.Name(), dir, nil)
+	require.NoError(t, err)
+
+	c.AssertNoLog(regexp.MustCompile(`panic`))
+
+	ts := time.Date(2023, 5, 1, 12, 0, 0, 123, time.UTC)
+	c.addLine("id1", "gaia-1-val-0-TestFoo", "stdout", ts.Format(time.RFC3339Nano)+" committed state height=5")
+	c.addLine("id2", "rly-TestFoo", "stderr", "no timestamp")
+
+	lines := c.Lines("gaia")
+	require.Len(t, lines, 1)
+	require.Equal(t, LogLine{
+		ContainerName: "gaia-1-val-0-TestFoo",
+		Stream:        "stdout",
+		When:          ts,
+		Text:          "committed state height=5",
+	}, lines[0])
+	require.Len(t, c.Lines(""), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Lines logged before the call are matched.
+	line, err := c.WaitForLog(ctx, "gaia", regexp.MustCompile(`height=\d+`))
+	require.NoError(t, err)
+	require.Equal(t, "committed state height=5", line.Text)
+
+	// Lines logged after the call are matched.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.addLine("id2", "rly-TestFoo", "stdout", "packet relayed")
+	}()
+	line, err = c.WaitForLog(ctx, "rly", regexp.MustCompile(`relayed`))
+	require.NoError(t, err)
+	require.Equal(t, "stdout", line.Stream)
+
+	shortCtx, shortCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancel()
+	_, err = c.WaitForLog(shortCtx, "gaia", regexp.MustCompile(`relayed`))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Empty(t, c.Violations())
+	c.addLine("id1", "gaia-1-val-0-TestFoo", "stderr", "panic: oops")
+	c.AssertNoLog(regexp.MustCompile(`no timestamp`))
+
+	violations := c.Violations()
+	require.Len(t, violations, 2)
+	require.Equal(t, "panic", violations[0].Pattern)
+	require.Equal(t, "panic: oops", violations[0].Line.Text)
+	require.Equal(t, "rly-TestFoo", violations[1].Line.ContainerName)
+
+	c.Stop()
+
+	b, err := os.ReadFile(filepath.Join(dir, "gaia-1-val-0-TestFoo.log"))
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(b), "\n"))
+	require.Contains(t, string(b), ts.Format(time.RFC3339Nano)+" stdout committed state height=5\n")
+}
+
+func TestLogCollector_MaxLines(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewLogCollector(zap.NewNop(), nil, t.Name(), dir, nil)
+	require.NoError(t, err)
+	c.SetMaxLines(3)
+	c.AssertNoLog(regexp.MustCompile(`line 0`))
+
+	for i := 0; i < 10; i++ {
+		c.addLine("id1", "gaia", "stdout", fmt.Sprintf("line %d", i))
+	}
+
+	var texts []string
+	for _, line := range c.Lines("") {
+		texts = append(texts, line.Text)
+	}
+	require.Equal(t, []string{"line 7", "line 8", "line 9"}, texts)
+
+	// Violations and files cover every line, including those no longer kept in memory.
+	require.Len(t, c.Violations(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.WaitForLog(ctx, "gaia", regexp.MustCompile(`line 1$`))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	c.Stop()
+
+	b, err := os.ReadFile(filepath.Join(dir, "gaia.log"))
+	require.NoError(t, err)
+	require.Equal(t, 10, strings.Count(string(b), "\n"))
+}
+
+func TestLogCollector_TruncatesPreviousRun(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gaia.log"), []byte("previous run\n"), 0644))
+
+	c, err := NewLogCollector(zap.NewNop(), nil, t.Name(), dir, nil)
+	require.NoError(t, err)
+	c.addLine("id1", "gaia", "stdout", "this run")
+	c.Stop()
+
+	b, err := os.ReadFile(filepath.Join(dir, "gaia.log"))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "previous run")
+	require.Contains(t, string(b), "this run")
+}
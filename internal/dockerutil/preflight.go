@@ -0,0 +1,262 @@
+package dockerutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// ImageError describes an image that could not be made available.
+type ImageError struct {
+	Ref string
+	Err error
+}
+
+func (e ImageError) Error() string {
+	return e.Ref + ": " + e.Err.Error()
+}
+
+func (e ImageError) Unwrap() error {
+	return e.Err
+}
+
+// ImageErrors is the aggregated error returned by EnsureImages.
+type ImageErrors []ImageError
+
+func (e ImageErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d image(s) unavailable:", len(e))
+	for _, ie := range e {
+		b.WriteString("\n\t")
+		b.WriteString(ie.Error())
+	}
+	return b.String()
+}
+
+// ImagePreflightOptions configures EnsureImages.
+type ImagePreflightOptions struct {
+	// Allow images that have no manifest for the docker host's platform,
+	// which are then run under emulation if the host supports it.
+	AllowEmulation bool
+
+	// How often to log the progress of pulls. Defaults to 5 seconds.
+	ProgressInterval time.Duration
+}
+
+// EnsureImages makes every image available to the docker host before any container is created.
+// Images are built or pulled in parallel, and pulled images are checked against the host's platform.
+//
+// Every image is attempted, and any failures are returned together as ImageErrors.
+func EnsureImages(ctx context.Context, log *zap.Logger, cli *client.Client, images []ibc.DockerImage, opts ImagePreflightOptions) error {
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = 5 * time.Second
+	}
+
+	sv, err := cli.ServerVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("docker server version: %w", err)
+	}
+	host := sv.Os + "/" + sv.Arch
+
+	// The same image is commonly used by several chains.
+	unique := make(map[string]ibc.DockerImage, len(images))
+	for _, image := range images {
+		unique[image.Ref()] = image
+	}
+
+	var (
+		mu   sync.Mutex
+		errs ImageErrors
+		eg   errgroup.Group
+	)
+	for ref, image := range unique {
+		ref, image := ref, image
+		eg.Go(func() error {
+			var err error
+			if image.Build != nil {
+				err = BuildImage(ctx, log, cli, image)
+			} else {
+				err = ensurePulledForPlatform(ctx, log.With(zap.String("image", ref)), cli, ref, host, opts)
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, ImageError{Ref: ref, Err: err})
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Ref < errs[j].Ref })
+	return errs
+}
+
+// EnsureImage makes a single image available to the docker host: it is built if it has a Build configuration,
+// and pulled otherwise. A failed pull is tolerated if the image already exists locally, e.g. when offline.
+//
+// Unlike EnsureImages, the platform of the image is not checked.
+func EnsureImage(ctx context.Context, log *zap.Logger, cli *client.Client, image ibc.DockerImage) error {
+	if image.Build != nil {
+		return BuildImage(ctx, log, cli, image)
+	}
+
+	ref := image.Ref()
+	rc, err := cli.ImagePull(ctx, ref, types.ImagePullOptions{})
+	if err == nil {
+		err = readPullProgress(rc, log.With(zap.String("image", ref)), 5*time.Second)
+		_ = rc.Close()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if _, _, inspectErr := cli.ImageInspectWithRaw(ctx, ref); inspectErr == nil {
+		log.Warn("Failed to pull image, using local image", zap.String("image", ref), zap.Error(err))
+		return nil
+	}
+	return fmt.Errorf("pull %s: %w", ref, err)
+}
+
+// ensurePulledForPlatform pulls ref unless it already exists for the host platform,
+// and verifies the platform of the resulting image.
+func ensurePulledForPlatform(ctx context.Context, log *zap.Logger, cli *client.Client, ref, host string, opts ImagePreflightOptions) error {
+	if inspect, _, err := cli.ImageInspectWithRaw(ctx, ref); err == nil {
+		if opts.AllowEmulation || imagePlatform(inspect) == host {
+			return nil
+		}
+		// A local image for another platform may have been pulled on purpose, e.g. for emulation,
+		// but the registry may also have a manifest for the host; try to pull that one below.
+	}
+
+	pullOpts := types.ImagePullOptions{}
+	if dist, err := cli.DistributionInspect(ctx, ref, ""); err == nil {
+		var platforms []string
+		for _, p := range dist.Platforms {
+			platforms = append(platforms, p.OS+"/"+p.Architecture)
+		}
+		// Platforms is empty for single-platform images, which are checked after pulling.
+		switch {
+		case containsString(platforms, host):
+			pullOpts.Platform = host
+		case len(platforms) == 0:
+		case opts.AllowEmulation:
+			pullOpts.Platform = platforms[0]
+		default:
+			return fmt.Errorf("no manifest for docker host platform %s (available: %s)", host, strings.Join(platforms, ", "))
+		}
+	} else {
+		// Some registries do not support inspecting the distribution without credentials.
+		// The pull below reports whether the image exists; its platform is checked afterwards.
+		log.Debug("Failed to inspect image distribution", zap.Error(err))
+	}
+
+	start := time.Now()
+	log.Info("Pulling image", zap.String("platform", pullOpts.Platform))
+
+	rc, err := cli.ImagePull(ctx, ref, pullOpts)
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+	err = readPullProgress(rc, log, opts.ProgressInterval)
+	_ = rc.Close()
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("inspect after pull: %w", err)
+	}
+	if p := imagePlatform(inspect); p != host && !opts.AllowEmulation {
+		return fmt.Errorf("image is for platform %s, but docker host platform is %s", p, host)
+	}
+
+	log.Info("Pulled image", zap.Duration("elapsed", time.Since(start)))
+	return nil
+}
+
+func imagePlatform(inspect types.ImageInspect) string {
+	return inspect.Os + "/" + inspect.Architecture
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// readPullProgress consumes the JSON message stream of an image pull,
+// periodically logging the download progress and returning the first reported error.
+// Errors such as a missing manifest are only reported in the stream, not by ImagePull itself.
+func readPullProgress(r io.Reader, log *zap.Logger, interval time.Duration) error {
+	type layerProgress struct{ current, total int64 }
+	layers := map[string]layerProgress{}
+	lastLog := time.Now()
+
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			ID             string `json:"id"`
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read pull output: %w", err)
+		}
+
+		if msg.ErrorDetail.Message != "" {
+			return errors.New(msg.ErrorDetail.Message)
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+
+		if msg.Status == "Downloading" && msg.ID != "" {
+			layers[msg.ID] = layerProgress{current: msg.ProgressDetail.Current, total: msg.ProgressDetail.Total}
+		}
+
+		if time.Since(lastLog) >= interval {
+			var current, total int64
+			for _, l := range layers {
+				current += l.current
+				total += l.total
+			}
+			log.Info("Pulling image",
+				zap.Int64("downloaded_bytes", current),
+				zap.Int64("total_bytes", total),
+				zap.Int("layers", len(layers)),
+			)
+			lastLog = time.Now()
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package dockerutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReadPullProgress(t *testing.T) {
+	const ok = `{"status":"Pulling from library/busybox","id":"stable"}
+{"status":"Downloading","progressDetail":{"current":100,"total":200},"id":"abc"}
+{"status":"Download complete","id":"abc"}
+{"status":"Status: Downloaded newer image for busybox:stable"}
+`
+	require.NoError(t, readPullProgress(strings.NewReader(ok), zap.NewNop(), 0))
+
+	const failed = `{"status":"Pulling from foundry-rs/foundry","id":"latest"}
+{"errorDetail":{"message":"no matching manifest for linux/arm64/v8 in the manifest list entries"},"error":"no matching manifest for linux/arm64/v8 in the manifest list entries"}
+`
+	err := readPullProgress(strings.NewReader(failed), zap.NewNop(), 0)
+	require.EqualError(t, err, "no matching manifest for linux/arm64/v8 in the manifest list entries")
+}
+
+func TestImageErrors(t *testing.T) {
+	notFound := errors.New("manifest unknown")
+	var err error = ImageErrors{
+		{Ref: "ghcr.io/foundry-rs/foundry:latest", Err: errors.New("no manifest for docker host platform linux/arm64 (available: linux/amd64)")},
+		{Ref: "ghcr.io/strangelove-ventures/heighliner/gaia:v0", Err: notFound},
+	}
+
+	require.Equal(t, `2 image(s) unavailable:
+	ghcr.io/foundry-rs/foundry:latest: no manifest for docker host platform linux/arm64 (available: linux/amd64)
+	ghcr.io/strangelove-ventures/heighliner/gaia:v0: manifest unknown`, err.Error())
+
+	var ie ImageErrors
+	require.ErrorAs(t, err, &ie)
+	require.ErrorIs(t, ie[1], notFound)
+}
@@ -0,0 +1,29 @@
+package dockerutil
+
+import (
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// ContainerResources converts limits into the docker representation used in a container's HostConfig.
+// A nil limits value results in an unrestricted container.
+func ContainerResources(limits *ibc.ResourceLimits) container.Resources {
+	var r container.Resources
+	if limits == nil {
+		return r
+	}
+
+	if limits.CPUs > 0 {
+		r.NanoCPUs = int64(limits.CPUs * 1e9)
+	}
+	if limits.Memory > 0 {
+		r.Memory = limits.Memory
+	}
+	if limits.PidsLimit > 0 {
+		pids := limits.PidsLimit
+		r.PidsLimit = &pids
+	}
+
+	return r
+}
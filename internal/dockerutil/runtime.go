@@ -0,0 +1,287 @@
+package dockerutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/client"
+)
+
+// Runtime is a container engine that interchaintest drives through the Docker Engine API.
+// Podman is supported through its Docker-compatible API socket.
+type Runtime interface {
+	// Name returns the name of the runtime, e.g. "docker" or "podman".
+	Name() string
+
+	// NewClient returns a client connected to the runtime's API socket.
+	NewClient() (*client.Client, error)
+
+	// HelperImage returns the image reference used for one-off helper containers,
+	// such as those started by SetVolumeOwner, FileWriter, and FileRetriever.
+	HelperImage() string
+
+	// VolumeOwner returns the owner, in "uid:gid" form, to set on a volume
+	// so that containers running as uidGid may use it.
+	VolumeOwner(uidGid string) (string, error)
+}
+
+// ContainerRuntimeEnv is the environment variable used to select the container runtime,
+// either "docker" or "podman".
+// When unset, podman is only used if it is the only runtime with a reachable socket.
+const ContainerRuntimeEnv = "ICTEST_CONTAINER_RUNTIME"
+
+// containerRuntime is the runtime used by DockerSetup and by the helper containers in this package.
+//
+// It is resolved from the environment on first use rather than at init,
+// so that an invalid ContainerRuntimeEnv fails the tests that use containers, not every importer.
+var containerRuntime struct {
+	mu sync.Mutex
+	rt Runtime
+}
+
+// SetContainerRuntime sets the runtime used by DockerSetup and by the helper containers in this package.
+// A nil runtime restores the default, as determined from the environment by RuntimeFromEnv.
+//
+// Because dockerutil is an internal package, the public API for setting this value
+// is interchaintest.UseContainerRuntime(string).
+func SetContainerRuntime(rt Runtime) {
+	containerRuntime.mu.Lock()
+	defer containerRuntime.mu.Unlock()
+	containerRuntime.rt = rt
+}
+
+// ContainerRuntime returns the runtime set by SetContainerRuntime,
+// or else the runtime determined from the environment by RuntimeFromEnv.
+func ContainerRuntime() (Runtime, error) {
+	containerRuntime.mu.Lock()
+	defer containerRuntime.mu.Unlock()
+
+	if containerRuntime.rt != nil {
+		return containerRuntime.rt, nil
+	}
+
+	rt, err := RuntimeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	containerRuntime.rt = rt
+	return rt, nil
+}
+
+// NewRuntimeClient returns a client connected to the API socket of the ContainerRuntime.
+func NewRuntimeClient() (*client.Client, error) {
+	rt, err := ContainerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := rt.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", rt.Name(), err)
+	}
+	return cli, nil
+}
+
+// RuntimeFromEnv returns the runtime named by ContainerRuntimeEnv,
+// or detects the runtime if the variable is unset.
+func RuntimeFromEnv() (Runtime, error) {
+	if name := os.Getenv(ContainerRuntimeEnv); name != "" {
+		return RuntimeByName(name)
+	}
+
+	if strings.Contains(os.Getenv("DOCKER_HOST"), "podman") || os.Getenv("CONTAINER_HOST") != "" {
+		return NewPodmanRuntime(), nil
+	}
+
+	if os.Getenv("DOCKER_HOST") == "" && !socketExists("/var/run/docker.sock") {
+		if p := NewPodmanRuntime(); socketExists(strings.TrimPrefix(p.Host, "unix://")) {
+			return p, nil
+		}
+	}
+
+	return DockerRuntime{}, nil
+}
+
+// RuntimeByName returns the runtime with the given name, either "docker" or "podman".
+func RuntimeByName(name string) (Runtime, error) {
+	switch strings.ToLower(name) {
+	case "docker":
+		return DockerRuntime{}, nil
+	case "podman":
+		return NewPodmanRuntime(), nil
+	default:
+		return nil, fmt.Errorf("unknown container runtime %q (%s must be \"docker\" or \"podman\")", name, ContainerRuntimeEnv)
+	}
+}
+
+func socketExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode()&os.ModeSocket != 0
+}
+
+// DockerRuntime is the Docker Engine runtime.
+// The client is configured from the standard DOCKER_* environment variables.
+type DockerRuntime struct{}
+
+func (DockerRuntime) Name() string {
+	return "docker"
+}
+
+func (DockerRuntime) NewClient() (*client.Client, error) {
+	return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+}
+
+func (DockerRuntime) HelperImage() string {
+	return "busybox:stable"
+}
+
+func (DockerRuntime) VolumeOwner(uidGid string) (string, error) {
+	return uidGid, nil
+}
+
+// PodmanRuntime is the Podman runtime, reached through its Docker-compatible API socket.
+//
+// When rootless, container users are mapped into the invoking user's subordinate ID ranges.
+// Container root is the invoking user and every other ID is allocated from /etc/subuid and /etc/subgid,
+// so volume owners must fall inside those ranges.
+type PodmanRuntime struct {
+	// Host is the API socket, e.g. unix:///run/user/1000/podman/podman.sock.
+	Host string
+
+	Rootless bool
+
+	// Number of user and group IDs available inside the rootless user namespace,
+	// including root. Zero means unknown, in which case owners are not checked.
+	UIDs, GIDs uint64
+}
+
+// NewPodmanRuntime returns a PodmanRuntime for the current user.
+//
+// The socket is taken from CONTAINER_HOST, or from DOCKER_HOST,
+// falling back to the default rootless or rootful socket path.
+func NewPodmanRuntime() *PodmanRuntime {
+	p := &PodmanRuntime{Rootless: os.Geteuid() != 0}
+
+	switch {
+	case os.Getenv("CONTAINER_HOST") != "":
+		p.Host = os.Getenv("CONTAINER_HOST")
+	case os.Getenv("DOCKER_HOST") != "":
+		p.Host = os.Getenv("DOCKER_HOST")
+	case p.Rootless:
+		dir := os.Getenv("XDG_RUNTIME_DIR")
+		if dir == "" {
+			dir = filepath.Join("/run/user", strconv.Itoa(os.Getuid()))
+		}
+		p.Host = "unix://" + filepath.Join(dir, "podman", "podman.sock")
+	default:
+		p.Host = "unix:///run/podman/podman.sock"
+	}
+
+	if p.Rootless {
+		p.UIDs = subIDCount("/etc/subuid")
+		p.GIDs = subIDCount("/etc/subgid")
+	}
+
+	return p
+}
+
+func (p *PodmanRuntime) Name() string {
+	return "podman"
+}
+
+func (p *PodmanRuntime) NewClient() (*client.Client, error) {
+	// Podman implements an older API version than the client defaults to.
+	return client.NewClientWithOpts(client.WithHost(p.Host), client.WithAPIVersionNegotiation())
+}
+
+// HelperImage returns a fully qualified reference,
+// because podman may refuse to resolve short names without a terminal.
+func (p *PodmanRuntime) HelperImage() string {
+	return "docker.io/library/busybox:stable"
+}
+
+// VolumeOwner returns uidGid unchanged, because helper containers run in the same user namespace
+// as every other container, but it fails early when the IDs are not mapped into a rootless namespace.
+// Otherwise chown would fail with a much less helpful "invalid argument".
+func (p *PodmanRuntime) VolumeOwner(uidGid string) (string, error) {
+	if !p.Rootless {
+		return uidGid, nil
+	}
+
+	uid, gid, err := parseUidGid(uidGid)
+	if err != nil {
+		// Owners given by name cannot be checked.
+		return uidGid, nil
+	}
+	if p.UIDs > 0 && uid >= p.UIDs {
+		return "", fmt.Errorf("uid %d is outside the %d user IDs mapped into the rootless podman namespace; extend the range in /etc/subuid", uid, p.UIDs)
+	}
+	if p.GIDs > 0 && gid >= p.GIDs {
+		return "", fmt.Errorf("gid %d is outside the %d group IDs mapped into the rootless podman namespace; extend the range in /etc/subgid", gid, p.GIDs)
+	}
+
+	return uidGid, nil
+}
+
+// parseUidGid parses a numeric "uid:gid" or "uid" string.
+func parseUidGid(uidGid string) (uid, gid uint64, err error) {
+	u, g, ok := strings.Cut(uidGid, ":")
+	if !ok {
+		g = u
+	}
+	if uid, err = strconv.ParseUint(u, 10, 32); err != nil {
+		return 0, 0, fmt.Errorf("invalid uid in %q: %w", uidGid, err)
+	}
+	if gid, err = strconv.ParseUint(g, 10, 32); err != nil {
+		return 0, 0, fmt.Errorf("invalid gid in %q: %w", uidGid, err)
+	}
+	return uid, gid, nil
+}
+
+// subIDCount returns the number of IDs available to the current user in a rootless namespace
+// described by the subordinate ID file at path, or 0 if it cannot be determined.
+func subIDCount(path string) uint64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	u, err := user.Current()
+	if err != nil {
+		return 0
+	}
+
+	n := parseSubIDCount(f, u.Username, u.Uid)
+	if n == 0 {
+		return 0
+	}
+	// Container root maps to the invoking user and is not part of the subordinate ranges.
+	return n + 1
+}
+
+// parseSubIDCount sums the ranges allotted to the user, identified by name or numeric ID,
+// in a file in the /etc/subuid format ("name:start:count" per line).
+func parseSubIDCount(r io.Reader, name, id string) uint64 {
+	var total uint64
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Split(strings.TrimSpace(s.Text()), ":")
+		if len(fields) != 3 || (fields[0] != name && fields[0] != id) {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		total += n
+	}
+	return total
+}
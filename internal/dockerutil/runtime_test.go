@@ -0,0 +1,91 @@
+package dockerutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeFromEnv(t *testing.T) {
+	t.Setenv(ContainerRuntimeEnv, "podman")
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	rt, err := RuntimeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, "podman", rt.Name())
+	require.Equal(t, "unix:///tmp/podman.sock", rt.(*PodmanRuntime).Host)
+
+	t.Setenv(ContainerRuntimeEnv, "Docker")
+	rt, err = RuntimeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, DockerRuntime{}, rt)
+
+	t.Setenv(ContainerRuntimeEnv, "containerd")
+	_, err = RuntimeFromEnv()
+	require.ErrorContains(t, err, "unknown container runtime")
+
+	// Detected from the socket environment variables.
+	t.Setenv(ContainerRuntimeEnv, "")
+	rt, err = RuntimeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, "podman", rt.Name())
+}
+
+func TestContainerRuntime(t *testing.T) {
+	t.Cleanup(func() { SetContainerRuntime(nil) })
+
+	// An invalid runtime is reported when the runtime is first needed.
+	SetContainerRuntime(nil)
+	t.Setenv(ContainerRuntimeEnv, "containerd")
+	_, err := ContainerRuntime()
+	require.ErrorContains(t, err, "unknown container runtime")
+	_, err = NewRuntimeClient()
+	require.ErrorContains(t, err, "unknown container runtime")
+
+	p := &PodmanRuntime{Host: "unix:///tmp/podman.sock"}
+	SetContainerRuntime(p)
+	rt, err := ContainerRuntime()
+	require.NoError(t, err)
+	require.Same(t, p, rt)
+
+	SetContainerRuntime(nil)
+	t.Setenv(ContainerRuntimeEnv, "docker")
+	rt, err = ContainerRuntime()
+	require.NoError(t, err)
+	require.Equal(t, DockerRuntime{}, rt)
+}
+
+func TestPodmanRuntime_VolumeOwner(t *testing.T) {
+	p := &PodmanRuntime{Rootless: true, UIDs: 65537, GIDs: 1001}
+
+	owner, err := p.VolumeOwner("1025:1000")
+	require.NoError(t, err)
+	require.Equal(t, "1025:1000", owner)
+
+	_, err = p.VolumeOwner("1025:1025")
+	require.ErrorContains(t, err, "gid 1025")
+
+	_, err = p.VolumeOwner("100000")
+	require.ErrorContains(t, err, "uid 100000")
+
+	owner, err = p.VolumeOwner("nobody")
+	require.NoError(t, err)
+	require.Equal(t, "nobody", owner)
+
+	p.Rootless = false
+	owner, err = p.VolumeOwner("100000:100000")
+	require.NoError(t, err)
+	require.Equal(t, "100000:100000", owner)
+}
+
+func TestParseSubIDCount(t *testing.T) {
+	const subuid = `alice:100000:65536
+bob:165536:65536
+1000:231072:1000
+# malformed
+alice:x:y
+`
+	require.Equal(t, uint64(66536), parseSubIDCount(strings.NewReader(subuid), "alice", "1000"))
+	require.Equal(t, uint64(65536), parseSubIDCount(strings.NewReader(subuid), "bob", "1001"))
+	require.Zero(t, parseSubIDCount(strings.NewReader(subuid), "carol", "1002"))
+}
@@ -63,9 +63,9 @@ var KeepVolumesOnFailure = os.Getenv("IBCTEST_SKIP_FAILURE_CLEANUP") != ""
 func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Helper()
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	cli, err := NewRuntimeClient()
 	if err != nil {
-		panic(fmt.Errorf("failed to create docker client: %v", err))
+		panic(err)
 	}
 
 	// Clean up docker resources at end of test.
@@ -0,0 +1,212 @@
+package dockerutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// ContainerStats is a single sample of a container's resource usage.
+type ContainerStats struct {
+	ContainerName string
+	SampledAt     time.Time
+
+	// CPU usage as a percentage of a single CPU, i.e. 200 means two fully used CPUs.
+	CPUPercent float64
+	// Number of scheduler periods in which the container was throttled by its CPU limit,
+	// since the container started.
+	CPUThrottledPeriods uint64
+
+	// Memory in use, excluding the page cache, and the memory limit in bytes.
+	MemoryUsage, MemoryLimit uint64
+
+	// Number of processes in the container and its process limit (0 if unlimited).
+	PidsCurrent, PidsLimit uint64
+
+	// Bytes received and transmitted across all networks.
+	NetworkRx, NetworkTx uint64
+}
+
+// GetContainerStats returns a single sample of the resource usage of the container with the given ID.
+func GetContainerStats(ctx context.Context, cli *client.Client, containerID string) (ContainerStats, error) {
+	res, err := cli.ContainerStats(ctx, containerID, false)
+	if err != nil {
+		return ContainerStats{}, fmt.Errorf("container stats %s: %w", containerID, err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var raw types.StatsJSON
+	if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+		return ContainerStats{}, fmt.Errorf("decode container stats %s: %w", containerID, err)
+	}
+
+	return newContainerStats(raw), nil
+}
+
+func newContainerStats(raw types.StatsJSON) ContainerStats {
+	s := ContainerStats{
+		ContainerName: strings.TrimPrefix(raw.Name, "/"),
+		SampledAt:     raw.Read,
+
+		CPUThrottledPeriods: raw.CPUStats.ThrottlingData.ThrottledPeriods,
+
+		MemoryUsage: raw.MemoryStats.Usage,
+		MemoryLimit: raw.MemoryStats.Limit,
+
+		PidsCurrent: raw.PidsStats.Current,
+		PidsLimit:   raw.PidsStats.Limit,
+	}
+
+	// Same calculation as "docker stats".
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(raw.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		s.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	// The page cache is reclaimable, so do not count it against the container.
+	// cgroup v1 reports it as total_inactive_file and cgroup v2 as inactive_file.
+	cache := raw.MemoryStats.Stats["total_inactive_file"]
+	if v, ok := raw.MemoryStats.Stats["inactive_file"]; ok {
+		cache = v
+	}
+	if cache < s.MemoryUsage {
+		s.MemoryUsage -= cache
+	}
+
+	for _, n := range raw.Networks {
+		s.NetworkRx += n.RxBytes
+		s.NetworkTx += n.TxBytes
+	}
+
+	return s
+}
+
+// ContainerStatsReporter receives the samples collected by a StatsSampler.
+// It is satisfied by *testreporter.ContainerStatsReporter.
+type ContainerStatsReporter interface {
+	TrackContainerStats(
+		containerName string,
+		sampledAt time.Time,
+		cpuPercent float64,
+		cpuThrottledPeriods uint64,
+		memoryUsage, memoryLimit uint64,
+		pidsCurrent, pidsLimit uint64,
+		networkRx, networkTx uint64,
+	)
+}
+
+// StatsSampler periodically samples the resource usage of every container
+// created for a test, i.e. every container labeled with CleanupLabel for the test name.
+type StatsSampler struct {
+	log *zap.Logger
+	cli *client.Client
+
+	testName string
+	interval time.Duration
+	rep      ContainerStatsReporter
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewStatsSampler returns a StatsSampler that reports a sample of every container
+// belonging to testName to rep, once per interval.
+func NewStatsSampler(log *zap.Logger, cli *client.Client, testName string, interval time.Duration, rep ContainerStatsReporter) *StatsSampler {
+	return &StatsSampler{
+		log:      log,
+		cli:      cli,
+		testName: testName,
+		interval: interval,
+		rep:      rep,
+	}
+}
+
+// Start begins sampling in a background goroutine. Call Stop to end sampling.
+func (s *StatsSampler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.sample(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends sampling and blocks until the background goroutine has returned.
+func (s *StatsSampler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+}
+
+// sample collects stats for all running containers of the test concurrently,
+// because each stats request blocks for roughly one second on the docker daemon.
+func (s *StatsSampler) sample(ctx context.Context) {
+	cs, err := s.cli.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", CleanupLabel+"="+s.testName),
+			filters.Arg("status", "running"),
+		),
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Info("Failed to list containers for stats", zap.Error(err))
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range cs {
+		c := c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			stats, err := GetContainerStats(ctx, s.cli, c.ID)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.log.Debug("Failed to sample container stats", zap.String("container_id", c.ID), zap.Error(err))
+				}
+				return
+			}
+
+			s.rep.TrackContainerStats(
+				stats.ContainerName,
+				stats.SampledAt,
+				stats.CPUPercent,
+				stats.CPUThrottledPeriods,
+				stats.MemoryUsage, stats.MemoryLimit,
+				stats.PidsCurrent, stats.PidsLimit,
+				stats.NetworkRx, stats.NetworkTx,
+			)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,64 @@
+package dockerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+func TestContainerResources(t *testing.T) {
+	require.Equal(t, int64(0), ContainerResources(nil).NanoCPUs)
+
+	r := ContainerResources(&ibc.ResourceLimits{
+		CPUs:      1.5,
+		Memory:    512 << 20,
+		PidsLimit: 256,
+	})
+	require.Equal(t, int64(1_500_000_000), r.NanoCPUs)
+	require.Equal(t, int64(512<<20), r.Memory)
+	require.NotNil(t, r.PidsLimit)
+	require.Equal(t, int64(256), *r.PidsLimit)
+
+	r = ContainerResources(&ibc.ResourceLimits{Memory: 1 << 30})
+	require.Zero(t, r.NanoCPUs)
+	require.Nil(t, r.PidsLimit)
+}
+
+func TestNewContainerStats(t *testing.T) {
+	now := time.Now()
+
+	var raw types.StatsJSON
+	raw.Name = "/gaia-1-val-0-TestFoo"
+	raw.Read = now
+	raw.CPUStats.CPUUsage.TotalUsage = 3_000
+	raw.CPUStats.SystemUsage = 20_000
+	raw.CPUStats.OnlineCPUs = 4
+	raw.CPUStats.ThrottlingData.ThrottledPeriods = 7
+	raw.PreCPUStats.CPUUsage.TotalUsage = 1_000
+	raw.PreCPUStats.SystemUsage = 10_000
+	raw.MemoryStats.Usage = 100 << 20
+	raw.MemoryStats.Limit = 512 << 20
+	raw.MemoryStats.Stats = map[string]uint64{"inactive_file": 20 << 20}
+	raw.PidsStats.Current = 9
+	raw.Networks = map[string]types.NetworkStats{
+		"eth0": {RxBytes: 10, TxBytes: 20},
+		"eth1": {RxBytes: 1, TxBytes: 2},
+	}
+
+	s := newContainerStats(raw)
+	require.Equal(t, ContainerStats{
+		ContainerName:       "gaia-1-val-0-TestFoo",
+		SampledAt:           now,
+		CPUPercent:          80,
+		CPUThrottledPeriods: 7,
+		MemoryUsage:         80 << 20,
+		MemoryLimit:         512 << 20,
+		PidsCurrent:         9,
+		NetworkRx:           11,
+		NetworkTx:           22,
+	}, s)
+}
@@ -0,0 +1,121 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ArchiveVolume writes the whole content of the volume to w as a tar stream,
+// with paths relative to the root of the volume.
+//
+// Containers using the volume should be stopped, so that the archive is consistent.
+func (r *FileRetriever) ArchiveVolume(ctx context.Context, volumeName string, w io.Writer) error {
+	rc, err := r.copyFromVolume(ctx, volumeName, "")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	// Docker names the entries after the mount path, i.e. "dockervolume/...".
+	root := path.Base(fileRetrieverMountPath) + "/"
+	if err := rewriteTar(rc, w, func(name string) (string, bool) {
+		rel := strings.TrimPrefix(name, root)
+		return rel, rel != name
+	}); err != nil {
+		return fmt.Errorf("archiving volume %s: %w", volumeName, err)
+	}
+	return nil
+}
+
+// RestoreVolume replaces the whole content of the volume with the tar stream,
+// as written by ArchiveVolume. The files are owned by the owner of the volume root.
+//
+// Containers using the volume should be stopped.
+func (w *FileWriter) RestoreVolume(ctx context.Context, volumeName string, archive io.Reader) error {
+	// The archive is staged in the container, since the volume can only be emptied once the container runs.
+	const staging = "volume"
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.WriteHeader(&tar.Header{
+			Name:     staging + "/",
+			Typeflag: tar.TypeDir,
+			Mode:     0755,
+		})
+		if err == nil {
+			err = rewriteTarTo(archive, tw, func(name string) (string, bool) {
+				return path.Join(staging, name), true
+			})
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	defer func() {
+		_ = pr.Close()
+	}()
+
+	if err := w.copyToVolume(ctx, volumeName, "/tmp", pr,
+		// Empty the volume, including hidden files, then copy the staged files
+		// and give them the uid and gid of the mount path.
+		`rm -rf "$1"/..?* "$1"/.[!.]* "$1"/* && cp -a /tmp/`+staging+`/. "$1" && chown -R "$(stat -c '%u:%g' "$1")" "$1"`,
+	); err != nil {
+		return fmt.Errorf("restoring volume %s: %w", volumeName, err)
+	}
+	return nil
+}
+
+// rewriteTar copies the entries of the tar stream src to dst,
+// renaming them with rename and skipping those it does not keep.
+func rewriteTar(src io.Reader, dst io.Writer, rename func(name string) (string, bool)) error {
+	tw := tar.NewWriter(dst)
+	if err := rewriteTarTo(src, tw, rename); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func rewriteTarTo(src io.Reader, tw *tar.Writer, rename func(name string) (string, bool)) error {
+	tr := tar.NewReader(src)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+
+		isDir := hdr.Typeflag == tar.TypeDir
+		name, keep := rename(strings.TrimSuffix(hdr.Name, "/"))
+		if !keep {
+			continue
+		}
+		hdr.Name = name
+		if isDir {
+			hdr.Name += "/"
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			// Hard links name another entry of the archive.
+			if hdr.Linkname, keep = rename(strings.TrimSuffix(hdr.Linkname, "/")); !keep {
+				continue
+			}
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing tar header: %w", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return fmt.Errorf("writing tar content: %w", err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package dockerutil_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestVolumeArchive(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, _ := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	newVolume := func() string {
+		v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+			Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		})
+		require.NoError(t, err)
+		return v.Name
+	}
+	src, dst := newVolume(), newVolume()
+
+	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), cli, t.Name())
+	fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+
+	require.NoError(t, fw.WriteFile(ctx, src, "config/app.toml", []byte("app")))
+	require.NoError(t, fw.WriteFile(ctx, src, ".hidden", []byte("hidden")))
+	require.NoError(t, fw.WriteFile(ctx, dst, "stale.txt", []byte("stale")))
+
+	var archive bytes.Buffer
+	require.NoError(t, fr.ArchiveVolume(ctx, src, &archive))
+	require.NoError(t, fw.RestoreVolume(ctx, dst, &archive))
+
+	content, err := fr.SingleFileContent(ctx, dst, "config/app.toml")
+	require.NoError(t, err)
+	require.Equal(t, "app", string(content))
+
+	content, err = fr.SingleFileContent(ctx, dst, ".hidden")
+	require.NoError(t, err)
+	require.Equal(t, "hidden", string(content))
+
+	_, err = fr.SingleFileContent(ctx, dst, "stale.txt")
+	require.Error(t, err)
+}
@@ -29,12 +29,21 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 	if owner == "" {
 		owner = GetRootUserString()
 	}
+	rt, err := ContainerRuntime()
+	if err != nil {
+		return err
+	}
+	owner, err = rt.VolumeOwner(owner)
+	if err != nil {
+		return err
+	}
 
 	// Start a one-off container to chmod and chown the volume.
 
 	containerName := fmt.Sprintf("interchaintest-volumeowner-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
 
-	if err := ensureBusybox(ctx, opts.Client); err != nil {
+	busyboxRef, err := ensureBusybox(ctx, opts.Client)
+	if err != nil {
 		return err
 	}
 
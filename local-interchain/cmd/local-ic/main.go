@@ -9,6 +9,10 @@ func main() {
 	rootCmd.AddCommand(chainsCmd)
 	rootCmd.AddCommand(newChainCmd)
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(addChainCmd)
+	rootCmd.AddCommand(addIBCPathCmd)
+	rootCmd.AddCommand(saveCmd)
+	rootCmd.AddCommand(restoreCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "error while executing your CLI. Err: %v\n", err)
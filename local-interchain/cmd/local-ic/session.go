@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/localinterchain/interchain"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+var saveCmd = &cobra.Command{
+	Use:          "save <name>",
+	Short:        "Stops the running local-ic and saves its chains and relayer as a session",
+	Long:         "Stops the running local-ic and saves its chains and relayer as sessions/<name>.tar.gz, which restore brings back at the same height.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return postTopology(cmd, "/v1/sessions", handlers.SaveSessionRequest{
+			Name: args[0],
+		})
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <name|session.tar.gz>",
+	Short: "Starts the chains and relayer of a saved session from where they were saved",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		apiAddr, _ := cmd.Flags().GetString(FlagAPIAddressOverride)
+		apiPort, _ := cmd.Flags().GetUint16(FlagAPIPortOverride)
+
+		interchain.RestoreChain(GetDirectory(), args[0], &interchain.AppConfig{
+			Address: apiAddr,
+			Port:    apiPort,
+			AuthKey: authKey(cmd),
+		})
+	},
+}
+
+func init() {
+	saveCmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "address of the running local-ic API")
+	saveCmd.Flags().Uint16(FlagAPIPortOverride, 8080, "port of the running local-ic API")
+	saveCmd.Flags().String(FlagAuthKey, "", "bearer token of the API (default $"+EnvAuthKey+")")
+
+	restoreCmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "override the default API address")
+	restoreCmd.Flags().Uint16(FlagAPIPortOverride, 8080, "override the default API port")
+	restoreCmd.Flags().String(FlagAuthKey, "", "bearer token required by the API (default $"+EnvAuthKey+", none if empty)")
+}
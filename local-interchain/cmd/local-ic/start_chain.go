@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"path"
 	"path/filepath"
 
@@ -11,8 +12,23 @@ import (
 const (
 	FlagAPIAddressOverride = "api-address"
 	FlagAPIPortOverride    = "api-port"
+	FlagAuthKey            = "auth-key"
+
+	// EnvAuthKey is the environment variable holding the API bearer token when FlagAuthKey is not set.
+	EnvAuthKey = "ICTEST_AUTH_KEY"
 )
 
+// authKey returns the API bearer token given by FlagAuthKey, or else by EnvAuthKey.
+// The environment is read when the command runs, rather than as the flag default,
+// so that the token is not printed by --help.
+func authKey(cmd *cobra.Command) string {
+	if cmd.Flags().Changed(FlagAuthKey) {
+		key, _ := cmd.Flags().GetString(FlagAuthKey)
+		return key
+	}
+	return os.Getenv(EnvAuthKey)
+}
+
 var startCmd = &cobra.Command{
 	Use:     "start <config.json>",
 	Aliases: []string{"s", "run"},
@@ -38,6 +54,7 @@ var startCmd = &cobra.Command{
 		interchain.StartChain(parentDir, configPath, &interchain.AppConfig{
 			Address: apiAddr,
 			Port:    apiPort,
+			AuthKey: authKey(cmd),
 		})
 	},
 }
@@ -45,4 +62,5 @@ var startCmd = &cobra.Command{
 func init() {
 	startCmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "override the default API address")
 	startCmd.Flags().Uint16(FlagAPIPortOverride, 8080, "override the default API port")
+	startCmd.Flags().String(FlagAuthKey, "", "bearer token required by the API (default $"+EnvAuthKey+", none if empty)")
 }
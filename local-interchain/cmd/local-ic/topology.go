@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+var addChainCmd = &cobra.Command{
+	Use:          "add-chain <config.json>",
+	Short:        "Adds the chains of a config to the running local-ic and prints the updated info",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return GetFiles(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return postTopology(cmd, "/v1/chains", handlers.AddChainsRequest{
+			Config: args[0],
+		})
+	},
+}
+
+var addIBCPathCmd = &cobra.Command{
+	Use:          "add-ibc-path <path> <chain_a> <chain_b>",
+	Short:        "Creates an IBC path between two chains of the running local-ic and prints the updated info",
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return postTopology(cmd, "/v1/ibc-paths", handlers.AddIBCPathRequest{
+			Path:   args[0],
+			ChainA: args[1],
+			ChainB: args[2],
+		})
+	},
+}
+
+// postTopology sends the request to the running local-ic API and prints the updated info.
+func postTopology(cmd *cobra.Command, endpoint string, req any) error {
+	apiAddr, _ := cmd.Flags().GetString(FlagAPIAddressOverride)
+	apiPort, _ := cmd.Flags().GetUint16(FlagAPIPortOverride)
+	key := authKey(cmd)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(cmd.Context(), http.MethodPost, fmt.Sprintf("http://%s:%d%s", apiAddr, apiPort, endpoint), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	res, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to reach local-ic API: %w", err)
+	}
+	defer res.Body.Close()
+
+	bz, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("local-ic API returned %s: %s", res.Status, bytes.TrimSpace(bz))
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{addChainCmd, addIBCPathCmd} {
+		cmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "address of the running local-ic API")
+		cmd.Flags().Uint16(FlagAPIPortOverride, 8080, "port of the running local-ic API")
+		cmd.Flags().String(FlagAuthKey, "", "bearer token of the API (default $"+EnvAuthKey+")")
+	}
+}
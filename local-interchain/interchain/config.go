@@ -13,6 +13,7 @@ import (
 
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 )
@@ -93,7 +94,76 @@ func FasterBlockTimesBuilder(blockTime string) testutil.Toml {
 	return testutil.Toml{"config/config.toml": tomlCfg}
 }
 
+// AnvilStartArgsBuilder returns the anvil flags of an ethereum chain, passed to the chain as ConfigFileOverrides.
+func AnvilStartArgsBuilder(cfg types.Chain) map[string]any {
+	blockTime, err := time.ParseDuration(cfg.BlockTime)
+	if err != nil {
+		panic(err)
+	}
+
+	// anvil block times are whole seconds.
+	seconds := int(blockTime.Round(time.Second).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	args := map[string]any{
+		"--chain-id":   cfg.ChainID,
+		"--block-time": seconds,
+	}
+	if cfg.Anvil.Accounts > 0 {
+		args["--accounts"] = cfg.Anvil.Accounts
+	}
+	if cfg.Anvil.Balance > 0 {
+		args["--balance"] = cfg.Anvil.Balance
+	}
+	if cfg.Anvil.Mnemonic != "" {
+		args["--mnemonic"] = cfg.Anvil.Mnemonic
+	}
+	if cfg.Anvil.ForkURL != "" {
+		args["--fork-url"] = cfg.Anvil.ForkURL
+	}
+	if cfg.Anvil.ForkBlockNumber > 0 {
+		args["--fork-block-number"] = cfg.Anvil.ForkBlockNumber
+	}
+	if cfg.Anvil.LoadState != "" {
+		args["--load-state"] = cfg.Anvil.LoadState
+	}
+	return args
+}
+
+func createEthereumChainConfigs(cfg types.Chain) (ibc.ChainConfig, *interchaintest.ChainSpec) {
+	chainCfg := ethereum.DefaultEthereumAnvilChainConfig(cfg.Name)
+	chainCfg.ChainID = cfg.ChainID
+	chainCfg.Bin = cfg.Binary
+	chainCfg.Images = []ibc.DockerImage{
+		{
+			Repository: cfg.DockerImage.Repository,
+			Version:    cfg.DockerImage.Version,
+			UidGid:     cfg.DockerImage.UidGid,
+		},
+	}
+	chainCfg.ConfigFileOverrides = AnvilStartArgsBuilder(cfg)
+
+	// anvil is a single node.
+	numVals, numNodes := 1, 0
+	chainSpecs := &interchaintest.ChainSpec{
+		Name:          cfg.Name,
+		Version:       cfg.DockerImage.Version,
+		ChainName:     cfg.ChainID,
+		ChainConfig:   chainCfg,
+		NumValidators: &numVals,
+		NumFullNodes:  &numNodes,
+	}
+
+	return chainCfg, chainSpecs
+}
+
 func CreateChainConfigs(cfg types.Chain) (ibc.ChainConfig, *interchaintest.ChainSpec) {
+	if cfg.IsEthereum() {
+		return createEthereumChainConfigs(cfg)
+	}
+
 	chainCfg := ibc.ChainConfig{
 		Type:                cfg.ChainType,
 		Name:                cfg.Name,
@@ -0,0 +1,204 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+)
+
+// Tx is the data of a tx event.
+type Tx struct {
+	Hash      string    `json:"hash"`
+	Code      uint32    `json:"code"`
+	Log       string    `json:"log,omitempty"`
+	GasWanted int64     `json:"gas_wanted"`
+	GasUsed   int64     `json:"gas_used"`
+	Events    []TxEvent `json:"events"`
+}
+
+type TxEvent struct {
+	Type       string        `json:"type"`
+	Attributes []TxAttribute `json:"attributes"`
+}
+
+type TxAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// EVMHeader is the data of a block event of an ethereum chain.
+type EVMHeader struct {
+	Number     uint64 `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parent_hash"`
+	Time       uint64 `json:"time"`
+	GasUsed    uint64 `json:"gas_used"`
+	GasLimit   uint64 `json:"gas_limit"`
+}
+
+// WatchCosmosChain publishes a block event for every new block of the chain, followed by a tx event
+// for each of its txs, until the context is done.
+func WatchCosmosChain(ctx context.Context, hub *Hub, chain *cosmos.CosmosChain, interval time.Duration) {
+	chainID := chain.Config().ChainID
+	client := chain.Validators[0].Client
+
+	var last int64
+	started := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		status, err := client.Status(ctx)
+		if err != nil {
+			continue
+		}
+		latest := status.SyncInfo.LatestBlockHeight
+		if !started {
+			// Stream from the latest block on, not from genesis.
+			last, started = latest-1, true
+		}
+
+		for h := last + 1; h <= latest; h++ {
+			if err := publishCosmosBlock(ctx, hub, chain, h); err != nil {
+				log.Printf("events: %s block %d: %v", chainID, h, err)
+				break
+			}
+			last = h
+		}
+	}
+}
+
+func publishCosmosBlock(ctx context.Context, hub *Hub, chain *cosmos.CosmosChain, height int64) error {
+	chainID := chain.Config().ChainID
+	client := chain.Validators[0].Client
+
+	block, err := client.Block(ctx, &height)
+	if err != nil {
+		return fmt.Errorf("failed to get block: %w", err)
+	}
+	results, err := client.BlockResults(ctx, &height)
+	if err != nil {
+		return fmt.Errorf("failed to get block results: %w", err)
+	}
+
+	hub.Publish(Event{
+		Type:    TypeBlock,
+		ChainID: chainID,
+		Height:  uint64(height),
+		Time:    block.Block.Time,
+		Data:    block.Block.Header,
+	})
+
+	for i, tx := range block.Block.Data.Txs {
+		if i >= len(results.TxsResults) {
+			break
+		}
+		res := results.TxsResults[i]
+		hub.Publish(Event{
+			Type:    TypeTx,
+			ChainID: chainID,
+			Height:  uint64(height),
+			Time:    block.Block.Time,
+			Data: Tx{
+				Hash:      fmt.Sprintf("%X", tx.Hash()),
+				Code:      res.Code,
+				Log:       res.Log,
+				GasWanted: res.GasWanted,
+				GasUsed:   res.GasUsed,
+				Events:    txEvents(res.Events),
+			},
+		})
+	}
+	return nil
+}
+
+func txEvents(events []abcitypes.Event) []TxEvent {
+	out := make([]TxEvent, len(events))
+	for i, ev := range events {
+		attrs := make([]TxAttribute, len(ev.Attributes))
+		for j, attr := range ev.Attributes {
+			attrs[j] = TxAttribute{Key: attr.Key, Value: attr.Value}
+		}
+		out[i] = TxEvent{Type: ev.Type, Attributes: attrs}
+	}
+	return out
+}
+
+// WatchEthereumChain publishes a block event for every new block of the chain until the context is done.
+func WatchEthereumChain(ctx context.Context, hub *Hub, chain *ethereum.EthereumChain, interval time.Duration) {
+	chainID := chain.Config().ChainID
+
+	client, err := ethclient.DialContext(ctx, chain.GetHostRPCAddress())
+	if err != nil {
+		log.Printf("events: %s: %v", chainID, err)
+		return
+	}
+	defer client.Close()
+
+	var last uint64
+	started := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			continue
+		}
+		if !started {
+			// Stream from the latest block on. Anvil starts at block 0.
+			last, started = latest, true
+			if err := publishEVMBlock(ctx, hub, client, chainID, latest); err != nil {
+				log.Printf("events: %s block %d: %v", chainID, latest, err)
+			}
+		}
+
+		for n := last + 1; n <= latest; n++ {
+			if err := publishEVMBlock(ctx, hub, client, chainID, n); err != nil {
+				log.Printf("events: %s block %d: %v", chainID, n, err)
+				break
+			}
+			last = n
+		}
+	}
+}
+
+func publishEVMBlock(ctx context.Context, hub *Hub, client *ethclient.Client, chainID string, number uint64) error {
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return fmt.Errorf("failed to get header: %w", err)
+	}
+
+	hub.Publish(Event{
+		Type:    TypeBlock,
+		ChainID: chainID,
+		Height:  number,
+		Time:    time.Unix(int64(header.Time), 0),
+		Data: EVMHeader{
+			Number:     number,
+			Hash:       header.Hash().Hex(),
+			ParentHash: header.ParentHash.Hex(),
+			Time:       header.Time,
+			GasUsed:    header.GasUsed,
+			GasLimit:   header.GasLimit,
+		},
+	})
+	return nil
+}
@@ -0,0 +1,149 @@
+package events
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types streamed by the hub.
+const (
+	TypeBlock   = "block"
+	TypeTx      = "tx"
+	TypeRelayer = "relayer"
+	TypeLog     = "log"
+)
+
+// subscriptionBuffer is the number of events queued for a subscriber before new events are dropped.
+const subscriptionBuffer = 256
+
+// Event is a single streamed event. Data depends on the type: a block header, a tx result,
+// a relayer exec or a container log line.
+type Event struct {
+	Type    string    `json:"type"`
+	ChainID string    `json:"chain_id,omitempty"`
+	Height  uint64    `json:"height,omitempty"`
+	Time    time.Time `json:"time"`
+	Data    any       `json:"data"`
+}
+
+// Filter selects the events of a subscription. Empty fields match everything.
+type Filter struct {
+	ChainIDs []string
+	Types    []string
+
+	// TxEventType keeps the txs emitting an event of this type, e.g. "transfer".
+	TxEventType string
+	// TxAttribute keeps the txs emitting an event with this attribute, as "key" or "key=value",
+	// within the events of TxEventType if set.
+	TxAttribute string
+}
+
+// Match reports whether the event passes the filter.
+// Events not tied to a chain, such as relayer execs, pass any chain filter.
+func (f Filter) Match(e Event) bool {
+	if len(f.Types) > 0 && !contains(f.Types, e.Type) {
+		return false
+	}
+	if e.ChainID != "" && len(f.ChainIDs) > 0 && !contains(f.ChainIDs, e.ChainID) {
+		return false
+	}
+	if e.Type == TypeTx {
+		if tx, ok := e.Data.(Tx); ok {
+			return f.matchTx(tx)
+		}
+	}
+	return true
+}
+
+func (f Filter) matchTx(tx Tx) bool {
+	if f.TxEventType == "" && f.TxAttribute == "" {
+		return true
+	}
+
+	key, value, hasValue := strings.Cut(f.TxAttribute, "=")
+	for _, ev := range tx.Events {
+		if f.TxEventType != "" && ev.Type != f.TxEventType {
+			continue
+		}
+		if f.TxAttribute == "" {
+			return true
+		}
+		for _, attr := range ev.Attributes {
+			if attr.Key == key && (!hasValue || attr.Value == value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription receives the events matching its filter from a Hub.
+type Subscription struct {
+	Filter Filter
+	C      chan Event
+}
+
+// Send queues the event if it matches the filter. It drops the event if the subscriber is too slow.
+func (s *Subscription) Send(e Event) {
+	if !s.Filter.Match(e) {
+		return
+	}
+	select {
+	case s.C <- e:
+	default:
+	}
+}
+
+// Hub fans out the events of the running chains and relayer to subscribers.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe returns a subscription to the events matching f. It must be closed with Unsubscribe.
+func (h *Hub) Subscribe(f Filter) *Subscription {
+	s := &Subscription{
+		Filter: f,
+		C:      make(chan Event, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, s)
+	h.mu.Unlock()
+}
+
+// Publish sends the event to every matching subscriber.
+func (h *Hub) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs {
+		s.Send(e)
+	}
+}
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	transfer := Tx{Events: []TxEvent{
+		{Type: "message", Attributes: []TxAttribute{{Key: "sender", Value: "cosmos1a"}}},
+		{Type: "transfer", Attributes: []TxAttribute{{Key: "recipient", Value: "cosmos1b"}, {Key: "amount", Value: "5stake"}}},
+	}}
+
+	for _, tt := range []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{
+			name:  "empty filter",
+			event: Event{Type: TypeBlock, ChainID: "a"},
+			want:  true,
+		},
+		{
+			name:   "type",
+			filter: Filter{Types: []string{TypeBlock}},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "chain",
+			filter: Filter{ChainIDs: []string{"a", "b"}},
+			event:  Event{Type: TypeBlock, ChainID: "b"},
+			want:   true,
+		},
+		{
+			name:   "other chain",
+			filter: Filter{ChainIDs: []string{"a"}},
+			event:  Event{Type: TypeBlock, ChainID: "b"},
+			want:   false,
+		},
+		{
+			name:   "relayer events pass chain filters",
+			filter: Filter{ChainIDs: []string{"a"}},
+			event:  Event{Type: TypeRelayer},
+			want:   true,
+		},
+		{
+			name:   "relayer events still filtered by type",
+			filter: Filter{ChainIDs: []string{"a"}, Types: []string{TypeBlock}},
+			event:  Event{Type: TypeRelayer},
+			want:   false,
+		},
+		{
+			name:   "tx filters do not apply to blocks",
+			filter: Filter{TxEventType: "transfer"},
+			event:  Event{Type: TypeBlock, ChainID: "a"},
+			want:   true,
+		},
+		{
+			name:   "tx_type",
+			filter: Filter{TxEventType: "transfer"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   true,
+		},
+		{
+			name:   "missing tx_type",
+			filter: Filter{TxEventType: "delegate"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "tx_attr key",
+			filter: Filter{TxAttribute: "sender"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   true,
+		},
+		{
+			name:   "missing tx_attr key",
+			filter: Filter{TxAttribute: "validator"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "tx_attr key=value",
+			filter: Filter{TxAttribute: "recipient=cosmos1b"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   true,
+		},
+		{
+			name:   "tx_attr key=value with another value",
+			filter: Filter{TxAttribute: "recipient=cosmos1c"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "tx_attr key=empty value",
+			filter: Filter{TxAttribute: "recipient="},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "tx_attr within tx_type",
+			filter: Filter{TxEventType: "transfer", TxAttribute: "amount=5stake"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   true,
+		},
+		{
+			name:   "tx_attr of another event type",
+			filter: Filter{TxEventType: "transfer", TxAttribute: "sender"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+		{
+			name:   "tx filters and chain filters combine",
+			filter: Filter{ChainIDs: []string{"b"}, TxEventType: "transfer"},
+			event:  Event{Type: TypeTx, ChainID: "a", Data: transfer},
+			want:   false,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Match(tt.event))
+		})
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	h := NewHub()
+	blocks := h.Subscribe(Filter{Types: []string{TypeBlock}})
+	defer h.Unsubscribe(blocks)
+
+	h.Publish(Event{Type: TypeTx, ChainID: "a"})
+	h.Publish(Event{Type: TypeBlock, ChainID: "a", Height: 1})
+
+	e := <-blocks.C
+	require.Equal(t, uint64(1), e.Height)
+	require.False(t, e.Time.IsZero())
+	require.Empty(t, blocks.C)
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	dockertypes "github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// maxLogLine is the longest log line streamed.
+const maxLogLine = 1024 * 1024
+
+// LogLine is the data of a log event.
+type LogLine struct {
+	Container string `json:"container"`
+	Stream    string `json:"stream"`
+	Line      string `json:"line"`
+}
+
+// FollowLogs sends a log event to the subscription for every line the container logs from now on,
+// until the context is done or the container stops.
+func FollowLogs(ctx context.Context, cli *dockerclient.Client, sub *Subscription, chainID, container string) {
+	rc, err := cli.ContainerLogs(ctx, container, dockertypes.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Since:      strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		log.Printf("events: logs of %s: %v", container, err)
+		return
+	}
+	defer rc.Close()
+
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+	go scanLines(stdoutR, sub, chainID, container, "stdout")
+	go scanLines(stderrR, sub, chainID, container, "stderr")
+
+	_, err = stdcopy.StdCopy(stdoutW, stderrW, rc)
+	_ = stdoutW.CloseWithError(err)
+	_ = stderrW.CloseWithError(err)
+}
+
+func scanLines(r io.Reader, sub *Subscription, chainID, container, stream string) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogLine)
+	for scanner.Scan() {
+		sub.Send(Event{
+			Type:    TypeLog,
+			ChainID: chainID,
+			Time:    time.Now(),
+			Data: LogLine{
+				Container: container,
+				Stream:    stream,
+				Line:      scanner.Text(),
+			},
+		})
+	}
+
+	// Keep draining the logs if a line was too long, so the demultiplexer does not block.
+	_, _ = io.Copy(io.Discard, r)
+}
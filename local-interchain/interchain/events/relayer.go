@@ -0,0 +1,63 @@
+package events
+
+import (
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// RelayerExec is the data of a relayer event.
+type RelayerExec struct {
+	ContainerName string    `json:"container_name"`
+	Command       []string  `json:"command"`
+	Stdout        string    `json:"stdout"`
+	Stderr        string    `json:"stderr"`
+	ExitCode      int       `json:"exit_code"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// RelayerExecReporter publishes a relayer event for every relayer exec, then reports it to the wrapped reporter.
+type RelayerExecReporter struct {
+	hub  *Hub
+	next ibc.RelayerExecReporter
+}
+
+var _ ibc.RelayerExecReporter = (*RelayerExecReporter)(nil)
+
+func NewRelayerExecReporter(hub *Hub, next ibc.RelayerExecReporter) *RelayerExecReporter {
+	return &RelayerExecReporter{
+		hub:  hub,
+		next: next,
+	}
+}
+
+func (r *RelayerExecReporter) TrackRelayerExec(
+	containerName string,
+	command []string,
+	stdout, stderr string,
+	exitCode int,
+	startedAt, finishedAt time.Time,
+	err error,
+) {
+	exec := RelayerExec{
+		ContainerName: containerName,
+		Command:       command,
+		Stdout:        stdout,
+		Stderr:        stderr,
+		ExitCode:      exitCode,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+	}
+	if err != nil {
+		exec.Error = err.Error()
+	}
+	r.hub.Publish(Event{
+		Type: TypeRelayer,
+		Time: finishedAt,
+		Data: exec,
+	})
+
+	r.next.TrackRelayerExec(containerName, command, stdout, stderr, exitCode, startedAt, finishedAt, err)
+}
@@ -13,7 +13,10 @@ import (
 
 func AddGenesisKeysToKeyring(ctx context.Context, config *types.Config, chains []ibc.Chain) {
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		chainObj, ok := chains[idx].(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
 
 		for _, acc := range chain.Genesis.Accounts {
 			if err := chainObj.RecoverKey(ctx, acc.Name, acc.Mnemonic); err != nil {
@@ -25,7 +28,10 @@ func AddGenesisKeysToKeyring(ctx context.Context, config *types.Config, chains [
 
 func PostStartupCommands(ctx context.Context, config *types.Config, chains []ibc.Chain) {
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		chainObj, ok := chains[idx].(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
 
 		for _, cmd := range chain.Genesis.StartupCommands {
 			log.Println("Running startup command", chainObj.Config().ChainID, cmd)
@@ -51,7 +57,10 @@ func SetupGenesisWallets(config *types.Config, chains []ibc.Chain) map[ibc.Chain
 	// iterate all chains chain's configs & setup accounts
 	additionalWallets := make(map[ibc.Chain][]ibc.WalletAmount)
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		chainObj, ok := chains[idx].(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
 
 		for _, acc := range chain.Genesis.Accounts {
 			amount, err := sdk.ParseCoinsNormalized(acc.Amount)
@@ -16,13 +16,14 @@ import (
 )
 
 type actions struct {
-	ctx  context.Context
-	ic   *interchaintest.Interchain
-	vals map[string]*cosmos.ChainNode
-	cc   map[string]*cosmos.CosmosChain
+	ctx    context.Context
+	ic     *interchaintest.Interchain
+	chains *Chains
 
 	relayer ibc.Relayer
 	eRep    ibc.RelayerExecReporter
+
+	topology *topology
 }
 
 type ActionHandler struct {
@@ -31,14 +32,14 @@ type ActionHandler struct {
 	Cmd     string `json:"cmd"`
 }
 
-func NewActions(ctx context.Context, ic *interchaintest.Interchain, cosmosChains map[string]*cosmos.CosmosChain, vals map[string]*cosmos.ChainNode, relayer ibc.Relayer, eRep ibc.RelayerExecReporter) *actions {
+func NewActions(ctx context.Context, ic *interchaintest.Interchain, chains *Chains, relayer ibc.Relayer, eRep ibc.RelayerExecReporter, topology *topology) *actions {
 	return &actions{
-		ctx:     ctx,
-		ic:      ic,
-		vals:    vals,
-		cc:      cosmosChains,
-		relayer: relayer,
-		eRep:    eRep,
+		ctx:      ctx,
+		ic:       ic,
+		chains:   chains,
+		relayer:  relayer,
+		eRep:     eRep,
+		topology: topology,
 	}
 }
 
@@ -52,19 +53,28 @@ func (a *actions) PostActions(w http.ResponseWriter, r *http.Request) {
 
 	action := ah.Action
 	if action == "kill-all" {
-		KillAll(a.ctx, a.ic, a.vals, a.relayer, a.eRep)
+		KillAll(a.ctx, a.ic, a.chains.Validators(), a.relayer, a.eRep)
+		return
+	}
+	if a.topology.action(w, r, ah) {
 		return
 	}
 
 	chainId := ah.ChainId
-	if _, ok := a.vals[chainId]; !ok {
-		util.Write(w, []byte(fmt.Sprintf(`{"error":"chain_id '%s' not found. Chains %v"}`, chainId, a.vals[chainId])))
+	if chain, ok := a.chains.Ethereum(chainId); ok {
+		a.evmActions(w, chain, ah)
 		return
 	}
 
-	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%RPC%", fmt.Sprintf("tcp://%s:26657", a.vals[chainId].HostName()))
+	chain, val, ok := a.chains.Cosmos(chainId)
+	if !ok {
+		util.Write(w, []byte(fmt.Sprintf(`{"error":"chain_id '%s' not found. Chains %v"}`, chainId, val)))
+		return
+	}
+
+	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%RPC%", fmt.Sprintf("tcp://%s:26657", val.HostName()))
 	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%CHAIN_ID%", ah.ChainId)
-	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%HOME%", a.vals[chainId].HomeDir())
+	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%HOME%", val.HomeDir())
 
 	cmd := strings.Split(ah.Cmd, " ")
 
@@ -72,16 +82,8 @@ func (a *actions) PostActions(w http.ResponseWriter, r *http.Request) {
 	var output []byte
 	var stdout, stderr []byte
 
-	val := a.vals[chainId]
-
 	// parse out special commands if there are any.
-	cmdMap := make(map[string]string)
-	if strings.Contains(ah.Cmd, "=") {
-		for _, c := range strings.Split(ah.Cmd, ";") {
-			s := strings.Split(c, "=")
-			cmdMap[s[0]] = s[1]
-		}
-	}
+	cmdMap := parseCmdMap(ah.Cmd)
 
 	// Node / Docker Linux Actions
 	switch action {
@@ -107,8 +109,6 @@ func (a *actions) PostActions(w http.ResponseWriter, r *http.Request) {
 		}
 		stdout = []byte(fmt.Sprintf(`{"overwrote_genesis_file":"%s"}`, val.ContainerID()))
 	case "add-full-nodes":
-		chain := a.cc[chainId]
-
 		amt, err := strconv.Atoi(cmdMap["amount"])
 		if err != nil {
 			util.WriteError(w, fmt.Errorf("failed to convert amount to int: %s", err))
@@ -182,6 +182,20 @@ func (a *actions) PostActions(w http.ResponseWriter, r *http.Request) {
 	util.Write(w, []byte(output))
 }
 
+// parseCmdMap parses the "key=value;key=value" arguments of special commands.
+func parseCmdMap(cmd string) map[string]string {
+	cmdMap := make(map[string]string)
+	if strings.Contains(cmd, "=") {
+		for _, c := range strings.Split(cmd, ";") {
+			s := strings.SplitN(c, "=", 2)
+			if len(s) == 2 {
+				cmdMap[s[0]] = s[1]
+			}
+		}
+	}
+	return cmdMap
+}
+
 func (a *actions) relayerCheck(w http.ResponseWriter, r *http.Request) error {
 	var err error = nil
 
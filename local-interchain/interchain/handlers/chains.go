@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+)
+
+// Chains is the set of chains served by the API, by chain ID.
+// Chains are added while requests are served, e.g. by add-chain, so all access goes through its lock.
+type Chains struct {
+	mu sync.RWMutex
+
+	vals   map[string]*cosmos.ChainNode
+	cosmos map[string]*cosmos.CosmosChain
+	eth    map[string]*ethereum.EthereumChain
+	// chain_id -> container names, for log events
+	containers map[string][]string
+}
+
+func NewChains() *Chains {
+	return &Chains{
+		vals:       make(map[string]*cosmos.ChainNode),
+		cosmos:     make(map[string]*cosmos.CosmosChain),
+		eth:        make(map[string]*ethereum.EthereumChain),
+		containers: make(map[string][]string),
+	}
+}
+
+// AddCosmos serves the chain, through its first validator.
+func (c *Chains) AddCosmos(chain *cosmos.CosmosChain) {
+	var containers []string
+	for _, n := range chain.Nodes() {
+		containers = append(containers, n.ContainerID())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	chainID := chain.Config().ChainID
+	c.vals[chainID] = chain.Validators[0]
+	c.cosmos[chainID] = chain
+	c.containers[chainID] = containers
+}
+
+// AddEthereum serves the chain.
+func (c *Chains) AddEthereum(chain *ethereum.EthereumChain) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	chainID := chain.Config().ChainID
+	c.eth[chainID] = chain
+	c.containers[chainID] = []string{chain.Name()}
+}
+
+// Validator returns the validator that serves the cosmos chain with the chain ID.
+func (c *Chains) Validator(chainID string) (*cosmos.ChainNode, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	val, ok := c.vals[chainID]
+	return val, ok
+}
+
+// Cosmos returns the cosmos chain with the chain ID, and the validator that serves it.
+func (c *Chains) Cosmos(chainID string) (*cosmos.CosmosChain, *cosmos.ChainNode, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	chain, ok := c.cosmos[chainID]
+	return chain, c.vals[chainID], ok
+}
+
+// Ethereum returns the ethereum chain with the chain ID.
+func (c *Chains) Ethereum(chainID string) (*ethereum.EthereumChain, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	chain, ok := c.eth[chainID]
+	return chain, ok
+}
+
+// Validators returns a copy of the validators serving the cosmos chains, by chain ID.
+func (c *Chains) Validators() map[string]*cosmos.ChainNode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	vals := make(map[string]*cosmos.ChainNode, len(c.vals))
+	for chainID, val := range c.vals {
+		vals[chainID] = val
+	}
+	return vals
+}
+
+// Containers returns a copy of the container names of every chain, by chain ID.
+func (c *Chains) Containers() map[string][]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	containers := make(map[string][]string, len(c.containers))
+	for chainID, names := range c.containers {
+		containers[chainID] = append([]string(nil), names...)
+	}
+	return containers
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// heartbeatInterval is the interval of the comments keeping idle streams open through proxies.
+const heartbeatInterval = 15 * time.Second
+
+type eventStream struct {
+	ctx context.Context
+	hub *events.Hub
+
+	cli    *dockerclient.Client
+	chains *Chains
+}
+
+func NewEvents(ctx context.Context, hub *events.Hub, cli *dockerclient.Client, chains *Chains) *eventStream {
+	return &eventStream{
+		ctx:    ctx,
+		hub:    hub,
+		cli:    cli,
+		chains: chains,
+	}
+}
+
+// GetEvents streams events as Server-Sent Events, one JSON events.Event per message named after its type.
+// Query params, all optional and comma separated:
+//   - chain_id: the chains to stream (default all)
+//   - type: block, tx, relayer, log (default all but log, which must be requested explicitly)
+//   - tx_type: keep the txs emitting an event of this type
+//   - tx_attr: keep the txs emitting an event attribute "key" or "key=value"
+func (e *eventStream) GetEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		util.WriteError(w, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	filter := parseFilter(r)
+	allContainers := e.chains.Containers()
+	for _, chainID := range filter.ChainIDs {
+		if _, ok := allContainers[chainID]; !ok {
+			util.WriteError(w, fmt.Errorf("chain_id %s not found", chainID))
+			return
+		}
+	}
+
+	sub := e.hub.Subscribe(filter)
+	defer e.hub.Unsubscribe(sub)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	for _, t := range filter.Types {
+		if t != events.TypeLog {
+			continue
+		}
+		for chainID, containers := range allContainers {
+			if len(filter.ChainIDs) > 0 && !contains(filter.ChainIDs, chainID) {
+				continue
+			}
+			for _, c := range containers {
+				go events.FollowLogs(ctx, e.cli, sub, chainID, c)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case ev := <-sub.C:
+			bz, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, bz); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func parseFilter(r *http.Request) events.Filter {
+	form := r.URL.Query()
+
+	f := events.Filter{
+		ChainIDs:    splitParam(form.Get("chain_id")),
+		Types:       splitParam(form.Get("type")),
+		TxEventType: form.Get("tx_type"),
+		TxAttribute: form.Get("tx_attr"),
+	}
+	if len(f.Types) == 0 {
+		f.Types = []string{events.TypeBlock, events.TypeTx, events.TypeRelayer}
+	}
+	return f
+}
+
+func splitParam(v string) []string {
+	return strings.FieldsFunc(v, func(c rune) bool {
+		return c == ','
+	})
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
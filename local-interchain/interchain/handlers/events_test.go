@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  events.Filter
+	}{
+		{
+			name:  "defaults to every type but logs",
+			query: "",
+			want:  events.Filter{Types: []string{events.TypeBlock, events.TypeTx, events.TypeRelayer}},
+		},
+		{
+			name:  "comma separated chains and types",
+			query: "chain_id=a,,b&type=tx,log",
+			want: events.Filter{
+				ChainIDs: []string{"a", "b"},
+				Types:    []string{events.TypeTx, events.TypeLog},
+			},
+		},
+		{
+			name:  "tx_type and tx_attr key",
+			query: "type=tx&tx_type=transfer&tx_attr=recipient",
+			want: events.Filter{
+				Types:       []string{events.TypeTx},
+				TxEventType: "transfer",
+				TxAttribute: "recipient",
+			},
+		},
+		{
+			name:  "tx_attr key=value",
+			query: "type=tx&tx_attr=recipient%3Dcosmos1b",
+			want: events.Filter{
+				Types:       []string{events.TypeTx},
+				TxAttribute: "recipient=cosmos1b",
+			},
+		},
+		{
+			name:  "unescaped tx_attr key=value",
+			query: "type=tx&tx_attr=recipient=cosmos1b",
+			want: events.Filter{
+				Types:       []string{events.TypeTx},
+				TxAttribute: "recipient=cosmos1b",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/events?"+tt.query, nil)
+			f := parseFilter(r)
+			require.ElementsMatch(t, tt.want.ChainIDs, f.ChainIDs)
+			require.Equal(t, tt.want.Types, f.Types)
+			require.Equal(t, tt.want.TxEventType, f.TxEventType)
+			require.Equal(t, tt.want.TxAttribute, f.TxAttribute)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// evmActions runs the actions of ethereum chains. Arguments are passed in the cmd as "key=value;key=value",
+// except for exec which takes a command, e.g. "cast balance 0x... --rpc-url %RPC%".
+func (a *actions) evmActions(w http.ResponseWriter, chain *ethereum.EthereumChain, ah ActionHandler) {
+	cmdMap := parseCmdMap(ah.Cmd)
+
+	var res any
+	var err error
+
+	switch ah.Action {
+	case "e", "exec", "execute":
+		cmd := strings.ReplaceAll(ah.Cmd, "%RPC%", chain.GetRPCAddress())
+		cmd = strings.ReplaceAll(cmd, "%CHAIN_ID%", ah.ChainId)
+		cmd = strings.ReplaceAll(cmd, "%HOME%", chain.HomeDir())
+
+		stdout, stderr, err := chain.Exec(a.ctx, strings.Split(cmd, " "), []string{})
+		if err != nil {
+			util.WriteError(w, fmt.Errorf("failed to execute (stderr=%q): %w", stderr, err))
+			return
+		}
+		util.Write(w, stdout)
+		return
+	case "send":
+		res, err = a.evmSend(chain, cmdMap)
+	case "deploy":
+		res, err = a.evmDeploy(chain, cmdMap)
+	case "call":
+		res, err = a.evmCall(chain, cmdMap)
+	case "mine":
+		blocks := uint64(1)
+		if b, ok := cmdMap["blocks"]; ok {
+			if blocks, err = strconv.ParseUint(b, 10, 64); err != nil {
+				util.WriteError(w, fmt.Errorf("failed to parse blocks: %w", err))
+				return
+			}
+		}
+		err = chain.Mine(a.ctx, blocks)
+		res = map[string]uint64{"mined": blocks}
+	case "snapshot":
+		var id string
+		id, err = chain.Snapshot(a.ctx)
+		res = map[string]string{"snapshot_id": id}
+	case "revert":
+		err = chain.Revert(a.ctx, cmdMap["id"])
+		res = map[string]string{"reverted": cmdMap["id"]}
+	default:
+		err = fmt.Errorf("action %s is not supported on ethereum chain %s", ah.Action, ah.ChainId)
+	}
+	if err != nil {
+		util.WriteError(w, err)
+		return
+	}
+
+	jsonRes, err := json.Marshal(res)
+	if err != nil {
+		util.WriteError(w, err)
+		return
+	}
+	util.Write(w, jsonRes)
+}
+
+// evmSend sends value (in wei) and the hex data from the account from to the address to.
+func (a *actions) evmSend(chain *ethereum.EthereumChain, cmdMap map[string]string) (map[string]any, error) {
+	tx := ethereum.Transaction{
+		From: cmdMap["from"],
+		To:   cmdMap["to"],
+	}
+	if tx.To == "" {
+		return nil, fmt.Errorf("to not found in commands")
+	}
+
+	if v, ok := cmdMap["value"]; ok {
+		value, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %q", v)
+		}
+		tx.Value = value
+	}
+
+	if d, ok := cmdMap["data"]; ok {
+		data, err := hexutil.Decode(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data: %w", err)
+		}
+		tx.Data = data
+	}
+
+	receipt, err := chain.SendTransaction(a.ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"tx_hash":      receipt.TxHash.Hex(),
+		"block_number": receipt.BlockNumber.Uint64(),
+		"gas_used":     receipt.GasUsed,
+	}, nil
+}
+
+// evmDeploy deploys the hex contract creation bytecode from the account from.
+func (a *actions) evmDeploy(chain *ethereum.EthereumChain, cmdMap map[string]string) (map[string]any, error) {
+	bytecode, err := hexutil.Decode(cmdMap["bytecode"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+
+	receipt, err := chain.DeployContract(a.ctx, cmdMap["from"], bytecode)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"contract_address": receipt.ContractAddress.Hex(),
+		"tx_hash":          receipt.TxHash.Hex(),
+		"block_number":     receipt.BlockNumber.Uint64(),
+	}, nil
+}
+
+// evmCall calls the contract to with the hex ABI encoded data.
+func (a *actions) evmCall(chain *ethereum.EthereumChain, cmdMap map[string]string) (map[string]any, error) {
+	data, err := hexutil.Decode(cmdMap["data"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+
+	res, err := chain.CallContract(a.ctx, cmdMap["to"], data)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"result": hexutil.Encode(res)}, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	types "github.com/strangelove-ventures/localinterchain/interchain/types"
 	util "github.com/strangelove-ventures/localinterchain/interchain/util"
@@ -24,12 +25,10 @@ type info struct {
 	// used to get information about state of the container
 	ctx     context.Context
 	ic      *interchaintest.Interchain
-	vals    map[string]*cosmos.ChainNode
+	chains  *Chains
 	relayer ibc.Relayer
 	eRep    ibc.RelayerExecReporter
 
-	cc map[string]*cosmos.CosmosChain
-
 	chainId string
 }
 
@@ -38,8 +37,7 @@ func NewInfo(
 	installDir string,
 	ctx context.Context,
 	ic *interchaintest.Interchain,
-	cosmosChains map[string]*cosmos.CosmosChain,
-	vals map[string]*cosmos.ChainNode,
+	chains *Chains,
 	relayer ibc.Relayer,
 	eRep ibc.RelayerExecReporter,
 ) *info {
@@ -49,8 +47,7 @@ func NewInfo(
 
 		ctx:     ctx,
 		ic:      ic,
-		vals:    vals,
-		cc:      cosmosChains,
+		chains:  chains,
 		relayer: relayer,
 		eRep:    eRep,
 	}
@@ -78,7 +75,16 @@ func (i *info) GetInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	i.chainId = chainId[0]
 
-	val := i.vals[i.chainId]
+	if chain, ok := i.chains.Ethereum(i.chainId); ok {
+		ethInfo(w, r, i, chain, res[0])
+		return
+	}
+
+	val, ok := i.chains.Validator(i.chainId)
+	if !ok {
+		util.WriteError(w, fmt.Errorf("chain_id %s not found", i.chainId))
+		return
+	}
 
 	switch res[0] {
 	case "logs":
@@ -112,6 +118,36 @@ func (i *info) GetInfo(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ethInfo serves the info requests supported by ethereum chains.
+func ethInfo(w http.ResponseWriter, r *http.Request, i *info, chain *ethereum.EthereumChain, request string) {
+	switch request {
+	case "logs":
+		get_logs(w, r, i)
+	case "config":
+		jsonRes, err := MarshalIBCChainConfig(chain.Config())
+		if err != nil {
+			util.WriteError(w, fmt.Errorf("failed to marshal config: %w", err))
+			return
+		}
+		util.Write(w, jsonRes)
+	case "name":
+		util.Write(w, []byte(chain.Name()))
+	case "hostname":
+		util.Write(w, []byte(chain.HostName()))
+	case "home_dir":
+		util.Write(w, []byte(chain.HomeDir()))
+	case "height":
+		height, _ := chain.Height(i.ctx)
+		util.Write(w, []byte(strconv.Itoa(int(height))))
+	case "json_rpc_address":
+		util.Write(w, []byte(chain.GetHostRPCAddress()))
+	case "ws_address":
+		util.Write(w, []byte(chain.GetHostWSAddress()))
+	default:
+		util.WriteError(w, fmt.Errorf("invalid get param for ethereum chain: %s. does not exist", request))
+	}
+}
+
 func config(w http.ResponseWriter, r *http.Request, val *cosmos.ChainNode) {
 	cfg := val.Chain.Config()
 	jsonRes, err := MarshalIBCChainConfig(cfg)
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// OpenAPISpec documents the v1 API. Keep it in sync with v1.go and the routes of the router.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+func GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	util.Write(w, OpenAPISpec)
+}
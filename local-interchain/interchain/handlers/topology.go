@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// ErrInvalidTopology is wrapped by Topology errors caused by the request
+// rather than by the chains or relayer.
+var ErrInvalidTopology = errors.New("invalid topology change")
+
+// Topology changes the chains and IBC paths of the running environment.
+type Topology interface {
+	// AddChains starts the chains of a config file in the chains directory,
+	// and links the IBC paths that the new chains share with each other or with running chains.
+	AddChains(configFile string) error
+
+	// AddIBCPath creates a new path between two running chains and relays it with the running relayer.
+	AddIBCPath(path, chainA, chainB string) error
+
+	// SaveSession stops the chains and relayer, and archives them as the named session,
+	// returning the path of the archive. Once the chains are stopped, whether or not the archive succeeds,
+	// local-ic exits after answering the pending API requests.
+	SaveSession(name string) (string, error)
+}
+
+type AddChainsRequest struct {
+	// Config file in the chains directory, e.g. "juno.json".
+	Config string `json:"config"`
+}
+
+type AddIBCPathRequest struct {
+	Path   string `json:"path"`
+	ChainA string `json:"chain_a"`
+	ChainB string `json:"chain_b"`
+}
+
+type SaveSessionRequest struct {
+	Name string `json:"name"`
+}
+
+type SaveSessionResponse struct {
+	Name string `json:"name"`
+	// Path of the archive, on the machine running local-ic.
+	Path string `json:"path"`
+}
+
+var sessionNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+type topology struct {
+	t    Topology
+	info *info
+}
+
+// NewTopology returns the handlers of topology changes, which respond with the updated info.
+func NewTopology(t Topology, info *info) *topology {
+	return &topology{
+		t:    t,
+		info: info,
+	}
+}
+
+func (t *topology) PostChains(w http.ResponseWriter, r *http.Request) {
+	var req AddChainsRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Config == "" {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("config is required"))
+		return
+	}
+
+	t.respond(w, r, t.t.AddChains(req.Config))
+}
+
+func (t *topology) PostIBCPaths(w http.ResponseWriter, r *http.Request) {
+	var req AddIBCPathRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Path == "" || req.ChainA == "" || req.ChainB == "" {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("path, chain_a and chain_b are required"))
+		return
+	}
+
+	t.respond(w, r, t.t.AddIBCPath(req.Path, req.ChainA, req.ChainB))
+}
+
+func (t *topology) PostSessions(w http.ResponseWriter, r *http.Request) {
+	var req SaveSessionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !sessionNameRe.MatchString(req.Name) {
+		util.WriteJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid session name %q", req.Name))
+		return
+	}
+
+	archive, err := t.t.SaveSession(req.Name)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, SaveSessionResponse{Name: req.Name, Path: archive})
+}
+
+// action runs the add-chain and add-ibc-path actions, reporting whether ah was one of them.
+func (t *topology) action(w http.ResponseWriter, r *http.Request, ah ActionHandler) bool {
+	cmdMap := parseCmdMap(ah.Cmd)
+
+	var err error
+	switch ah.Action {
+	case "add-chain", "add_chain", "addChain":
+		cfg, ok := cmdMap["config"]
+		if !ok {
+			util.WriteError(w, fmt.Errorf("config not found in commands"))
+			return true
+		}
+		err = t.t.AddChains(cfg)
+	case "add-ibc-path", "add_ibc_path", "addIBCPath":
+		path, ok1 := cmdMap["path"]
+		chainA, ok2 := cmdMap["chain_a"]
+		chainB, ok3 := cmdMap["chain_b"]
+		if !ok1 || !ok2 || !ok3 {
+			util.WriteError(w, fmt.Errorf("path, chain_a or chain_b not found in commands"))
+			return true
+		}
+		err = t.t.AddIBCPath(path, chainA, chainB)
+	default:
+		return false
+	}
+
+	if err != nil {
+		util.WriteError(w, err)
+		return true
+	}
+	get_logs(w, r, t.info)
+	return true
+}
+
+func (t *topology) respond(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidTopology):
+		util.WriteJSONError(w, http.StatusBadRequest, err)
+	case err != nil:
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		get_logs(w, r, t.info)
+	}
+}
@@ -8,13 +8,12 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/localinterchain/interchain/util"
 )
 
 type upload struct {
-	ctx  context.Context
-	vals map[string]*cosmos.ChainNode
+	ctx    context.Context
+	chains *Chains
 }
 
 type Uploader struct {
@@ -25,10 +24,10 @@ type Uploader struct {
 	KeyName string `json:"key_name,omitempty"`
 }
 
-func NewUploader(ctx context.Context, vals map[string]*cosmos.ChainNode) *upload {
+func NewUploader(ctx context.Context, chains *Chains) *upload {
 	return &upload{
-		ctx:  ctx,
-		vals: vals,
+		ctx:    ctx,
+		chains: chains,
 	}
 }
 
@@ -47,7 +46,8 @@ func (u *upload) PostUpload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	chainId := upload.ChainId
-	if _, ok := u.vals[chainId]; !ok {
+	val, ok := u.chains.Validator(chainId)
+	if !ok {
 		util.Write(w, []byte(fmt.Sprintf(`{"error":"chain_id %s not found"}`, chainId)))
 		return
 	}
@@ -56,7 +56,7 @@ func (u *upload) PostUpload(w http.ResponseWriter, r *http.Request) {
 	switch headerType {
 	case "cosmwasm":
 		// Upload & Store the contract on chain.
-		codeId, err := u.vals[chainId].StoreContract(u.ctx, upload.KeyName, srcPath)
+		codeId, err := val.StoreContract(u.ctx, upload.KeyName, srcPath)
 		if err != nil {
 			util.WriteError(w, err)
 			return
@@ -67,12 +67,12 @@ func (u *upload) PostUpload(w http.ResponseWriter, r *http.Request) {
 	default:
 		// Upload the file to the docker volume (val[0]).
 		_, file := filepath.Split(srcPath)
-		if err := u.vals[chainId].CopyFile(u.ctx, srcPath, file); err != nil {
+		if err := val.CopyFile(u.ctx, srcPath, file); err != nil {
 			util.WriteError(w, fmt.Errorf(`{"error":"writing contract file to docker volume: %w"}`, err))
 			return
 		}
 
-		home := u.vals[chainId].HomeDir()
+		home := val.HomeDir()
 		fileLoc := filepath.Join(home, file)
 		util.Write(w, []byte(fmt.Sprintf(`{"success":"file uploaded to %s","location":"%s"}`, chainId, fileLoc)))
 	}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// Requests and responses of the v1 API, documented in openapi.json.
+
+type QueryRequest struct {
+	// Args of the query command, e.g. ["bank", "total"].
+	Args []string `json:"args"`
+}
+
+type TxRequest struct {
+	KeyName string `json:"key_name"`
+	// Args of the tx command, e.g. ["bank", "send", "acc0", "juno1...", "1ujuno"].
+	Args []string `json:"args"`
+}
+
+type TxResponse struct {
+	TxHash string `json:"tx_hash"`
+}
+
+type RecoverKeyRequest struct {
+	KeyName  string `json:"key_name"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+type RecoverKeyResponse struct {
+	KeyName string `json:"key_name"`
+	Address string `json:"address"`
+}
+
+type AddFullNodesRequest struct {
+	Amount int `json:"amount"`
+}
+
+type AddFullNodesResponse struct {
+	Added     int `json:"added"`
+	FullNodes int `json:"full_nodes"`
+}
+
+type RelayerStartRequest struct {
+	Paths []string `json:"paths"`
+}
+
+type RelayerExecRequest struct {
+	// Args of the relayer command, e.g. ["rly", "transact", "flush", "path", "channel-0"].
+	Args []string `json:"args"`
+}
+
+type RelayerExecResponse struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+type StoreContractRequest struct {
+	KeyName string `json:"key_name"`
+	// FilePath of the wasm file on the machine running local-ic.
+	FilePath string `json:"file_path"`
+}
+
+type StoreContractResponse struct {
+	CodeID string `json:"code_id"`
+}
+
+type InstantiateContractRequest struct {
+	KeyName string          `json:"key_name"`
+	CodeID  string          `json:"code_id"`
+	Msg     json.RawMessage `json:"msg"`
+	// Admin of the contract. The contract has no admin if empty.
+	Admin string `json:"admin,omitempty"`
+	// Amount of funds sent to the contract, e.g. "1000ujuno".
+	Amount string `json:"amount,omitempty"`
+}
+
+type InstantiateContractResponse struct {
+	ContractAddress string `json:"contract_address"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+var errRelayerNotConfigured = errors.New("relayer not configured for this setup")
+
+// decodeRequest decodes the JSON body into req, rejecting unknown fields.
+func decodeRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		util.WriteJSONError(w, http.StatusBadRequest, fmt.Errorf("failed to decode json: %w", err))
+		return false
+	}
+	return true
+}
+
+// v1Chain returns the cosmos chain and validator of the chain_id path variable.
+func (a *actions) v1Chain(w http.ResponseWriter, r *http.Request) (*cosmos.CosmosChain, *cosmos.ChainNode, bool) {
+	chainID := mux.Vars(r)["chain_id"]
+	chain, val, ok := a.chains.Cosmos(chainID)
+	if !ok {
+		util.WriteJSONError(w, http.StatusNotFound, fmt.Errorf("chain_id %s not found", chainID))
+		return nil, nil, false
+	}
+	return chain, val, true
+}
+
+func (a *actions) V1Query(w http.ResponseWriter, r *http.Request) {
+	_, val, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req QueryRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.Args) == 0 {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("args are required"))
+		return
+	}
+
+	stdout, _, err := val.ExecQuery(a.ctx, req.Args...)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	util.Write(w, stdout)
+}
+
+func (a *actions) V1Tx(w http.ResponseWriter, r *http.Request) {
+	_, val, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req TxRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.KeyName == "" || len(req.Args) == 0 {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("key_name and args are required"))
+		return
+	}
+
+	txHash, err := val.ExecTx(a.ctx, req.KeyName, req.Args...)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, TxResponse{TxHash: txHash})
+}
+
+func (a *actions) V1RecoverKey(w http.ResponseWriter, r *http.Request) {
+	_, val, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req RecoverKeyRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.KeyName == "" || req.Mnemonic == "" {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("key_name and mnemonic are required"))
+		return
+	}
+
+	// An existing key is not an error, as with the recover-key action.
+	if err := val.RecoverKey(a.ctx, req.KeyName, req.Mnemonic); err != nil && !strings.Contains(err.Error(), "aborted") {
+		util.WriteJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to recover key: %w", err))
+		return
+	}
+
+	addr, err := val.AccountKeyBech32(a.ctx, req.KeyName)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, RecoverKeyResponse{KeyName: req.KeyName, Address: addr})
+}
+
+func (a *actions) V1AddFullNodes(w http.ResponseWriter, r *http.Request) {
+	chain, _, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req AddFullNodesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Amount < 1 {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("amount must be positive"))
+		return
+	}
+
+	if err := chain.AddFullNodes(a.ctx, nil, req.Amount); err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to add full nodes: %w", err))
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, AddFullNodesResponse{Added: req.Amount, FullNodes: len(chain.FullNodes)})
+}
+
+func (a *actions) V1StoreContract(w http.ResponseWriter, r *http.Request) {
+	chain, _, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req StoreContractRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.KeyName == "" || req.FilePath == "" {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("key_name and file_path are required"))
+		return
+	}
+	if _, err := os.Stat(req.FilePath); err != nil {
+		util.WriteJSONError(w, http.StatusBadRequest, fmt.Errorf("file %s does not exist on the source machine", req.FilePath))
+		return
+	}
+
+	codeID, err := chain.StoreContract(a.ctx, req.KeyName, req.FilePath)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, StoreContractResponse{CodeID: codeID})
+}
+
+func (a *actions) V1InstantiateContract(w http.ResponseWriter, r *http.Request) {
+	chain, _, ok := a.v1Chain(w, r)
+	if !ok {
+		return
+	}
+	var req InstantiateContractRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.KeyName == "" || req.CodeID == "" || !json.Valid(req.Msg) {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("key_name, code_id and a JSON msg are required"))
+		return
+	}
+
+	var extraArgs []string
+	if req.Admin != "" {
+		extraArgs = append(extraArgs, "--admin", req.Admin)
+	}
+	if req.Amount != "" {
+		extraArgs = append(extraArgs, "--amount", req.Amount)
+	}
+
+	addr, err := chain.InstantiateContract(a.ctx, req.KeyName, req.CodeID, string(req.Msg), req.Admin == "", extraArgs...)
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, InstantiateContractResponse{ContractAddress: addr})
+}
+
+func (a *actions) V1Channels(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := a.v1Chain(w, r); !ok {
+		return
+	}
+	if a.relayer == nil {
+		util.WriteJSONError(w, http.StatusServiceUnavailable, errRelayerNotConfigured)
+		return
+	}
+
+	channels, err := a.relayer.GetChannels(a.ctx, a.eRep, mux.Vars(r)["chain_id"])
+	if err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, channels)
+}
+
+func (a *actions) V1RelayerStart(w http.ResponseWriter, r *http.Request) {
+	if a.relayer == nil {
+		util.WriteJSONError(w, http.StatusServiceUnavailable, errRelayerNotConfigured)
+		return
+	}
+	var req RelayerStartRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := a.relayer.StartRelayer(a.ctx, a.eRep, req.Paths...); err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, StatusResponse{Status: "started"})
+}
+
+func (a *actions) V1RelayerStop(w http.ResponseWriter, r *http.Request) {
+	if a.relayer == nil {
+		util.WriteJSONError(w, http.StatusServiceUnavailable, errRelayerNotConfigured)
+		return
+	}
+
+	if err := a.relayer.StopRelayer(a.ctx, a.eRep); err != nil {
+		util.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, StatusResponse{Status: "stopped"})
+}
+
+func (a *actions) V1RelayerExec(w http.ResponseWriter, r *http.Request) {
+	if a.relayer == nil {
+		util.WriteJSONError(w, http.StatusServiceUnavailable, errRelayerNotConfigured)
+		return
+	}
+	var req RelayerExecRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.Args) == 0 {
+		util.WriteJSONError(w, http.StatusBadRequest, errors.New("args are required"))
+		return
+	}
+
+	cmd := req.Args
+	if !contains(cmd, "--home") {
+		cmd = append(cmd, "--home", "/home/relayer")
+	}
+
+	res := a.relayer.Exec(a.ctx, a.eRep, cmd, nil)
+	if res.Err != nil && res.ExitCode == 0 {
+		util.WriteJSONError(w, http.StatusInternalServerError, res.Err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, RelayerExecResponse{
+		Stdout:   string(res.Stdout),
+		Stderr:   string(res.Stderr),
+		ExitCode: res.ExitCode,
+	})
+}
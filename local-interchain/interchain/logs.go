@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	types "github.com/strangelove-ventures/localinterchain/interchain/types"
 	"go.uber.org/zap"
@@ -27,20 +28,37 @@ func DumpChainsInfoToLogs(configDir string, config *types.Config, chains []ibc.C
 
 	// Iterate chain config & get the ibc chain's to save data to logs.
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
-
 		ibcPaths := chain.IBCPaths
 		if ibcPaths == nil {
 			ibcPaths = []string{}
 		}
 
-		log := types.LogOutput{
-			ChainID:     chainObj.Config().ChainID,
-			ChainName:   chainObj.Config().Name,
-			RPCAddress:  chainObj.GetHostRPCAddress(),
-			RESTAddress: chainObj.GetHostAPIAddress(),
-			GRPCAddress: chainObj.GetHostGRPCAddress(),
-			IBCPath:     ibcPaths,
+		var log types.LogOutput
+		switch chainObj := chains[idx].(type) {
+		case *cosmos.CosmosChain:
+			log = types.LogOutput{
+				ChainID:     chainObj.Config().ChainID,
+				ChainName:   chainObj.Config().Name,
+				RPCAddress:  chainObj.GetHostRPCAddress(),
+				RESTAddress: chainObj.GetHostAPIAddress(),
+				GRPCAddress: chainObj.GetHostGRPCAddress(),
+				IBCPath:     ibcPaths,
+			}
+			if chainObj.Config().EVM != nil {
+				log.JSONRPCAddress = chainObj.GetHostEVMRPCAddress()
+				log.WSAddress = chainObj.GetHostEVMWSAddress()
+			}
+		case *ethereum.EthereumChain:
+			log = types.LogOutput{
+				ChainID:        chainObj.Config().ChainID,
+				ChainName:      chainObj.Config().Name,
+				RPCAddress:     chainObj.GetHostRPCAddress(),
+				IBCPath:        ibcPaths,
+				JSONRPCAddress: chainObj.GetHostRPCAddress(),
+				WSAddress:      chainObj.GetHostWSAddress(),
+			}
+		default:
+			continue
 		}
 
 		mainLogs.Chains = append(mainLogs.Chains, log)
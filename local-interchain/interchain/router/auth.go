@@ -0,0 +1,38 @@
+package router
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// openPaths do not require the auth key.
+var openPaths = map[string]bool{
+	"/openapi.json": true,
+}
+
+// bearerAuth rejects requests without the auth key as bearer token. Without an auth key, all requests pass.
+func bearerAuth(authKey string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if authKey == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			token := strings.TrimPrefix(header, "Bearer ")
+			valid := token != header && subtle.ConstantTimeCompare([]byte(token), []byte(authKey)) == 1
+			if valid || openPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			util.WriteJSONError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+		})
+	}
+}
@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	dockerclient "github.com/docker/docker/client"
 	"github.com/gorilla/mux"
 	"github.com/strangelove-ventures/interchaintest/v7"
-	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
 	ictypes "github.com/strangelove-ventures/localinterchain/interchain/types"
 	"github.com/strangelove-ventures/localinterchain/interchain/util"
 
@@ -24,23 +25,47 @@ func NewRouter(
 	ctx context.Context,
 	ic *interchaintest.Interchain,
 	config *ictypes.Config,
-	cosmosChains map[string]*cosmos.CosmosChain,
-	vals map[string]*cosmos.ChainNode,
+	chains *handlers.Chains,
 	relayer ibc.Relayer,
 	eRep ibc.RelayerExecReporter,
+	hub *events.Hub,
+	cli *dockerclient.Client,
+	topology handlers.Topology,
 	installDir string,
+	authKey string,
 ) *mux.Router {
 	r := mux.NewRouter()
+	r.Use(bearerAuth(authKey))
 
-	infoH := handlers.NewInfo(config, installDir, ctx, ic, cosmosChains, vals, relayer, eRep)
+	infoH := handlers.NewInfo(config, installDir, ctx, ic, chains, relayer, eRep)
 	r.HandleFunc("/info", infoH.GetInfo).Methods(http.MethodGet)
 
-	actionsH := handlers.NewActions(ctx, ic, cosmosChains, vals, relayer, eRep)
+	topologyH := handlers.NewTopology(topology, infoH)
+	actionsH := handlers.NewActions(ctx, ic, chains, relayer, eRep, topologyH)
 	r.HandleFunc("/", actionsH.PostActions).Methods(http.MethodPost)
 
-	uploaderH := handlers.NewUploader(ctx, vals)
+	uploaderH := handlers.NewUploader(ctx, chains)
 	r.HandleFunc("/upload", uploaderH.PostUpload).Methods(http.MethodPost)
 
+	eventsH := handlers.NewEvents(ctx, hub, cli, chains)
+	r.HandleFunc("/events", eventsH.GetEvents).Methods(http.MethodGet)
+
+	// Typed actions, documented in /openapi.json.
+	r.HandleFunc("/openapi.json", handlers.GetOpenAPI).Methods(http.MethodGet)
+	r.HandleFunc("/v1/chains", topologyH.PostChains).Methods(http.MethodPost)
+	r.HandleFunc("/v1/ibc-paths", topologyH.PostIBCPaths).Methods(http.MethodPost)
+	r.HandleFunc("/v1/sessions", topologyH.PostSessions).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/query", actionsH.V1Query).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/tx", actionsH.V1Tx).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/keys/recover", actionsH.V1RecoverKey).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/full-nodes", actionsH.V1AddFullNodes).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/contracts/store", actionsH.V1StoreContract).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/contracts/instantiate", actionsH.V1InstantiateContract).Methods(http.MethodPost)
+	r.HandleFunc("/v1/chains/{chain_id}/channels", actionsH.V1Channels).Methods(http.MethodGet)
+	r.HandleFunc("/v1/relayer/start", actionsH.V1RelayerStart).Methods(http.MethodPost)
+	r.HandleFunc("/v1/relayer/stop", actionsH.V1RelayerStop).Methods(http.MethodPost)
+	r.HandleFunc("/v1/relayer/exec", actionsH.V1RelayerExec).Methods(http.MethodPost)
+
 	availableRoutes := getAllMethods(*r)
 	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		jsonRes, err := json.MarshalIndent(availableRoutes, "", "  ")
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	ictypes "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouter_OpenAPI checks that openapi.json documents exactly the /v1 routes of the router.
+func TestRouter_OpenAPI(t *testing.T) {
+	r := NewRouter(context.Background(), nil, &ictypes.Config{}, handlers.NewChains(), nil, nil, nil, nil, nil, t.TempDir(), "")
+
+	var routed []string
+	for _, route := range getAllMethods(*r) {
+		if !strings.HasPrefix(route.Path, "/v1/") {
+			continue
+		}
+		for _, m := range route.Methods {
+			routed = append(routed, m+" "+route.Path)
+		}
+	}
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(handlers.OpenAPISpec, &spec))
+
+	var documented []string
+	for path, item := range spec.Paths {
+		for m := range item {
+			switch m := strings.ToUpper(m); m {
+			case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+				documented = append(documented, m+" "+path)
+			}
+		}
+	}
+
+	sort.Strings(routed)
+	sort.Strings(documented)
+	require.NotEmpty(t, routed)
+	require.Equal(t, routed, documented)
+}
@@ -0,0 +1,388 @@
+package interchain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// Session describes a saved environment. It is stored as session.json at the root of the session archive,
+// next to the node homes (chains/<chain_id>/<val|fn>-<index>.tar), the anvil states
+// (chains/<chain_id>/anvil_state.json) and the relayer home (relayer.tar).
+type Session struct {
+	Name string `json:"name"`
+	// TestName is kept on restore, since the container host names in the node and relayer configs derive from it.
+	TestName string            `json:"test_name"`
+	SavedAt  time.Time         `json:"saved_at"`
+	Heights  map[string]uint64 `json:"heights"`
+	// Config is the resolved config of the running chains, including those added at runtime.
+	Config *types.Config `json:"config"`
+}
+
+const (
+	sessionFile     = "session.json"
+	relayerArchive  = "relayer.tar"
+	anvilStateFile  = "anvil_state.json"
+	sessionsDirName = "sessions"
+
+	// resumeTimeout bounds the wait for a restored chain to produce its first block.
+	resumeTimeout = time.Minute
+)
+
+// homeArchiver is implemented by the docker relayers.
+type homeArchiver interface {
+	ArchiveHome(ctx context.Context, w io.Writer) error
+	RestoreHome(ctx context.Context, r io.Reader) error
+}
+
+// SessionArchivePath returns the archive of the named session in the install directory,
+// or session itself if it is the path of a .tar.gz archive, e.g. one shared by a teammate.
+func SessionArchivePath(installDir, session string) string {
+	if strings.HasSuffix(session, ".tar.gz") {
+		return session
+	}
+	return filepath.Join(installDir, sessionsDirName, session+".tar.gz")
+}
+
+func nodeArchiveName(n *cosmos.ChainNode) string {
+	nodeType := "fn"
+	if n.Validator {
+		nodeType = "val"
+	}
+	return path.Join("chains", n.Chain.Config().ChainID, fmt.Sprintf("%s-%d.tar", nodeType, n.Index))
+}
+
+// SaveSession stops the chains and relayer, and archives them with the resolved config
+// to sessions/<name>.tar.gz in the install directory.
+func (t *topology) SaveSession(name string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	dir := filepath.Join(t.installDir, sessionsDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, name+"-*.tar.gz.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	session := Session{
+		Name:     name,
+		TestName: t.opts.TestName,
+		SavedAt:  time.Now().UTC(),
+		Heights:  make(map[string]uint64, len(t.chains)),
+		Config:   t.config,
+	}
+
+	// Heights and anvil states are read while the chains still run.
+	for _, chain := range t.chains {
+		chainID := chain.Config().ChainID
+		height, err := chain.Height(t.ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get height of %s: %w", chainID, err)
+		}
+		session.Heights[chainID] = height
+
+		if c, ok := chain.(*ethereum.EthereumChain); ok {
+			state, err := c.DumpState(t.ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to save state of %s: %w", chainID, err)
+			}
+			if err := addTarFile(tw, path.Join("chains", chainID, anvilStateFile), state); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// From here on the environment is stopped, whether or not the archive succeeds.
+	t.saving.Store(true)
+	defer t.stopped()
+
+	if t.relayer != nil {
+		if err := t.relayer.StopRelayer(t.ctx, t.eRep); err != nil {
+			log.Println("failed to stop relayer:", err)
+		}
+	}
+
+	for _, chain := range t.chains {
+		c, ok := chain.(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
+		if err := c.StopAllNodes(t.ctx); err != nil {
+			return "", fmt.Errorf("failed to stop %s: %w", c.Config().ChainID, err)
+		}
+		for _, n := range c.Nodes() {
+			if err := addTarArchive(tw, nodeArchiveName(n), func(w io.Writer) error {
+				return n.ArchiveHome(t.ctx, w)
+			}); err != nil {
+				return "", fmt.Errorf("failed to archive %s: %w", n.Name(), err)
+			}
+		}
+	}
+
+	if t.relayer != nil {
+		r, ok := t.relayer.(homeArchiver)
+		if !ok {
+			return "", fmt.Errorf("relayer %T cannot be archived", t.relayer)
+		}
+		if err := addTarArchive(tw, relayerArchive, func(w io.Writer) error {
+			return r.ArchiveHome(t.ctx, w)
+		}); err != nil {
+			return "", fmt.Errorf("failed to archive relayer: %w", err)
+		}
+	}
+
+	bz, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := addTarFile(tw, sessionFile, bz); err != nil {
+		return "", err
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	archive := SessionArchivePath(t.installDir, name)
+	if err := os.Rename(f.Name(), archive); err != nil {
+		return "", err
+	}
+	return archive, nil
+}
+
+func addTarFile(tw *tar.Writer, name string, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Size:    int64(len(content)),
+		Mode:    0644,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// addTarArchive adds the tar stream written by write as the named file.
+// The stream is buffered to a temporary file, since its size must be known beforehand.
+func addTarArchive(tw *tar.Writer, name string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp("", "local-ic-archive-*.tar")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Size:    size,
+		Mode:    0644,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+// extractSession extracts the session archive to dir, replacing its content, and returns the session.
+func extractSession(archive, dir string) (*Session, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archive, err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archive, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Reject entries escaping the session directory.
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid path %q in %s", hdr.Name, archive)
+		}
+
+		dst := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return nil, err
+		}
+		out, err := os.Create(dst)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(out, tr)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bz, err := os.ReadFile(filepath.Join(dir, sessionFile))
+	if err != nil {
+		return nil, fmt.Errorf("%s not found in %s: %w", sessionFile, archive, err)
+	}
+	var session Session
+	if err := json.Unmarshal(bz, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", sessionFile, err)
+	}
+	if session.Config == nil || session.TestName == "" {
+		return nil, fmt.Errorf("%s of %s is incomplete", sessionFile, archive)
+	}
+
+	// Anvil chains start from their saved state.
+	for i, cfg := range session.Config.Chains {
+		state := filepath.Join(dir, "chains", cfg.ChainID, anvilStateFile)
+		if _, err := os.Stat(state); err == nil && cfg.IsEthereum() {
+			session.Config.Chains[i].Anvil.LoadState = state
+		}
+	}
+
+	return &session, nil
+}
+
+// restoreHomes replaces the homes of the nodes and relayer of the freshly built chains
+// with those of the session extracted to dir, and restarts the nodes.
+func restoreHomes(ctx context.Context, dir string, heights map[string]uint64, chains []ibc.Chain, relayer ibc.Relayer) error {
+	for _, chain := range chains {
+		c, ok := chain.(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
+
+		if err := c.StopAllNodes(ctx); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", c.Config().ChainID, err)
+		}
+		for _, n := range c.Nodes() {
+			if err := restoreHome(filepath.Join(dir, filepath.FromSlash(nodeArchiveName(n))), func(r io.Reader) error {
+				return n.RestoreHome(ctx, r)
+			}); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", n.Name(), err)
+			}
+		}
+		if err := c.StartAllNodes(ctx); err != nil {
+			return fmt.Errorf("failed to start %s: %w", c.Config().ChainID, err)
+		}
+		if err := verifyResumedHeight(ctx, c, heights); err != nil {
+			return err
+		}
+	}
+
+	if relayer == nil {
+		return nil
+	}
+	r, ok := relayer.(homeArchiver)
+	if !ok {
+		return fmt.Errorf("relayer %T cannot be restored", relayer)
+	}
+	if err := restoreHome(filepath.Join(dir, relayerArchive), func(rd io.Reader) error {
+		return r.RestoreHome(ctx, rd)
+	}); err != nil {
+		return fmt.Errorf("failed to restore relayer: %w", err)
+	}
+	return nil
+}
+
+// verifyResumedHeight waits for the restarted chain to produce a block and checks that it resumed
+// from the height saved in the session, rather than from a reinitialized home.
+func verifyResumedHeight(ctx context.Context, chain ibc.Chain, heights map[string]uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, resumeTimeout)
+	defer cancel()
+
+	chainID := chain.Config().ChainID
+	if err := testutil.WaitForBlocks(ctx, 1, chain); err != nil {
+		return fmt.Errorf("%s did not resume producing blocks: %w", chainID, err)
+	}
+	height, err := chain.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height of %s: %w", chainID, err)
+	}
+	return checkResumedHeight(chainID, heights, height)
+}
+
+// checkResumedHeight returns an error if chainID resumed below the height saved in heights.
+func checkResumedHeight(chainID string, heights map[string]uint64, resumed uint64) error {
+	saved, ok := heights[chainID]
+	if !ok {
+		return fmt.Errorf("session has no saved height for %s", chainID)
+	}
+	if resumed < saved {
+		return fmt.Errorf("%s resumed at height %d, below its saved height %d: its home was reinitialized", chainID, resumed, saved)
+	}
+	return nil
+}
+
+func restoreHome(archive string, restore func(r io.Reader) error) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return restore(f)
+}
@@ -0,0 +1,16 @@
+package interchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResumedHeight(t *testing.T) {
+	heights := map[string]uint64{"localjuno-1": 120}
+
+	require.NoError(t, checkResumedHeight("localjuno-1", heights, 120))
+	require.NoError(t, checkResumedHeight("localjuno-1", heights, 125))
+	require.ErrorContains(t, checkResumedHeight("localjuno-1", heights, 3), "home was reinitialized")
+	require.ErrorContains(t, checkResumedHeight("localcosmos-1", heights, 125), "no saved height")
+}
@@ -2,36 +2,82 @@ package interchain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"net/http"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/strangelove-ventures/interchaintest/v7"
-	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	interchaintestrelayer "github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
 	"github.com/strangelove-ventures/localinterchain/interchain/router"
 	"github.com/strangelove-ventures/localinterchain/interchain/types"
 	"go.uber.org/zap"
 )
 
+// eventsPollInterval is the interval at which chains are polled for new blocks to stream.
+const eventsPollInterval = 500 * time.Millisecond
+
 type AppConfig struct {
 	Address string
 	Port    uint16
+
+	// AuthKey, if set, must be sent as a bearer token to the REST API.
+	AuthKey string
 }
 
 func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
+	config, err := LoadConfig(installDir, chainCfgFile)
+	if err != nil {
+		// try again with .json, then if it still fails - panic
+		config, err = LoadConfig(installDir, chainCfgFile+".json")
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	name := strings.ReplaceAll(chainCfgFile, ".json", "") + "ic"
+	startChain(installDir, name, config, ac, "", nil)
+}
+
+// RestoreChain starts the chains of a session saved with SaveSession,
+// from the height, keys and channels they had when saved.
+func RestoreChain(installDir, session string, ac *AppConfig) {
+	archive := SessionArchivePath(installDir, session)
+	dir := filepath.Join(installDir, sessionsDirName, strings.TrimSuffix(filepath.Base(archive), ".tar.gz"))
+
+	s, err := extractSession(archive, dir)
+	if err != nil {
+		log.Fatal("RestoreChain: ", err)
+	}
+	log.Printf("Restoring session %s saved at %s, heights %v\n", s.Name, s.SavedAt.Format(time.RFC3339), s.Heights)
+
+	startChain(installDir, s.TestName, s.Config, ac, dir, s.Heights)
+}
+
+// startChain runs the chains of config until they stop or are saved.
+// If sessionDir is set, the node and relayer homes are restored from the session extracted there,
+// and each chain must resume at or above its height in savedHeights.
+func startChain(installDir, name string, config *types.Config, ac *AppConfig, sessionDir string, savedHeights map[string]uint64) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	restoring := sessionDir != ""
+
 	var relayer ibc.Relayer
 	var eRep *testreporter.RelayerExecReporter
 
-	vals := make(map[string]*cosmos.ChainNode)
+	// Streams the events of the chains and relayer at /events.
+	hub := events.NewHub()
+
 	ic := interchaintest.NewInterchain()
 	defer ic.Close()
 
@@ -44,7 +90,7 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 	// go func() {
 	// 	for sig := range c {
 	// 		log.Printf("Closing from signal: %s\n", sig)
-	// 		handlers.KillAll(ctx, ic, vals, relayer, eRep)
+	// 		handlers.KillAll(ctx, ic, topo.served.Validators(), relayer, eRep)
 	// 	}
 	// }()
 
@@ -54,15 +100,6 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 		panic(err)
 	}
 
-	config, err := LoadConfig(installDir, chainCfgFile)
-	if err != nil {
-		// try again with .json, then if it still fails - panic
-		config, err = LoadConfig(installDir, chainCfgFile+".json")
-		if err != nil {
-			panic(err)
-		}
-	}
-
 	WriteRunningChains(installDir, []byte("{}"))
 
 	// ibc-path-name -> index of []cosmos.CosmosChain
@@ -88,7 +125,6 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 	cf := interchaintest.NewBuiltinChainFactory(logger, chainSpecs)
 
 	// Get chains from the chain factory
-	name := strings.ReplaceAll(chainCfgFile, ".json", "") + "ic"
 	chains, err := cf.Chains(name)
 	if err != nil {
 		log.Fatal("cf.Chains", err)
@@ -107,6 +143,9 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 	rep := testreporter.NewNopReporter()
 	eRep = rep.RelayerExecReporter(&fakeT)
 
+	// Relayer execs after the build are also published to the event stream.
+	streamRep := events.NewRelayerExecReporter(hub, eRep)
+
 	client, network := interchaintest.DockerSetup(fakeT)
 
 	// setup a relayer if we have IBC paths to use.
@@ -134,71 +173,108 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 	}
 
 	// Build all chains & begin.
-	err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
-		TestName:         name,
-		Client:           client,
-		NetworkID:        network,
-		SkipPathCreation: false,
+	buildOpts := interchaintest.InterchainBuildOptions{
+		TestName:  name,
+		Client:    client,
+		NetworkID: network,
+		// A restored relayer already has the paths of the session.
+		SkipPathCreation: restoring,
 		// BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
-	})
+	}
+	err = ic.Build(ctx, eRep, buildOpts)
 	if err != nil {
 		logger.Fatal("ic.Build", zap.Error(err))
 	}
 
+	if restoring {
+		if err := restoreHomes(ctx, sessionDir, savedHeights, chains, relayer); err != nil {
+			logger.Fatal("restoreHomes", zap.Error(err))
+		}
+	}
+
 	if relayer != nil && len(ibcpaths) > 0 {
 		paths := make([]string, 0, len(ibcpaths))
 		for k := range ibcpaths {
 			paths = append(paths, k)
 		}
 
-		relayer.StartRelayer(ctx, eRep, paths...)
+		relayer.StartRelayer(ctx, streamRep, paths...)
 		defer func() {
-			relayer.StopRelayer(ctx, eRep)
+			relayer.StopRelayer(ctx, streamRep)
 		}()
 	}
 
-	for _, chain := range chains {
-		if cosmosChain, ok := chain.(*cosmos.CosmosChain); ok {
-			chainID := cosmosChain.Config().ChainID
-			vals[chainID] = cosmosChain.Validators[0]
-		}
-	}
+	topo := &topology{
+		ctx:        ctx,
+		installDir: installDir,
+		logger:     logger,
+		opts:       buildOpts,
+		ic:         ic,
+		relayer:    relayer,
+		eRep:       streamRep,
+		hub:        hub,
 
-	// Starts a non blocking REST server to take action on the chain.
-	go func() {
-		cosmosChains := map[string]*cosmos.CosmosChain{}
-		for _, chain := range chains {
-			if cosmosChain, ok := chain.(*cosmos.CosmosChain); ok {
-				cosmosChains[cosmosChain.Config().ChainID] = cosmosChain
-			}
-		}
+		config:   config,
+		chains:   chains,
+		ibcpaths: ibcpaths,
 
-		r := router.NewRouter(ctx, ic, config, cosmosChains, vals, relayer, eRep, installDir)
+		served: handlers.NewChains(),
 
-		config.Server = types.RestServer{
-			Host: ac.Address,
-			Port: fmt.Sprintf("%d", ac.Port),
-		}
+		done: make(chan struct{}),
+	}
+	topo.track(chains)
 
-		server := fmt.Sprintf("%s:%s", config.Server.Host, config.Server.Port)
-		if err := http.ListenAndServe(server, r); err != nil {
+	config.Server = types.RestServer{
+		Host: ac.Address,
+		Port: fmt.Sprintf("%d", ac.Port),
+	}
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%s", config.Server.Host, config.Server.Port),
+		Handler: router.NewRouter(ctx, ic, config, topo.served, relayer, streamRep, hub, client, topo, installDir, ac.AuthKey),
+	}
+
+	// Starts a non blocking REST server to take action on the chain.
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Default().Println(err)
 		}
 	}()
 
-	AddGenesisKeysToKeyring(ctx, config, chains)
+	// Restored chains already have the keys and state of their startup commands.
+	if !restoring {
+		AddGenesisKeysToKeyring(ctx, config, chains)
 
-	// run commands for each server after startup. Iterate chain configs
-	PostStartupCommands(ctx, config, chains)
+		// run commands for each server after startup. Iterate chain configs
+		PostStartupCommands(ctx, config, chains)
+	}
 
-	connections := GetChannelConnections(ctx, ibcpaths, chains, ic, relayer, eRep)
+	connections := GetChannelConnections(ctx, ibcpaths, chains, ic, relayer, streamRep)
 
 	// Save to logs.json file for runtime chain information.
 	DumpChainsInfoToLogs(installDir, config, chains, connections)
 
 	log.Println("\nLocal-IC API is running on ", fmt.Sprintf("http://%s:%s", config.Server.Host, config.Server.Port))
 
-	if err = testutil.WaitForBlocks(ctx, math.MaxInt, chains[0]); err != nil {
-		log.Fatal("WaitForBlocks StartChain: ", err)
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- testutil.WaitForBlocks(ctx, math.MaxInt, chains[0])
+	}()
+
+	select {
+	case err := <-waitErr:
+		// The chains also stop while a session is saved.
+		if !topo.saving.Load() {
+			log.Fatal("WaitForBlocks StartChain: ", err)
+		}
+		<-topo.done
+	case <-topo.done:
+	}
+
+	// Answers the save request before exiting.
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println("failed to shut down the API:", err)
 	}
+	log.Println("Session saved, local-ic stopped")
 }
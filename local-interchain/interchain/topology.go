@@ -0,0 +1,218 @@
+package interchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	"github.com/strangelove-ventures/localinterchain/interchain/types"
+	"go.uber.org/zap"
+)
+
+// topology tracks the chains and IBC paths of the running environment,
+// and adds new ones to it. It implements handlers.Topology.
+type topology struct {
+	mu sync.Mutex
+
+	ctx        context.Context
+	installDir string
+	logger     *zap.Logger
+	opts       interchaintest.InterchainBuildOptions
+	ic         *interchaintest.Interchain
+	relayer    ibc.Relayer
+	eRep       ibc.RelayerExecReporter
+	hub        *events.Hub
+
+	config *types.Config
+	chains []ibc.Chain
+	// ibc-path-name -> index of chains
+	ibcpaths map[string][]int
+
+	// Shared with the REST API handlers.
+	served *handlers.Chains
+
+	// saving is set once SaveSession starts stopping the environment.
+	saving atomic.Bool
+	// done is closed once the environment is stopped by SaveSession.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+var _ handlers.Topology = (*topology)(nil)
+
+// track registers chains with the REST API and streams their blocks.
+func (t *topology) track(chains []ibc.Chain) {
+	for _, chain := range chains {
+		switch c := chain.(type) {
+		case *cosmos.CosmosChain:
+			t.served.AddCosmos(c)
+			go events.WatchCosmosChain(t.ctx, t.hub, c, eventsPollInterval)
+		case *ethereum.EthereumChain:
+			t.served.AddEthereum(c)
+			go events.WatchEthereumChain(t.ctx, t.hub, c, eventsPollInterval)
+		}
+	}
+}
+
+func (t *topology) AddChains(configFile string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	config, err := LoadConfig(t.installDir, configFile)
+	if err != nil {
+		config, err = LoadConfig(t.installDir, configFile+".json")
+		if err != nil {
+			return fmt.Errorf("%w: failed to load chain config %s: %v", handlers.ErrInvalidTopology, configFile, err)
+		}
+	}
+
+	chainSpecs := make([]*interchaintest.ChainSpec, 0, len(config.Chains))
+	for _, cfg := range config.Chains {
+		if t.chainIndex(cfg.ChainID) >= 0 {
+			return fmt.Errorf("%w: chain_id %s is already running", handlers.ErrInvalidTopology, cfg.ChainID)
+		}
+		_, chainSpec := CreateChainConfigs(cfg)
+		chainSpecs = append(chainSpecs, chainSpec)
+	}
+
+	cf := interchaintest.NewBuiltinChainFactory(t.logger, chainSpecs)
+	chains, err := cf.Chains(t.opts.TestName)
+	if err != nil {
+		return fmt.Errorf("failed to create chains: %w", err)
+	}
+
+	// Chains that started are part of the environment, even if a later step fails.
+	defer t.dumpLogs()
+
+	wallets := SetupGenesisWallets(config, chains)
+	for idx, chain := range chains {
+		if err := t.ic.AddChainAfterBuild(t.ctx, t.opts, chain, wallets[chain]...); err != nil {
+			return err
+		}
+
+		newConfig := &types.Config{Chains: config.Chains[idx : idx+1]}
+		AddGenesisKeysToKeyring(t.ctx, newConfig, chains[idx:idx+1])
+		PostStartupCommands(t.ctx, newConfig, chains[idx:idx+1])
+
+		t.config.Chains = append(t.config.Chains, config.Chains[idx])
+		t.chains = append(t.chains, chain)
+		t.track(chains[idx : idx+1])
+	}
+
+	return t.linkNewPaths()
+}
+
+// linkNewPaths links the paths of the chain configs that are shared by exactly two chains,
+// and were not linked yet.
+func (t *topology) linkNewPaths() error {
+	paths := make(map[string][]int)
+	for idx, cfg := range t.config.Chains {
+		for _, path := range cfg.IBCPaths {
+			if _, ok := t.ibcpaths[path]; !ok {
+				paths[path] = append(paths[path], idx)
+			}
+		}
+	}
+
+	for path, idxs := range paths {
+		if len(idxs) != 2 {
+			log.Printf("Not linking ibc path '%s' of %d chains\n", path, len(idxs))
+			continue
+		}
+		if err := t.link(path, idxs[0], idxs[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *topology) AddIBCPath(path, chainA, chainB string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.ibcpaths[path]; ok {
+		return fmt.Errorf("%w: ibc path '%s' already exists", handlers.ErrInvalidTopology, path)
+	}
+
+	a, b := t.chainIndex(chainA), t.chainIndex(chainB)
+	if a < 0 || b < 0 {
+		return fmt.Errorf("%w: chain_id %s or %s not found", handlers.ErrInvalidTopology, chainA, chainB)
+	}
+
+	err := t.link(path, a, b)
+	if _, ok := t.ibcpaths[path]; ok {
+		// The path was created, even if the relayer failed to restart.
+		t.config.Chains[a].IBCPaths = append(t.config.Chains[a].IBCPaths, path)
+		t.config.Chains[b].IBCPaths = append(t.config.Chains[b].IBCPaths, path)
+		t.dumpLogs()
+	}
+	return err
+}
+
+// link creates the path between the chains at the indexes,
+// and restarts the relayer to relay it along with the existing paths.
+func (t *topology) link(path string, a, b int) error {
+	if t.relayer == nil {
+		return fmt.Errorf("%w: relayer not configured for this setup, start with at least one ibc path", handlers.ErrInvalidTopology)
+	}
+	if a == b {
+		return fmt.Errorf("%w: ibc path '%s' must be between different chains", handlers.ErrInvalidTopology, path)
+	}
+
+	if err := t.ic.AddLinkAfterBuild(t.ctx, t.eRep, interchaintest.InterchainLink{
+		Chain1:  t.chains[a],
+		Chain2:  t.chains[b],
+		Path:    path,
+		Relayer: t.relayer,
+	}); err != nil {
+		return err
+	}
+	t.ibcpaths[path] = []int{a, b}
+
+	paths := make([]string, 0, len(t.ibcpaths))
+	for p := range t.ibcpaths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	if err := t.relayer.StopRelayer(t.ctx, t.eRep); err != nil {
+		return fmt.Errorf("failed to stop relayer: %w", err)
+	}
+	if err := t.relayer.StartRelayer(t.ctx, t.eRep, paths...); err != nil {
+		return fmt.Errorf("failed to restart relayer: %w", err)
+	}
+	return nil
+}
+
+// chainIndex returns the index of the chain with the chain ID, or -1.
+func (t *topology) chainIndex(chainID string) int {
+	for idx, c := range t.chains {
+		if c.Config().ChainID == chainID {
+			return idx
+		}
+	}
+	return -1
+}
+
+// dumpLogs saves the updated environment to logs.json, which /info serves.
+func (t *topology) dumpLogs() {
+	connections := GetChannelConnections(t.ctx, t.ibcpaths, t.chains, t.ic, t.relayer, t.eRep)
+	DumpChainsInfoToLogs(t.installDir, t.config, t.chains, connections)
+}
+
+// stopped reports that the environment was stopped, so that local-ic exits
+// once the pending API requests are answered.
+func (t *topology) stopped() {
+	t.doneOnce.Do(func() {
+		close(t.done)
+	})
+}
@@ -0,0 +1,94 @@
+package interchain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/localinterchain/interchain/events"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	"github.com/strangelove-ventures/localinterchain/interchain/router"
+	"github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeCosmosChain returns a chain with one validator, without any container.
+func fakeCosmosChain(chainID string) *cosmos.CosmosChain {
+	chain := cosmos.NewCosmosChain("TestTopology", ibc.ChainConfig{
+		Type:    "cosmos",
+		Name:    chainID,
+		ChainID: chainID,
+	}, 1, 0, zap.NewNop())
+	chain.Validators = cosmos.ChainNodes{
+		cosmos.NewChainNode(zap.NewNop(), true, chain, nil, "", "TestTopology", ibc.DockerImage{}, 0),
+	}
+	return chain
+}
+
+// Run with -race: tracking chains must not race with the handlers serving requests.
+func TestTopology_TrackWhileServing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// Cancelled so that the chain watchers return instead of polling the fake chains.
+	cancel()
+
+	topo := &topology{
+		ctx:    ctx,
+		hub:    events.NewHub(),
+		served: handlers.NewChains(),
+		done:   make(chan struct{}),
+	}
+	srv := httptest.NewServer(router.NewRouter(ctx, nil, &types.Config{}, topo.served, nil, nil, topo.hub, nil, topo, t.TempDir(), ""))
+	defer srv.Close()
+
+	const numChains = 50
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numChains; i++ {
+			topo.track([]ibc.Chain{fakeCosmosChain(fmt.Sprintf("chain-%d", i))})
+		}
+	}()
+
+	requests := []func() (*http.Response, error){
+		func() (*http.Response, error) {
+			return http.Get(srv.URL + "/info?request=config&chain_id=missing")
+		},
+		func() (*http.Response, error) {
+			return http.Get(srv.URL + "/events?chain_id=missing")
+		},
+		func() (*http.Response, error) {
+			return http.Post(srv.URL+"/", "application/json", strings.NewReader(`{"chain_id":"missing","action":"q","cmd":"bank total"}`))
+		},
+		func() (*http.Response, error) {
+			return http.Post(srv.URL+"/v1/chains/missing/query", "application/json", strings.NewReader(`{"cmd":"bank total"}`))
+		},
+	}
+	for i := 0; i < numChains; i++ {
+		for _, req := range requests {
+			res, err := req()
+			require.NoError(t, err)
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+	}
+
+	wg.Wait()
+
+	for i := 0; i < numChains; i++ {
+		_, ok := topo.served.Validator(fmt.Sprintf("chain-%d", i))
+		require.True(t, ok)
+	}
+	require.Len(t, topo.served.Containers(), numChains)
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"strconv"
+
 	"github.com/go-playground/validator"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 )
@@ -28,6 +30,25 @@ type Chain struct {
 	NumberNode    int      `json:"number_node"`
 	IBCPaths      []string `json:"ibc_paths"`
 	Genesis       Genesis  `json:"genesis"`
+
+	// Anvil options, for chain_type "ethereum" only.
+	Anvil Anvil `json:"anvil,omitempty"`
+}
+
+// Anvil configures the anvil node of an ethereum chain.
+// The chain_id must be numeric, and block_time is rounded to whole seconds.
+type Anvil struct {
+	Accounts        int    `json:"accounts,omitempty"`
+	Balance         int    `json:"balance,omitempty"` // ether per account
+	Mnemonic        string `json:"mnemonic,omitempty"`
+	ForkURL         string `json:"fork_url,omitempty"`
+	ForkBlockNumber uint64 `json:"fork_block_number,omitempty"`
+	LoadState       string `json:"load_state,omitempty"` // absolute, or relative to the working directory
+}
+
+// IsEthereum reports whether the chain is an anvil ethereum chain.
+func (chain *Chain) IsEthereum() bool {
+	return chain.ChainType == "ethereum"
 }
 
 func (chain *Chain) Validate() error {
@@ -40,11 +61,16 @@ func (chain *Chain) SetChainDefaults() {
 		chain.ChainType = "cosmos"
 	}
 
+	if chain.IsEthereum() {
+		chain.setEthereumDefaults()
+	}
+
 	if chain.CoinType == 0 {
 		chain.CoinType = 118
 	}
 
-	if chain.DockerImage.UidGid == "" {
+	// Ethereum chains keep the volume owner of the default anvil chain config.
+	if chain.DockerImage.UidGid == "" && !chain.IsEthereum() {
 		chain.DockerImage.UidGid = "1025:1025"
 	}
 
@@ -86,3 +112,32 @@ func (chain *Chain) SetChainDefaults() {
 		panic("'bech32_prefix' is required in your config for " + chain.ChainID)
 	}
 }
+
+// setEthereumDefaults fills in the fields anvil does not use, so only the name and chain_id are required.
+func (chain *Chain) setEthereumDefaults() {
+	if _, err := strconv.ParseUint(chain.ChainID, 10, 64); err != nil {
+		panic("'chain_id' must be numeric for ethereum chain " + chain.ChainID)
+	}
+
+	if chain.CoinType == 0 {
+		chain.CoinType = 60
+	}
+	if chain.Binary == "" {
+		chain.Binary = "anvil"
+	}
+	if chain.Denom == "" {
+		chain.Denom = "wei"
+	}
+	if chain.Bech32Prefix == "" {
+		chain.Bech32Prefix = "n/a"
+	}
+	if chain.GasPrices == "" {
+		chain.GasPrices = "0"
+	}
+	if chain.DockerImage.Repository == "" {
+		chain.DockerImage.Repository = "ghcr.io/foundry-rs/foundry"
+	}
+	if chain.DockerImage.Version == "" {
+		chain.DockerImage.Version = "latest"
+	}
+}
@@ -13,4 +13,8 @@ type LogOutput struct {
 	RESTAddress string   `json:"rest_address"`
 	GRPCAddress string   `json:"grpc_address"`
 	IBCPath     []string `json:"ibc_paths"`
+
+	// EVM endpoints, of ethereum chains and of cosmos chains with an EVM.
+	JSONRPCAddress string `json:"json_rpc_address,omitempty"`
+	WSAddress      string `json:"ws_address,omitempty"`
 }
@@ -0,0 +1,151 @@
+from typing import List, Optional
+
+import httpx
+
+# (method, path) of every operation used by ApiV1, checked against the served OpenAPI document by validate().
+OPERATIONS = [
+    ("post", "/v1/chains"),
+    ("post", "/v1/ibc-paths"),
+    ("post", "/v1/sessions"),
+    ("post", "/v1/chains/{chain_id}/query"),
+    ("post", "/v1/chains/{chain_id}/tx"),
+    ("post", "/v1/chains/{chain_id}/keys/recover"),
+    ("post", "/v1/chains/{chain_id}/full-nodes"),
+    ("post", "/v1/chains/{chain_id}/contracts/store"),
+    ("post", "/v1/chains/{chain_id}/contracts/instantiate"),
+    ("get", "/v1/chains/{chain_id}/channels"),
+    ("post", "/v1/relayer/start"),
+    ("post", "/v1/relayer/stop"),
+    ("post", "/v1/relayer/exec"),
+]
+
+
+class ApiError(Exception):
+    def __init__(self, status_code: int, error: str):
+        super().__init__(f"{status_code}: {error}")
+        self.status_code = status_code
+        self.error = error
+
+
+class ApiV1:
+    """
+    Client of the typed v1 API. auth_key is sent as a bearer token if local-ic
+    was started with --auth-key.
+    """
+
+    def __init__(self, api: str, chain_id: str, auth_key: str = "", timeout: int = 120):
+        if api == "":
+            raise Exception("ApiV1 URL is empty")
+
+        self.api = api.rstrip("/")
+        self.chain_id = chain_id
+        self.headers = {"Content-Type": "application/json"}
+        if auth_key:
+            self.headers["Authorization"] = f"Bearer {auth_key}"
+        self.timeout = timeout
+
+    def _request(self, method: str, path: str, payload: Optional[dict] = None):
+        res = httpx.request(
+            method,
+            self.api + path.replace("{chain_id}", self.chain_id),
+            json=payload,
+            headers=self.headers,
+            timeout=self.timeout,
+        )
+        body = res.json()
+        if res.status_code != 200:
+            raise ApiError(res.status_code, body.get("error", res.text))
+        return body
+
+    def validate(self):
+        """
+        Raises if the server does not document an operation used by this client.
+        """
+        spec = httpx.get(f"{self.api}/openapi.json").json()
+        missing = [
+            f"{method.upper()} {path}"
+            for method, path in OPERATIONS
+            if method not in spec["paths"].get(path, {})
+        ]
+        if missing:
+            raise Exception(f"operations missing from the OpenAPI document: {missing}")
+
+    def query(self, args: List[str]) -> dict:
+        return self._request("POST", "/v1/chains/{chain_id}/query", {"args": args})
+
+    def tx(self, key_name: str, args: List[str]) -> str:
+        res = self._request(
+            "POST", "/v1/chains/{chain_id}/tx", {"key_name": key_name, "args": args}
+        )
+        return res["tx_hash"]
+
+    def recover_key(self, key_name: str, mnemonic: str) -> str:
+        res = self._request(
+            "POST",
+            "/v1/chains/{chain_id}/keys/recover",
+            {"key_name": key_name, "mnemonic": mnemonic},
+        )
+        return res["address"]
+
+    def add_full_nodes(self, amount: int) -> dict:
+        return self._request(
+            "POST", "/v1/chains/{chain_id}/full-nodes", {"amount": amount}
+        )
+
+    def store_contract(self, key_name: str, file_path: str) -> str:
+        res = self._request(
+            "POST",
+            "/v1/chains/{chain_id}/contracts/store",
+            {"key_name": key_name, "file_path": file_path},
+        )
+        return res["code_id"]
+
+    def instantiate_contract(
+        self, key_name: str, code_id: str, msg: dict, admin: str = "", amount: str = ""
+    ) -> str:
+        payload = {"key_name": key_name, "code_id": code_id, "msg": msg}
+        if admin:
+            payload["admin"] = admin
+        if amount:
+            payload["amount"] = amount
+
+        res = self._request(
+            "POST", "/v1/chains/{chain_id}/contracts/instantiate", payload
+        )
+        return res["contract_address"]
+
+    def channels(self) -> List[dict]:
+        return self._request("GET", "/v1/chains/{chain_id}/channels")
+
+    def start_relayer(self, paths: List[str]) -> dict:
+        return self._request("POST", "/v1/relayer/start", {"paths": paths})
+
+    def stop_relayer(self) -> dict:
+        return self._request("POST", "/v1/relayer/stop")
+
+    def relayer_exec(self, args: List[str]) -> dict:
+        return self._request("POST", "/v1/relayer/exec", {"args": args})
+
+    def add_chains(self, config: str) -> dict:
+        """
+        Starts the chains of a config file in local-ic's chains directory.
+        Returns the updated /info.
+        """
+        return self._request("POST", "/v1/chains", {"config": config})
+
+    def add_ibc_path(self, path: str, chain_a: str, chain_b: str) -> dict:
+        """
+        Creates an IBC path between two running chains. Returns the updated /info.
+        """
+        return self._request(
+            "POST",
+            "/v1/ibc-paths",
+            {"path": path, "chain_a": chain_a, "chain_b": chain_b},
+        )
+
+    def save_session(self, name: str) -> dict:
+        """
+        Stops the chains and relayer and saves them as sessions/<name>.tar.gz.
+        local-ic exits once it responds; restore it with `local-ic restore <name>`.
+        """
+        return self._request("POST", "/v1/sessions", {"name": name})
@@ -0,0 +1,77 @@
+import json
+from typing import Iterator, List, Optional
+
+import httpx
+
+
+def subscribe(
+    api: str,
+    chain_ids: Optional[List[str]] = None,
+    types: Optional[List[str]] = None,
+    tx_type: str = "",
+    tx_attr: str = "",
+    auth_key: str = "",
+) -> Iterator[dict]:
+    """
+    Streams events from the /events endpoint. Each event is a dict with a type
+    (block, tx, relayer or log), chain_id, height, time and data.
+
+    log events must be requested explicitly in types.
+    tx_type / tx_attr ("key" or "key=value") filter txs by their events.
+    auth_key is sent as a bearer token if local-ic was started with --auth-key.
+
+    for event in subscribe(API_URL, chain_ids=["localjuno-1"], types=["block"]):
+        print(event["height"])
+    """
+    if api == "":
+        raise Exception("subscribe URL is empty")
+
+    params = {}
+    if chain_ids:
+        params["chain_id"] = ",".join(chain_ids)
+    if types:
+        params["type"] = ",".join(types)
+    if tx_type:
+        params["tx_type"] = tx_type
+    if tx_attr:
+        params["tx_attr"] = tx_attr
+
+    headers = {}
+    if auth_key:
+        headers["Authorization"] = f"Bearer {auth_key}"
+
+    with httpx.stream(
+        "GET",
+        f"{api.rstrip('/')}/events",
+        params=params,
+        headers=headers,
+        timeout=None,
+    ) as res:
+        res.raise_for_status()
+        for line in res.iter_lines():
+            # Server-Sent Events: only data lines hold events, others are names and heartbeats.
+            if line.startswith("data: "):
+                yield json.loads(line[len("data: ") :])
+
+
+def wait_for_tx(
+    api: str,
+    chain_id: str,
+    tx_type: str = "",
+    tx_attr: str = "",
+    auth_key: str = "",
+) -> dict:
+    """
+    Blocks until a tx matching the filters is included on the chain and returns its event.
+    """
+    for event in subscribe(
+        api,
+        chain_ids=[chain_id],
+        types=["tx"],
+        tx_type=tx_type,
+        tx_attr=tx_attr,
+        auth_key=auth_key,
+    ):
+        return event
+
+    raise Exception("event stream closed")
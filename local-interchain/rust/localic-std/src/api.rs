@@ -0,0 +1,283 @@
+use reqwest::blocking::Client;
+use reqwest::Method;
+use serde_json::{json, Value};
+
+use crate::errors::LocalError;
+
+/// (method, path) of every operation used by `ApiV1`, checked against the served OpenAPI document by `validate`.
+pub const OPERATIONS: &[(&str, &str)] = &[
+    ("post", "/v1/chains"),
+    ("post", "/v1/ibc-paths"),
+    ("post", "/v1/sessions"),
+    ("post", "/v1/chains/{chain_id}/query"),
+    ("post", "/v1/chains/{chain_id}/tx"),
+    ("post", "/v1/chains/{chain_id}/keys/recover"),
+    ("post", "/v1/chains/{chain_id}/full-nodes"),
+    ("post", "/v1/chains/{chain_id}/contracts/store"),
+    ("post", "/v1/chains/{chain_id}/contracts/instantiate"),
+    ("get", "/v1/chains/{chain_id}/channels"),
+    ("post", "/v1/relayer/start"),
+    ("post", "/v1/relayer/stop"),
+    ("post", "/v1/relayer/exec"),
+];
+
+/// Client of the typed v1 API. `auth_key` is sent as a bearer token if local-ic was started with --auth-key.
+#[derive(Clone, Debug)]
+pub struct ApiV1 {
+    client: Client,
+    api: String,
+    chain_id: String,
+    auth_key: Option<String>,
+}
+
+impl ApiV1 {
+    /// # Errors
+    ///
+    /// Returns `Err` if the `api` or `chain_id` is empty.
+    pub fn new(api: &str, chain_id: &str, auth_key: Option<String>) -> Result<ApiV1, LocalError> {
+        if api.is_empty() {
+            return Err(LocalError::ApiNotFound {});
+        }
+        if chain_id.is_empty() {
+            return Err(LocalError::ChainIdNotFound {});
+        }
+
+        Ok(ApiV1 {
+            client: Client::new(),
+            api: api.trim_end_matches('/').to_string(),
+            chain_id: chain_id.to_string(),
+            auth_key,
+        })
+    }
+
+    fn request(
+        &self,
+        method: Method,
+        path: &str,
+        payload: Option<Value>,
+    ) -> Result<Value, LocalError> {
+        let url = format!("{}{}", self.api, path.replace("{chain_id}", &self.chain_id));
+        let mut req = self
+            .client
+            .request(method, url)
+            .timeout(std::time::Duration::from_secs(120));
+        if let Some(key) = &self.auth_key {
+            req = req.bearer_auth(key);
+        }
+        if let Some(payload) = payload {
+            req = req.json(&payload);
+        }
+
+        let res = req.send().map_err(|e| LocalError::ApiError {
+            status: 0,
+            error: e.to_string(),
+        })?;
+        let status = res.status();
+        let body: Value = res.json().map_err(|e| LocalError::ApiError {
+            status: status.as_u16(),
+            error: e.to_string(),
+        })?;
+
+        if !status.is_success() {
+            return Err(LocalError::ApiError {
+                status: status.as_u16(),
+                error: body["error"].as_str().unwrap_or_default().to_string(),
+            });
+        }
+        Ok(body)
+    }
+
+    fn field(body: &Value, key: &str) -> Result<String, LocalError> {
+        body[key]
+            .as_str()
+            .map(ToString::to_string)
+            .ok_or(LocalError::ApiError {
+                status: 200,
+                error: format!("{key} not found in response: {body}"),
+            })
+    }
+
+    /// Checks that the server documents every operation used by this client.
+    /// # Errors
+    ///
+    /// Returns `Err` if the document could not be fetched or an operation is missing.
+    pub fn validate(&self) -> Result<(), LocalError> {
+        let spec = self.request(Method::GET, "/openapi.json", None)?;
+
+        let missing: Vec<String> = OPERATIONS
+            .iter()
+            .filter(|(method, path)| spec["paths"][path][method].is_null())
+            .map(|(method, path)| format!("{} {path}", method.to_uppercase()))
+            .collect();
+        if !missing.is_empty() {
+            return Err(LocalError::ApiError {
+                status: 0,
+                error: format!("operations missing from the OpenAPI document: {missing:?}"),
+            });
+        }
+        Ok(())
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the query fails.
+    pub fn query(&self, args: &[&str]) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/query",
+            Some(json!({ "args": args })),
+        )
+    }
+
+    /// Signs and broadcasts the tx command, returning its hash once included.
+    /// # Errors
+    ///
+    /// Returns `Err` if the transaction fails.
+    pub fn tx(&self, key_name: &str, args: &[&str]) -> Result<String, LocalError> {
+        let res = self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/tx",
+            Some(json!({ "key_name": key_name, "args": args })),
+        )?;
+        Self::field(&res, "tx_hash")
+    }
+
+    /// Recovers the key and returns its address.
+    /// # Errors
+    ///
+    /// Returns `Err` if the key could not be recovered.
+    pub fn recover_key(&self, key_name: &str, mnemonic: &str) -> Result<String, LocalError> {
+        let res = self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/keys/recover",
+            Some(json!({ "key_name": key_name, "mnemonic": mnemonic })),
+        )?;
+        Self::field(&res, "address")
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the full nodes could not be added.
+    pub fn add_full_nodes(&self, amount: u64) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/full-nodes",
+            Some(json!({ "amount": amount })),
+        )
+    }
+
+    /// Stores the contract at `file_path`, on the machine running local-ic, and returns its code id.
+    /// # Errors
+    ///
+    /// Returns `Err` if the contract could not be stored.
+    pub fn store_contract(&self, key_name: &str, file_path: &str) -> Result<String, LocalError> {
+        let res = self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/contracts/store",
+            Some(json!({ "key_name": key_name, "file_path": file_path })),
+        )?;
+        Self::field(&res, "code_id")
+    }
+
+    /// Instantiates the contract and returns its address. It has no admin if `admin` is empty.
+    /// # Errors
+    ///
+    /// Returns `Err` if the contract could not be instantiated.
+    pub fn instantiate_contract(
+        &self,
+        key_name: &str,
+        code_id: &str,
+        msg: &Value,
+        admin: &str,
+        amount: &str,
+    ) -> Result<String, LocalError> {
+        let mut payload = json!({ "key_name": key_name, "code_id": code_id, "msg": msg });
+        if !admin.is_empty() {
+            payload["admin"] = json!(admin);
+        }
+        if !amount.is_empty() {
+            payload["amount"] = json!(amount);
+        }
+
+        let res = self.request(
+            Method::POST,
+            "/v1/chains/{chain_id}/contracts/instantiate",
+            Some(payload),
+        )?;
+        Self::field(&res, "contract_address")
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the relayer is not configured or fails.
+    pub fn channels(&self) -> Result<Value, LocalError> {
+        self.request(Method::GET, "/v1/chains/{chain_id}/channels", None)
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the relayer is not configured or fails.
+    pub fn start_relayer(&self, paths: &[&str]) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/relayer/start",
+            Some(json!({ "paths": paths })),
+        )
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the relayer is not configured or fails.
+    pub fn stop_relayer(&self) -> Result<Value, LocalError> {
+        self.request(Method::POST, "/v1/relayer/stop", None)
+    }
+
+    /// # Errors
+    ///
+    /// Returns `Err` if the relayer is not configured or fails.
+    pub fn relayer_exec(&self, args: &[&str]) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/relayer/exec",
+            Some(json!({ "args": args })),
+        )
+    }
+
+    /// Starts the chains of a config file in local-ic's chains directory, returning the updated `/info`.
+    /// # Errors
+    ///
+    /// Returns `Err` if the config is not found or a chain fails to start.
+    pub fn add_chains(&self, config: &str) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/chains",
+            Some(json!({ "config": config })),
+        )
+    }
+
+    /// Creates an IBC path between two running chains, returning the updated `/info`.
+    /// # Errors
+    ///
+    /// Returns `Err` if a chain is not found or the relayer fails.
+    pub fn add_ibc_path(
+        &self,
+        path: &str,
+        chain_a: &str,
+        chain_b: &str,
+    ) -> Result<Value, LocalError> {
+        self.request(
+            Method::POST,
+            "/v1/ibc-paths",
+            Some(json!({ "path": path, "chain_a": chain_a, "chain_b": chain_b })),
+        )
+    }
+
+    /// Stops the chains and relayer and saves them as `sessions/<name>.tar.gz`, returning its name and path.
+    /// local-ic exits once it responds; restore it with `local-ic restore <name>`.
+    /// # Errors
+    ///
+    /// Returns `Err` if the name is invalid or the archive fails.
+    pub fn save_session(&self, name: &str) -> Result<Value, LocalError> {
+        self.request(Method::POST, "/v1/sessions", Some(json!({ "name": name })))
+    }
+}
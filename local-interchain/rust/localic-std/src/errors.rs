@@ -40,4 +40,10 @@ pub enum LocalError {
 
     #[error("Could not get filesystem files. {error}")]
     GetFilesError { error: String },
+
+    #[error("event subscription failed. reason: {reason}")]
+    SubscribeFailed { reason: String },
+
+    #[error("API request failed. status: {status}. error: {error}")]
+    ApiError { status: u16, error: String },
 }
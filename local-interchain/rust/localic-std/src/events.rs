@@ -0,0 +1,118 @@
+use std::io::{BufRead, BufReader, Lines};
+
+use reqwest::blocking::{Client, Response};
+use serde_json::Value;
+
+use crate::errors::LocalError;
+
+/// Filters of an event subscription. Empty fields match everything.
+#[derive(Clone, Debug, Default)]
+pub struct EventFilter {
+    pub chain_ids: Vec<String>,
+    /// block, tx, relayer or log. Defaults to all but log, which must be requested explicitly.
+    pub types: Vec<String>,
+    /// Keeps the txs emitting an event of this type, e.g. "transfer".
+    pub tx_type: String,
+    /// Keeps the txs emitting an event attribute "key" or "key=value".
+    pub tx_attr: String,
+}
+
+/// A stream of events from the /events endpoint. Each event is a JSON object
+/// with a type, chain_id, height, time and data.
+pub struct Subscription {
+    lines: Lines<BufReader<Response>>,
+}
+
+impl Iterator for Subscription {
+    type Item = Value;
+
+    fn next(&mut self) -> Option<Self::Item> {
+        for line in self.lines.by_ref() {
+            let Ok(line) = line else {
+                return None;
+            };
+            // Server-Sent Events: only data lines hold events, others are names and heartbeats.
+            if let Some(data) = line.strip_prefix("data: ") {
+                if let Ok(event) = serde_json::from_str(data) {
+                    return Some(event);
+                }
+            }
+        }
+        None
+    }
+}
+
+/// Subscribes to the events of the running chains and relayer.
+/// `auth_key` is sent as a bearer token if local-ic was started with --auth-key.
+/// # Errors
+///
+/// Returns `Err` if the stream could not be opened.
+pub fn subscribe(
+    c: &Client,
+    api_url: &str,
+    filter: &EventFilter,
+    auth_key: Option<&str>,
+) -> Result<Subscription, LocalError> {
+    let mut params: Vec<(&str, String)> = vec![];
+    if !filter.chain_ids.is_empty() {
+        params.push(("chain_id", filter.chain_ids.join(",")));
+    }
+    if !filter.types.is_empty() {
+        params.push(("type", filter.types.join(",")));
+    }
+    if !filter.tx_type.is_empty() {
+        params.push(("tx_type", filter.tx_type.clone()));
+    }
+    if !filter.tx_attr.is_empty() {
+        params.push(("tx_attr", filter.tx_attr.clone()));
+    }
+
+    // The server sends heartbeats, so reads of an idle stream do not hit the client timeout.
+    let mut req = c
+        .get(format!("{}/events", api_url.trim_end_matches('/')))
+        .query(&params);
+    if let Some(key) = auth_key {
+        req = req.bearer_auth(key);
+    }
+    let res = req
+        .send()
+        .map_err(|e| LocalError::SubscribeFailed {
+            reason: e.to_string(),
+        })?;
+
+    if !res.status().is_success() {
+        return Err(LocalError::SubscribeFailed {
+            reason: res.status().to_string(),
+        });
+    }
+
+    Ok(Subscription {
+        lines: BufReader::new(res).lines(),
+    })
+}
+
+/// Blocks until a tx matching the filter is included on the chain and returns its event.
+/// # Errors
+///
+/// Returns `Err` if the stream could not be opened or closed before a tx matched.
+pub fn wait_for_tx(
+    c: &Client,
+    api_url: &str,
+    chain_id: &str,
+    tx_type: &str,
+    tx_attr: &str,
+    auth_key: Option<&str>,
+) -> Result<Value, LocalError> {
+    let filter = EventFilter {
+        chain_ids: vec![chain_id.to_string()],
+        types: vec!["tx".to_string()],
+        tx_type: tx_type.to_string(),
+        tx_attr: tx_attr.to_string(),
+    };
+
+    subscribe(c, api_url, &filter, auth_key)?
+        .next()
+        .ok_or(LocalError::SubscribeFailed {
+            reason: "event stream closed".to_string(),
+        })
+}
@@ -1,4 +1,6 @@
+pub mod api;
 pub mod errors;
+pub mod events;
 pub mod filesystem;
 pub mod polling;
 pub mod transactions;
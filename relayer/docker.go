@@ -50,6 +50,8 @@ type DockerRelayer struct {
 	homeDir string
 
 	extraStartupFlags []string
+
+	resourceLimits *ibc.ResourceLimits
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
@@ -148,6 +150,20 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 	return bytes, nil
 }
 
+// ArchiveHome writes the whole home directory of the relayer, including its config and keys, to w as a tar stream.
+// The relayer should be stopped, so that the archive is consistent.
+func (r *DockerRelayer) ArchiveHome(ctx context.Context, w io.Writer) error {
+	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
+	return fr.ArchiveVolume(ctx, r.volumeName, w)
+}
+
+// RestoreHome replaces the home directory of the relayer with the tar stream written by ArchiveHome.
+// The relayer should be stopped.
+func (r *DockerRelayer) RestoreHome(ctx context.Context, rd io.Reader) error {
+	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
+	return fw.RestoreVolume(ctx, r.volumeName, rd)
+}
+
 // Modify a toml config file in relayer home directory
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
 	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
@@ -300,10 +316,14 @@ func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporte
 }
 
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
-	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.ContainerImage().Repository, r.ContainerImage().Version)
+	repo, tag := r.ContainerImage().RepositoryAndTag()
+	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, repo, tag)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: r.Bind(),
+		Env:       env,
+		Binds:     r.Bind(),
+		Resources: dockerutil.ContainerResources(r.resourceLimits),
+		// Reported through rep below.
+		SkipExecReport: true,
 	}
 
 	startedAt := time.Now()
@@ -367,6 +387,7 @@ func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecRep
 	cmd := r.c.StartRelayer(r.HomeDir(), pathNames...)
 
 	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
+	r.containerLifecycle.SetResourceLimits(r.resourceLimits)
 
 	if err := r.containerLifecycle.CreateContainer(
 		ctx, r.testName, r.networkID, containerImage, nil,
@@ -476,19 +497,13 @@ func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
 	}
 }
 
+// pullContainerImageIfNecessary pulls the image, or builds it if it is built from source.
+// Images built from source are always built, because they cannot be pulled.
 func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerImage) error {
-	if !r.pullImage {
+	if containerImage.Build == nil && !r.pullImage {
 		return nil
 	}
-
-	rc, err := r.client.ImagePull(context.TODO(), containerImage.Ref(), types.ImagePullOptions{})
-	if err != nil {
-		return err
-	}
-
-	_, _ = io.Copy(io.Discard, rc)
-	_ = rc.Close()
-	return nil
+	return dockerutil.EnsureImage(context.TODO(), r.log, r.client, containerImage)
 }
 
 func (r *DockerRelayer) Name() string {
@@ -25,6 +25,17 @@ func CustomDockerImage(repository string, version string, uidGid string) Relayer
 	})
 }
 
+// DockerImageFromSource overrides the default relayer docker image with one built from a local source tree.
+// The image is built once per run and reused while the build context is unchanged.
+// uidGid is the uid:gid format owner that should be used within the container.
+// If uidGid is empty, root user will be assumed.
+func DockerImageFromSource(build ibc.DockerBuild, uidGid string) RelayerOpt {
+	return DockerImage(&ibc.DockerImage{
+		UidGid: uidGid,
+		Build:  &build,
+	})
+}
+
 // HomeDir overrides the default relayer home directory.
 func HomeDir(homeDir string) RelayerOpt {
 	return func(r *DockerRelayer) {
@@ -39,6 +50,13 @@ func ImagePull(pull bool) RelayerOpt {
 	}
 }
 
+// ResourceLimits sets the CPU, memory, and process limits of the relayer containers.
+func ResourceLimits(limits *ibc.ResourceLimits) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.resourceLimits = limits
+	}
+}
+
 // StartupFlags overrides the default relayer startup flags.
 func StartupFlags(flags ...string) RelayerOpt {
 	return func(r *DockerRelayer) {
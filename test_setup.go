@@ -12,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/zap"
 )
 
 const (
@@ -30,6 +31,35 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// UseContainerRuntime selects the container runtime used by DockerSetup
+// and by the helper containers that manage volumes: either "docker" or "podman".
+//
+// The runtime is detected by default, but can be set with the
+// environment variable ICTEST_CONTAINER_RUNTIME.
+// Call UseContainerRuntime before DockerSetup.
+func UseContainerRuntime(name string) error {
+	rt, err := dockerutil.RuntimeByName(name)
+	if err != nil {
+		return err
+	}
+	dockerutil.SetContainerRuntime(rt)
+	return nil
+}
+
+// NewContainerRuntimeClient returns a client connected to the container runtime used by DockerSetup,
+// for tests that manage containers of their own.
+func NewContainerRuntimeClient() (*client.Client, error) {
+	return dockerutil.NewRuntimeClient()
+}
+
+// BuildImage builds image from its Build configuration and tags it as image.Ref().
+// The image is rebuilt only when the files in its build context, or its build options, change.
+//
+// Chains and relayers build their images automatically; BuildImage is for other containers used by a test.
+func BuildImage(ctx context.Context, log *zap.Logger, cli *client.Client, image ibc.DockerImage) error {
+	return dockerutil.BuildImage(ctx, log, cli, image)
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
@@ -38,6 +68,33 @@ func DockerSetup(t dockerutil.DockerSetupTestingT) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// SampleContainerStats samples the CPU, memory, and process usage of every container
+// associated with t once per interval, recording each sample in rep,
+// until t completes.
+//
+// Call SampleContainerStats after DockerSetup so that sampling stops before the containers are cleaned up.
+func SampleContainerStats(ctx context.Context, t dockerutil.DockerSetupTestingT, cli *client.Client, rep *testreporter.Reporter, interval time.Duration) {
+	t.Helper()
+
+	s := dockerutil.NewStatsSampler(zap.NewNop(), cli, t.Name(), interval, rep.ContainerStatsReporter(t))
+	s.Start(ctx)
+	t.Cleanup(s.Stop)
+}
+
+// TrackExecs records every command that chains and other images associated with t
+// run to completion in a container, such as CLI transactions and queries, in rep until t completes.
+// Relayer commands are not included, as they are already reported through rep.RelayerExecReporter.
+//
+// Interchain.Build already reports the commands of the chains it builds,
+// so TrackExecs is only needed for chains that are initialized and started without it.
+// Commands are associated with the name the chains were built with, typically t.Name(),
+// so call TrackExecs with the same t that builds the chains.
+func TrackExecs(t *testing.T, rep *testreporter.Reporter) {
+	t.Helper()
+
+	t.Cleanup(dockerutil.RegisterExecReporter(t.Name(), rep.ExecReporter(t)))
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
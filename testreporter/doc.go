@@ -73,4 +73,19 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// NewReporter writes the report as a stream of JSON lines.
+// To write other formats, such as JUnit XML for CI dashboards or a self-contained HTML timeline,
+// create the reporter with NewSinkReporter and any of the JSONSink, JUnitSink and HTMLSink:
+//
+//	reporter := testreporter.NewSinkReporter(
+//	  testreporter.NewJSONSink(jsonFile),
+//	  testreporter.NewJUnitSink(junitFile),
+//	)
+//
+// Relayer commands are reported through RelayerExecReporter.
+// Commands that chains and helper containers execute are reported through ExecReporter;
+// Interchain.Build registers one for the chains it builds, using its RelayerExecReporter's test.
+//
+// An existing JSON report can be converted to the other formats with Convert.
 package testreporter
@@ -0,0 +1,188 @@
+package testreporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// HTMLSink writes a self-contained HTML report when closed,
+// with a timeline of the tests and the commands they executed in containers.
+type HTMLSink struct {
+	w io.WriteCloser
+	s suite
+}
+
+// NewHTMLSink returns an HTMLSink writing to w, closing w when the sink is closed.
+func NewHTMLSink(w io.WriteCloser) *HTMLSink {
+	return &HTMLSink{w: w}
+}
+
+func (s *HTMLSink) Write(m Message) error {
+	s.s.add(m)
+	return nil
+}
+
+func (s *HTMLSink) Close() error {
+	err := writeHTML(s.w, &s.s)
+	if closeErr := s.w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+type htmlReport struct {
+	StartedAt string
+	Duration  string
+	Counts    map[string]int
+	Tests     []htmlTest
+}
+
+type htmlTest struct {
+	ID          int
+	Name        string
+	Status      string
+	Duration    string
+	Span        htmlSpan
+	SkipMessage string
+	Errors      []string
+	Execs       []htmlExec
+}
+
+type htmlExec struct {
+	Command string
+	Failed  bool
+	Span    htmlSpan
+	Output  string
+}
+
+// htmlSpan positions a bar in the timeline, in percent of the suite duration.
+type htmlSpan struct {
+	Left, Width float64
+}
+
+func writeHTML(w io.Writer, s *suite) error {
+	start, total := s.StartedAt, s.Duration()
+	if total <= 0 {
+		// The suite did not finish; fit the timeline to the tests.
+		for _, t := range s.Tests {
+			if d := t.FinishedAt.Sub(start); d > total {
+				total = d
+			}
+		}
+	}
+	span := func(from, to time.Time) htmlSpan {
+		if total <= 0 {
+			return htmlSpan{Width: 100}
+		}
+		left := float64(from.Sub(start)) / float64(total) * 100
+		width := float64(to.Sub(from)) / float64(total) * 100
+		// Keep instant events visible.
+		if width < 0.2 {
+			width = 0.2
+		}
+		return htmlSpan{Left: left, Width: width}
+	}
+
+	report := htmlReport{
+		StartedAt: s.StartedAt.UTC().Format(time.RFC3339),
+		Duration:  total.Round(time.Millisecond).String(),
+		Counts:    make(map[string]int),
+	}
+	for i, t := range s.Tests {
+		status := t.Status()
+		report.Counts[status]++
+
+		ht := htmlTest{
+			ID:          i,
+			Name:        t.Name,
+			Status:      status,
+			Duration:    t.Duration().Round(time.Millisecond).String(),
+			Span:        span(t.StartedAt, t.FinishedAt),
+			SkipMessage: t.SkipMessage,
+		}
+		for _, e := range t.Errors {
+			ht.Errors = append(ht.Errors, e.Message)
+		}
+		for _, e := range t.Execs {
+			ht.Execs = append(ht.Execs, htmlExec{
+				Command: strings.Join(e.Command, " "),
+				Failed:  e.ExitCode != 0 || e.Error != "",
+				Span:    span(e.StartedAt, e.FinishedAt),
+				Output:  formatExec(e),
+			})
+		}
+		report.Tests = append(report.Tests, ht)
+	}
+
+	if err := htmlTemplate.Execute(w, report); err != nil {
+		return fmt.Errorf("execute html template: %w", err)
+	}
+	return nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct": func(f float64) string { return fmt.Sprintf("%.3f%%", f) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>interchaintest report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+td, th { padding: 2px 6px; text-align: left; vertical-align: middle; }
+.name { width: 30%; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; max-width: 0; }
+.lane { position: relative; height: 18px; background: #f4f4f4; }
+.bar { position: absolute; top: 0; height: 18px; opacity: 0.6; }
+.exec { position: absolute; top: 4px; height: 10px; background: #333; }
+.exec.failed, .bar.failed, .bar.unfinished { background: #d33; }
+.bar.passed { background: #3a3; }
+.bar.skipped { background: #aaa; }
+.status.failed, .status.unfinished, .failed > summary { color: #d33; }
+pre { background: #f4f4f4; padding: 0.5em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>interchaintest report</h1>
+<p>Started {{.StartedAt}}, took {{.Duration}}:
+{{index .Counts "passed"}} passed, {{index .Counts "failed"}} failed, {{index .Counts "skipped"}} skipped, {{index .Counts "unfinished"}} unfinished.</p>
+
+<h2>Timeline</h2>
+<table>
+{{- range .Tests}}
+<tr>
+<td class="name"><a href="#test-{{.ID}}" title="{{.Name}}">{{.Name}}</a></td>
+<td class="lane">
+<div class="bar {{.Status}}" style="left: {{pct .Span.Left}}; width: {{pct .Span.Width}}" title="{{.Name}}: {{.Status}} in {{.Duration}}"></div>
+{{- range .Execs}}
+<div class="exec{{if .Failed}} failed{{end}}" style="left: {{pct .Span.Left}}; width: {{pct .Span.Width}}" title="{{.Command}}"></div>
+{{- end}}
+</td>
+</tr>
+{{- end}}
+</table>
+
+<h2>Tests</h2>
+{{- range .Tests}}
+<section id="test-{{.ID}}">
+<h3>{{.Name}} <span class="status {{.Status}}">{{.Status}}</span> in {{.Duration}}</h3>
+{{- if .SkipMessage}}
+<p>Skipped: {{.SkipMessage}}</p>
+{{- end}}
+{{- range .Errors}}
+<pre class="failed">{{.}}</pre>
+{{- end}}
+{{- range .Execs}}
+<details{{if .Failed}} class="failed"{{end}}>
+<summary><code>{{.Command}}</code></summary>
+<pre>{{.Output}}</pre>
+</details>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
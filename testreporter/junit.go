@@ -0,0 +1,171 @@
+package testreporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JUnitSink writes a JUnit XML report when closed, with a test suite per top-level test.
+// The output of the relayer commands executed by a test is its system-out.
+type JUnitSink struct {
+	w io.WriteCloser
+	s suite
+}
+
+// NewJUnitSink returns a JUnitSink writing to w, closing w when the sink is closed.
+func NewJUnitSink(w io.WriteCloser) *JUnitSink {
+	return &JUnitSink{w: w}
+}
+
+func (s *JUnitSink) Write(m Message) error {
+	s.s.add(m)
+	return nil
+}
+
+func (s *JUnitSink) Close() error {
+	err := writeJUnit(s.w, &s.s)
+	if closeErr := s.w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, s *suite) error {
+	report := junitTestSuites{
+		Name: "interchaintest",
+		Time: junitSeconds(s.Duration()),
+	}
+
+	suiteIdx := make(map[string]int)
+	for _, t := range s.Tests {
+		classname := t.Name
+		if i := strings.Index(classname, "/"); i >= 0 {
+			classname = classname[:i]
+		}
+		idx, ok := suiteIdx[classname]
+		if !ok {
+			idx = len(report.Suites)
+			suiteIdx[classname] = idx
+			report.Suites = append(report.Suites, junitTestSuite{
+				Name:      classname,
+				Timestamp: t.StartedAt.UTC().Format(time.RFC3339),
+			})
+		}
+		ts := &report.Suites[idx]
+
+		tc := junitTestCase{
+			Name:      t.Name,
+			Classname: classname,
+			Time:      junitSeconds(t.Duration()),
+		}
+		switch t.Status() {
+		case "unfinished":
+			tc.Error = &junitMessage{Message: "test did not finish before the suite"}
+			ts.Errors++
+		case "failed":
+			tc.Failure = &junitMessage{Message: "test failed"}
+			var msgs []string
+			for _, e := range t.Errors {
+				msgs = append(msgs, e.Message)
+			}
+			if len(msgs) > 0 {
+				tc.Failure.Message = firstLine(msgs[0])
+				tc.Failure.Text = strings.Join(msgs, "\n\n")
+			}
+			ts.Failures++
+		case "skipped":
+			tc.Skipped = &junitMessage{Message: t.SkipMessage}
+			ts.Skipped++
+		}
+		for _, e := range t.Execs {
+			tc.SystemOut += formatExec(e)
+		}
+
+		ts.Tests++
+		ts.TestCases = append(ts.TestCases, tc)
+	}
+
+	for i := range report.Suites {
+		ts := &report.Suites[i]
+		var total time.Duration
+		for _, tc := range ts.TestCases {
+			t := s.byName[tc.Name]
+			// Subtests run within their parent.
+			if !strings.Contains(t.Name, "/") {
+				total += t.Duration()
+			}
+		}
+		ts.Time = junitSeconds(total)
+
+		report.Tests += ts.Tests
+		report.Failures += ts.Failures
+		report.Errors += ts.Errors
+		report.Skipped += ts.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encode junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
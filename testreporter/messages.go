@@ -133,6 +133,77 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// ExecMessage is the result of executing a command in a container other than a relayer,
+// such as a chain CLI transaction or query, or a helper container's command.
+// This message is populated through the ExecReporter type,
+// which is returned by the Reporter's ExecReporter method.
+type ExecMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	StartedAt, FinishedAt time.Time
+
+	ContainerName string `json:",omitempty"`
+	Image         string `json:",omitempty"`
+
+	Command []string
+
+	Stdout, Stderr string
+
+	ExitCode int
+
+	Error string `json:",omitempty"`
+}
+
+func (m ExecMessage) typ() string {
+	return "Exec"
+}
+
+// ContainerStatsMessage is a single sample of the resource usage of a docker container
+// associated with a test.
+// This message is populated through the ContainerStatsReporter type,
+// which is returned by the Reporter's ContainerStatsReporter method.
+type ContainerStatsMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	ContainerName string
+
+	SampledAt time.Time
+
+	CPUPercent          float64
+	CPUThrottledPeriods uint64 `json:",omitempty"`
+
+	MemoryUsage, MemoryLimit uint64
+
+	PidsCurrent uint64
+	PidsLimit   uint64 `json:",omitempty"`
+
+	NetworkRx, NetworkTx uint64
+}
+
+func (m ContainerStatsMessage) typ() string {
+	return "ContainerStats"
+}
+
+// ContainerLogMessage is a single line that a docker container associated with a test
+// wrote to stdout or stderr.
+// This message is populated through the ContainerLogReporter type,
+// which is returned by the Reporter's ContainerLogReporter method.
+type ContainerLogMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	ContainerName string
+
+	Stream string // "stdout" or "stderr".
+
+	When time.Time
+
+	Line string
+}
+
+func (m ContainerLogMessage) typ() string {
+	return "ContainerLog"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -199,6 +270,18 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "Exec":
+		x := ExecMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "ContainerStats":
+		x := ContainerStatsMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "ContainerLog":
+		x := ContainerLogMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
@@ -39,6 +39,42 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.ExecMessage{
+				Name:          "foo",
+				StartedAt:     time.Now(),
+				FinishedAt:    time.Now().Add(time.Second),
+				ContainerName: "gaia-exec-123",
+				Image:         "ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.0",
+				Command:       []string{"gaiad", "query", "bank", "balances", "cosmos1abc"},
+				Stdout:        "balances: []",
+				ExitCode:      1,
+				Error:         "exit code 1",
+			},
+		},
+		{
+			Message: testreporter.ContainerStatsMessage{
+				Name:          "foo",
+				ContainerName: "gaia-1-val-0-foo",
+				SampledAt:     time.Now(),
+				CPUPercent:    42.5,
+				MemoryUsage:   128 << 20,
+				MemoryLimit:   512 << 20,
+				PidsCurrent:   12,
+				PidsLimit:     256,
+				NetworkRx:     1024,
+				NetworkTx:     2048,
+			},
+		},
+		{
+			Message: testreporter.ContainerLogMessage{
+				Name:          "foo",
+				ContainerName: "gaia-1-val-0-foo",
+				Stream:        "stderr",
+				When:          time.Now(),
+				Line:          "CONSENSUS FAILURE!!!",
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -0,0 +1,143 @@
+package testreporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// suite aggregates the messages of a report per test,
+// for the sinks that can only write the report once it is complete.
+type suite struct {
+	StartedAt, FinishedAt time.Time
+
+	Tests  []*testResult
+	byName map[string]*testResult
+}
+
+// testResult is the outcome of a single test.
+type testResult struct {
+	Name string
+
+	StartedAt, FinishedAt time.Time
+
+	// Finished is false if the suite ended before the test, e.g. because of a panic or a timeout.
+	Finished        bool
+	Failed, Skipped bool
+	SkipMessage     string
+
+	Errors []TestErrorMessage
+
+	// Execs are the relayer and other container commands of the test, ordered by start time.
+	Execs []ExecMessage
+}
+
+// addExec inserts m into t.Execs, keeping them ordered by start time.
+func (t *testResult) addExec(m ExecMessage) {
+	i := sort.Search(len(t.Execs), func(i int) bool {
+		return t.Execs[i].StartedAt.After(m.StartedAt)
+	})
+	t.Execs = append(t.Execs, ExecMessage{})
+	copy(t.Execs[i+1:], t.Execs[i:])
+	t.Execs[i] = m
+}
+
+// Status is one of passed, failed, skipped or unfinished.
+func (t *testResult) Status() string {
+	switch {
+	case !t.Finished:
+		return "unfinished"
+	case t.Failed:
+		return "failed"
+	case t.Skipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
+
+func (t *testResult) Duration() time.Duration {
+	return t.FinishedAt.Sub(t.StartedAt)
+}
+
+// add aggregates m. Messages of tests that were not tracked with TrackTest start the test.
+func (s *suite) add(m Message) {
+	switch m := m.(type) {
+	case BeginSuiteMessage:
+		s.StartedAt = m.StartedAt
+	case FinishSuiteMessage:
+		s.FinishedAt = m.FinishedAt
+		for _, t := range s.Tests {
+			if !t.Finished {
+				t.FinishedAt = m.FinishedAt
+			}
+		}
+	case BeginTestMessage:
+		s.test(m.Name, m.StartedAt)
+	case FinishTestMessage:
+		t := s.test(m.Name, m.FinishedAt)
+		t.FinishedAt = m.FinishedAt
+		t.Finished = true
+		t.Failed = m.Failed
+		t.Skipped = m.Skipped
+	case TestErrorMessage:
+		t := s.test(m.Name, m.When)
+		t.Errors = append(t.Errors, m)
+	case TestSkipMessage:
+		s.test(m.Name, m.When).SkipMessage = m.Message
+	case RelayerExecMessage:
+		s.test(m.Name, m.StartedAt).addExec(ExecMessage{
+			Name:          m.Name,
+			StartedAt:     m.StartedAt,
+			FinishedAt:    m.FinishedAt,
+			ContainerName: m.ContainerName,
+			Command:       m.Command,
+			Stdout:        m.Stdout,
+			Stderr:        m.Stderr,
+			ExitCode:      m.ExitCode,
+			Error:         m.Error,
+		})
+	case ExecMessage:
+		s.test(m.Name, m.StartedAt).addExec(m)
+	}
+}
+
+func (s *suite) test(name string, startedAt time.Time) *testResult {
+	if t, ok := s.byName[name]; ok {
+		return t
+	}
+	if s.byName == nil {
+		s.byName = make(map[string]*testResult)
+	}
+	t := &testResult{Name: name, StartedAt: startedAt, FinishedAt: startedAt}
+	s.byName[name] = t
+	s.Tests = append(s.Tests, t)
+	return t
+}
+
+func (s *suite) Duration() time.Duration {
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+// formatExec formats the command with its output, as a terminal would show it.
+func formatExec(m ExecMessage) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "$ %s\n", strings.Join(m.Command, " "))
+	sb.WriteString("# ")
+	if m.Image != "" {
+		fmt.Fprintf(&sb, "image=%s ", m.Image)
+	}
+	fmt.Fprintf(&sb, "container=%s exit_code=%d duration=%s\n", m.ContainerName, m.ExitCode, m.FinishedAt.Sub(m.StartedAt).Round(time.Millisecond))
+	if m.Stdout != "" {
+		sb.WriteString(strings.TrimRight(m.Stdout, "\n") + "\n")
+	}
+	if m.Stderr != "" {
+		sb.WriteString("# stderr:\n")
+		sb.WriteString(strings.TrimRight(m.Stderr, "\n") + "\n")
+	}
+	if m.Error != "" {
+		fmt.Fprintf(&sb, "# error: %s\n", m.Error)
+	}
+	return sb.String()
+}
@@ -1,7 +1,6 @@
 package testreporter
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"time"
@@ -22,16 +21,23 @@ type T interface {
 }
 
 type Reporter struct {
-	w io.WriteCloser
+	sinks []Sink
 
 	in chan Message
 
 	writerDone chan error
 }
 
+// NewReporter returns a Reporter writing a JSON-lines stream of messages to w.
 func NewReporter(w io.WriteCloser) *Reporter {
+	return NewSinkReporter(NewJSONSink(w))
+}
+
+// NewSinkReporter returns a Reporter writing every message to each of the sinks,
+// e.g. to produce a JUnit XML report alongside the JSON report.
+func NewSinkReporter(sinks ...Sink) *Reporter {
 	r := &Reporter{
-		w: w,
+		sinks: sinks,
 
 		in:         make(chan Message, 256), // Arbitrary size that seems unlikely to be filled.
 		writerDone: make(chan error, 1),
@@ -45,18 +51,17 @@ func NewReporter(w io.WriteCloser) *Reporter {
 
 // write runs in its own goroutine to continually output reporting messages.
 // Allowing all writes to happen in a single goroutine avoids any lock contention
-// that could happen with a mutex guarding concurrent writes to the io.Writer.
+// that could happen with a mutex guarding concurrent writes to the sinks.
 func (r *Reporter) write() {
-	enc := json.NewEncoder(r.w)
-	enc.SetEscapeHTML(false)
-
 	for m := range r.in {
-		if err := enc.Encode(JSONMessage(m)); err != nil {
-			panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
+		for _, s := range r.sinks {
+			if err := s.Write(m); err != nil {
+				panic(fmt.Errorf("reporter failed to write message; tests cannot continue: %w", err))
+			}
 		}
 	}
 
-	r.writerDone <- r.w.Close()
+	r.writerDone <- closeSinks(r.sinks)
 }
 
 // Close closes the reporter and blocks until its results are flushed
@@ -156,6 +161,113 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// ExecReporter returns an ExecReporter associated with t.
+func (r *Reporter) ExecReporter(t T) *ExecReporter {
+	return &ExecReporter{r: r, testName: t.Name()}
+}
+
+// ExecReporter returns an ExecReporter associated with the same test as r,
+// for the commands that chains run in containers.
+func (r *RelayerExecReporter) ExecReporter() *ExecReporter {
+	return &ExecReporter{r: r.r, testName: r.testName}
+}
+
+// ExecReporter records commands executed in chain and helper containers.
+// It satisfies the dockerutil.ExecReporter interface.
+// Instances of ExecReporter must be retrieved through (*Reporter).ExecReporter.
+type ExecReporter struct {
+	r        *Reporter
+	testName string
+}
+
+// TrackExec tracks the execution of an individual command in a container.
+func (r *ExecReporter) TrackExec(
+	containerName, image string,
+	command []string,
+	stdout, stderr string,
+	exitCode int,
+	startedAt, finishedAt time.Time,
+	err error,
+) {
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+	r.r.in <- ExecMessage{
+		Name:          r.testName,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+		ContainerName: containerName,
+		Image:         image,
+		Command:       command,
+		Stdout:        stdout,
+		Stderr:        stderr,
+		ExitCode:      exitCode,
+		Error:         errMsg,
+	}
+}
+
+// ContainerStatsReporter returns a ContainerStatsReporter associated with t.
+// Only the name of t is used, so t need not implement the full T interface.
+func (r *Reporter) ContainerStatsReporter(t interface{ Name() string }) *ContainerStatsReporter {
+	return &ContainerStatsReporter{r: r, testName: t.Name()}
+}
+
+// ContainerStatsReporter records samples of container resource usage.
+// Instances of ContainerStatsReporter must be retrieved through (*Reporter).ContainerStatsReporter.
+type ContainerStatsReporter struct {
+	r        *Reporter
+	testName string
+}
+
+// TrackContainerStats tracks a single sample of a container's resource usage.
+func (r *ContainerStatsReporter) TrackContainerStats(
+	containerName string,
+	sampledAt time.Time,
+	cpuPercent float64,
+	cpuThrottledPeriods uint64,
+	memoryUsage, memoryLimit uint64,
+	pidsCurrent, pidsLimit uint64,
+	networkRx, networkTx uint64,
+) {
+	r.r.in <- ContainerStatsMessage{
+		Name:                r.testName,
+		ContainerName:       containerName,
+		SampledAt:           sampledAt,
+		CPUPercent:          cpuPercent,
+		CPUThrottledPeriods: cpuThrottledPeriods,
+		MemoryUsage:         memoryUsage,
+		MemoryLimit:         memoryLimit,
+		PidsCurrent:         pidsCurrent,
+		PidsLimit:           pidsLimit,
+		NetworkRx:           networkRx,
+		NetworkTx:           networkTx,
+	}
+}
+
+// ContainerLogReporter returns a ContainerLogReporter associated with t.
+func (r *Reporter) ContainerLogReporter(t T) *ContainerLogReporter {
+	return &ContainerLogReporter{r: r, testName: t.Name()}
+}
+
+// ContainerLogReporter records the output of containers.
+// Instances of ContainerLogReporter must be retrieved through (*Reporter).ContainerLogReporter.
+type ContainerLogReporter struct {
+	r        *Reporter
+	testName string
+}
+
+// TrackContainerLog tracks a single line of container output.
+func (r *ContainerLogReporter) TrackContainerLog(containerName, stream string, when time.Time, line string) {
+	r.r.in <- ContainerLogMessage{
+		Name:          r.testName,
+		ContainerName: containerName,
+		Stream:        stream,
+		When:          when,
+		Line:          line,
+	}
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //
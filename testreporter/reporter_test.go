@@ -3,6 +3,7 @@ package testreporter_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"testing"
 	"time"
@@ -259,6 +260,121 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_Exec(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	execStartedAt := time.Now()
+	execFinishedAt := execStartedAt.Add(time.Second)
+	r.ExecReporter(mt).TrackExec(
+		"my_container", "my_image:latest",
+		[]string{"gaiad", "fake_command"},
+		"", "",
+		1,
+		execStartedAt, execFinishedAt,
+		errors.New("exit code 1: fake failure"),
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.ExecMessage{
+		Name:          "my_test",
+		StartedAt:     execStartedAt,
+		FinishedAt:    execFinishedAt,
+		ContainerName: "my_container",
+		Image:         "my_image:latest",
+		Command:       []string{"gaiad", "fake_command"},
+		ExitCode:      1,
+		Error:         "exit code 1: fake failure",
+	}, msgs[2].(testreporter.ExecMessage))
+	require.Empty(t, diff)
+}
+
+func TestReporter_ContainerStats(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	sampledAt := time.Now()
+	r.ContainerStatsReporter(mt).TrackContainerStats(
+		"my_container",
+		sampledAt,
+		150.5,
+		3,
+		64<<20, 256<<20,
+		10, 100,
+		4096, 8192,
+	)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.ContainerStatsMessage{
+		Name:                "my_test",
+		ContainerName:       "my_container",
+		SampledAt:           sampledAt,
+		CPUPercent:          150.5,
+		CPUThrottledPeriods: 3,
+		MemoryUsage:         64 << 20,
+		MemoryLimit:         256 << 20,
+		PidsCurrent:         10,
+		PidsLimit:           100,
+		NetworkRx:           4096,
+		NetworkTx:           8192,
+	}, msgs[2].(testreporter.ContainerStatsMessage))
+	require.Empty(t, diff)
+}
+
+func TestReporter_ContainerLog(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+
+	r.TrackTest(mt)
+
+	when := time.Now()
+	r.ContainerLogReporter(mt).TrackContainerLog("my_container", "stdout", when, "relayer started")
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.ContainerLogMessage{
+		Name:          "my_test",
+		ContainerName: "my_container",
+		Stream:        "stdout",
+		When:          when,
+		Line:          "relayer started",
+	}, msgs[2].(testreporter.ContainerLogMessage))
+	require.Empty(t, diff)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
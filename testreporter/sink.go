@@ -0,0 +1,74 @@
+package testreporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Sink receives the messages tracked by a Reporter, in order.
+// Write is never called concurrently, and Close is called once after the last message.
+type Sink interface {
+	Write(Message) error
+	Close() error
+}
+
+// JSONSink writes a JSON-lines stream of messages, as read by Convert.
+type JSONSink struct {
+	w   io.WriteCloser
+	enc *json.Encoder
+}
+
+// NewJSONSink returns a JSONSink writing to w, closing w when the sink is closed.
+func NewJSONSink(w io.WriteCloser) *JSONSink {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONSink{w: w, enc: enc}
+}
+
+func (s *JSONSink) Write(m Message) error {
+	return s.enc.Encode(JSONMessage(m))
+}
+
+func (s *JSONSink) Close() error {
+	return s.w.Close()
+}
+
+// closeSinks closes all sinks, returning the first error.
+func closeSinks(sinks []Sink) error {
+	var firstErr error
+	for _, s := range sinks {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Convert reads a JSON-lines report, as written by NewReporter, and writes its messages to each of the sinks,
+// e.g. to produce a JUnit XML or HTML report from an existing JSON report.
+// The sinks are closed when Convert returns.
+func Convert(r io.Reader, sinks ...Sink) (err error) {
+	defer func() {
+		if closeErr := closeSinks(sinks); err == nil {
+			err = closeErr
+		}
+	}()
+
+	dec := json.NewDecoder(r)
+	for {
+		var wm WrappedMessage
+		if err := dec.Decode(&wm); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("decode message: %w", err)
+		}
+
+		for _, s := range sinks {
+			if err := s.Write(wm.Message); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// trackSuite tracks a passing test with relayer and chain commands, a failing subtest, a skipped test,
+// and a test that does not finish before the reporter is closed.
+func trackSuite(t *testing.T, r *testreporter.Reporter) {
+	t.Helper()
+
+	passing := mocktesting.NewT("TestRelayer")
+	r.TrackTest(passing)
+	now := time.Now()
+	r.RelayerExecReporter(passing).TrackRelayerExec(
+		"rly-container", []string{"rly", "tx", "link", "gaia-osmosis"},
+		"linked <ok>\n", "warning\n", 0, now, now.Add(time.Millisecond), nil,
+	)
+	r.RelayerExecReporter(passing).TrackRelayerExec(
+		"rly-container", []string{"rly", "start"},
+		"", "", 1, now, now.Add(time.Millisecond), errors.New("exit status 1"),
+	)
+	r.ExecReporter(passing).TrackExec(
+		"gaia-exec-123", "gaia:v7.0.0", []string{"gaiad", "status"},
+		"{}\n", "", 0, now.Add(-time.Millisecond), now, nil,
+	)
+	passing.RunCleanups()
+
+	failing := mocktesting.NewT("TestRelayer/subtest")
+	failing.Simulate(func() {
+		r.TrackTest(failing)
+		require.Fail(r.TestifyT(failing), "forced failure")
+	})
+
+	skipped := mocktesting.NewT("TestSkipped")
+	skipped.Simulate(func() {
+		r.TrackTest(skipped)
+		r.TrackSkip(skipped, "no docker")
+	})
+
+	r.TrackTest(mocktesting.NewT("TestHangs"))
+
+	require.NoError(t, r.Close())
+}
+
+type junitReport struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Errors   int `xml:"errors,attr"`
+	Skipped  int `xml:"skipped,attr"`
+	Suites   []struct {
+		Name      string `xml:"name,attr"`
+		Tests     int    `xml:"tests,attr"`
+		TestCases []struct {
+			Name      string `xml:"name,attr"`
+			Classname string `xml:"classname,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+				Text    string `xml:",chardata"`
+			} `xml:"failure"`
+			Error *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"error"`
+			Skipped *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"skipped"`
+			SystemOut string `xml:"system-out"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func TestJUnitSink(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	trackSuite(t, testreporter.NewSinkReporter(testreporter.NewJUnitSink(nopCloser{Writer: buf})))
+
+	require.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitReport
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	require.Equal(t, 4, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Equal(t, 1, report.Errors)
+	require.Equal(t, 1, report.Skipped)
+
+	require.Len(t, report.Suites, 3)
+	relayerSuite := report.Suites[0]
+	require.Equal(t, "TestRelayer", relayerSuite.Name)
+	require.Equal(t, 2, relayerSuite.Tests)
+
+	passing := relayerSuite.TestCases[0]
+	require.Equal(t, "TestRelayer", passing.Name)
+	require.Nil(t, passing.Failure)
+	require.Contains(t, passing.SystemOut, "$ rly tx link gaia-osmosis\n# container=rly-container exit_code=0 duration=1ms\nlinked <ok>\n# stderr:\nwarning\n")
+	require.Contains(t, passing.SystemOut, "$ rly start\n")
+	require.Contains(t, passing.SystemOut, "# error: exit status 1\n")
+	// Ordered by start time, regardless of the order the commands were tracked in.
+	require.True(t, strings.HasPrefix(passing.SystemOut, "$ gaiad status\n# image=gaia:v7.0.0 container=gaia-exec-123 exit_code=0 duration=1ms\n{}\n"))
+
+	failing := relayerSuite.TestCases[1]
+	require.Equal(t, "TestRelayer/subtest", failing.Name)
+	require.Equal(t, "TestRelayer", failing.Classname)
+	require.NotNil(t, failing.Failure)
+	require.Contains(t, failing.Failure.Text, "forced failure")
+
+	require.Equal(t, "no docker", report.Suites[1].TestCases[0].Skipped.Message)
+	require.NotNil(t, report.Suites[2].TestCases[0].Error)
+}
+
+func TestHTMLSink(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	trackSuite(t, testreporter.NewSinkReporter(testreporter.NewHTMLSink(nopCloser{Writer: buf})))
+
+	out := buf.String()
+	require.Contains(t, out, "1 passed, 1 failed, 1 skipped, 1 unfinished.")
+	require.Contains(t, out, `<div class="bar passed" style="left: `)
+	require.Contains(t, out, `<div class="exec failed" style="left: `)
+	require.NotContains(t, out, "ZgotmplZ") // Rejected by html/template.
+	require.Contains(t, out, "<summary><code>rly tx link gaia-osmosis</code></summary>")
+	require.Contains(t, out, "<summary><code>gaiad status</code></summary>")
+	require.Contains(t, out, "linked &lt;ok&gt;")
+	require.Contains(t, out, "Skipped: no docker")
+	require.Contains(t, out, "forced failure")
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	var jsonReport, junitReport bytes.Buffer
+	trackSuite(t, testreporter.NewSinkReporter(
+		testreporter.NewJSONSink(nopCloser{Writer: &jsonReport}),
+		testreporter.NewJUnitSink(nopCloser{Writer: &junitReport}),
+	))
+
+	var converted bytes.Buffer
+	require.NoError(t, testreporter.Convert(bytes.NewReader(jsonReport.Bytes()), testreporter.NewJUnitSink(nopCloser{Writer: &converted})))
+	// Durations of the live report use monotonic clock readings, which are not encoded to JSON.
+	durations := regexp.MustCompile(`time="[0-9.]+"`)
+	require.Equal(t,
+		durations.ReplaceAllString(junitReport.String(), `time=""`),
+		durations.ReplaceAllString(converted.String(), `time=""`),
+	)
+
+	err := testreporter.Convert(strings.NewReader(`{"Type":"Unknown","Message":{}}`), testreporter.NewJUnitSink(nopCloser{Writer: new(bytes.Buffer)}))
+	require.ErrorContains(t, err, `unknown message type "Unknown"`)
+}